@@ -18,4 +18,14 @@ integration {
     name = "Oracle Cloud Infrastructure Classic Compute"
     slug = "classic"
   }
+  component {
+    type = "data-source"
+    name = "Oracle Plugin"
+    slug = "plugin"
+  }
+  component {
+    type = "data-source"
+    name = "Oracle OCI Fault Domains"
+    slug = "oci-fault-domains"
+  }
 }
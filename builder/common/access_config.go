@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"errors"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/hashicorp/packer-plugin-sdk/pathing"
+	ocicommon "github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// APIKeyConfig holds the options to access OCI with an API signing key, as
+// shared by the oracle-oci builder and data sources. The options that are not
+// set are read from a profile of the OCI configuration file.
+type APIKeyConfig struct {
+	ConfigFile        string
+	ConfigFileProfile string
+	UserID            string
+	TenancyID         string
+	Region            string
+	Fingerprint       string
+	KeyFile           string
+	PassPhrase        string
+}
+
+// ConfigurationProvider composes the options of c with the profile of the
+// configuration file, the options taking precedence. The configuration file
+// is ignored when it cannot be read. The region defaults to defaultRegion
+// when neither sets it.
+func (c APIKeyConfig) ConfigurationProvider(defaultRegion string) (ocicommon.ConfigurationProvider, error) {
+	var keyContent []byte
+	if c.KeyFile != "" {
+		path, err := pathing.ExpandUser(c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		// Read API signing key
+		keyContent, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fileProvider, _ := ocicommon.ConfigurationProviderFromFileWithProfile(c.ConfigFile, c.ConfigFileProfile, c.PassPhrase)
+	region := c.Region
+	if region == "" {
+		if fileProvider != nil {
+			region, _ = fileProvider.Region()
+		}
+		if region == "" {
+			region = defaultRegion
+		}
+	}
+
+	providers := []ocicommon.ConfigurationProvider{
+		ocicommon.NewRawConfigurationProvider(c.TenancyID, c.UserID, region, c.Fingerprint, string(keyContent), &c.PassPhrase),
+	}
+	if fileProvider != nil {
+		providers = append(providers, fileProvider)
+	}
+
+	return ocicommon.ComposingConfigurationProvider(providers)
+}
+
+// DefaultOCISettingsPath returns the path of the OCI configuration file of
+// the current user, ~/.oci/config, when it exists.
+func DefaultOCISettingsPath() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+
+	if u.HomeDir == "" {
+		return "", errors.New("Unable to determine the home directory for the current user.")
+	}
+
+	path := filepath.Join(u.HomeDir, ".oci", "config")
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ampereShapes are the Ampere shapes an image built on an Ampere shape is
+// made compatible with. A custom image is otherwise only compatible with the
+// shape of the instance it was created from.
+var ampereShapes = []string{
+	"BM.Standard.A1.160",
+	"VM.Standard.A1.Flex",
+	"VM.Standard.A2.Flex",
+}
+
+var ampereShapeRe = regexp.MustCompile(`^(VM|BM)\.Standard\.A\d+\.`)
+
+// isAmpereShape reports whether shape is an Ampere (aarch64) shape.
+func isAmpereShape(shape string) bool {
+	return ampereShapeRe.MatchString(shape)
+}
+
+// isShapeUnavailableError reports whether err is the error returned when
+// adding the compatibility with a shape not offered in the region.
+func isShapeUnavailableError(err error) bool {
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		return false
+	}
+
+	if reqErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+	return strings.Contains(strings.ToLower(reqErr.Error()), "shape not available")
+}
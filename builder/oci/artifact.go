@@ -7,8 +7,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"time"
 
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
 	"github.com/hashicorp/packer-plugin-sdk/packer/registry/image"
 	"github.com/oracle/oci-go-sdk/v65/core"
 )
@@ -18,6 +21,7 @@ type Artifact struct {
 	Image  core.Image
 	Region string
 	driver Driver
+	files  []string
 
 	// StateData should store data such as GeneratedData
 	// to be shared with post-processors
@@ -29,10 +33,11 @@ func (a *Artifact) BuilderId() string {
 	return BuilderId
 }
 
-// Files lists the files associated with an artifact. We don't have any files
-// as the custom image is stored server side.
+// Files lists the files associated with an artifact. The custom image is
+// stored server side, so these are only the first boot validation bundle and
+// the manifest, if any.
 func (a *Artifact) Files() []string {
-	return nil
+	return a.files
 }
 
 // Id returns the OCID of the associated Image.
@@ -46,10 +51,37 @@ func (a *Artifact) String() string {
 		displayName = *a.Image.DisplayName
 	}
 
-	return fmt.Sprintf(
+	s := fmt.Sprintf(
 		"An image was created: '%v' (OCID: %v) in region '%v'",
 		displayName, *a.Image.Id, a.Region,
 	)
+	if uri, ok := a.StateData["export_uri"].(string); ok {
+		s += fmt.Sprintf("\nThe image was exported to %v", uri)
+	}
+	if publicationID, ok := a.StateData["marketplace_publication_id"].(string); ok {
+		s += fmt.Sprintf("\nThe image was published to the Marketplace (OCID: %v)", publicationID)
+	}
+	if backupID, ok := a.StateData["boot_volume_backup_id"].(string); ok {
+		s += fmt.Sprintf("\nThe boot volume was backed up (OCID: %v)", backupID)
+	}
+	if shareURL, ok := a.StateData["share_url"].(string); ok {
+		expires, _ := a.StateData["share_url_expires"].(time.Time)
+		s += fmt.Sprintf("\nOther tenancies can import the image until %v from %v", expires.Format(time.RFC3339), shareURL)
+	}
+	copies := a.regionImages()
+	names, _ := a.StateData["region_image_names"].(map[string]string)
+	for _, region := range sortedKeys(copies) {
+		if name, ok := names[region]; ok {
+			s += fmt.Sprintf("\nThe image was copied to region '%v' as '%v' (OCID: %v)", region, name, copies[region])
+		} else {
+			s += fmt.Sprintf("\nThe image was copied to region '%v' (OCID: %v)", region, copies[region])
+		}
+	}
+	compartmentCopies := a.compartmentImages()
+	for _, compartment := range sortedKeys(compartmentCopies) {
+		s += fmt.Sprintf("\nThe image was copied to compartment '%v' (OCID: %v)", compartment, compartmentCopies[compartment])
+	}
+	return s
 }
 
 func (a *Artifact) State(name string) interface{} {
@@ -60,9 +92,61 @@ func (a *Artifact) State(name string) interface{} {
 	return a.StateData[name]
 }
 
-// Destroy deletes the custom image associated with the artifact.
+// regionImages returns the OCIDs of the copies of the image by region.
+func (a *Artifact) regionImages() map[string]string {
+	copies, _ := a.StateData["region_images"].(map[string]string)
+	return copies
+}
+
+// compartmentImages returns the OCIDs of the copies of the image by
+// compartment, in the region of the image.
+func (a *Artifact) compartmentImages() map[string]string {
+	copies, _ := a.StateData["compartment_images"].(map[string]string)
+	return copies
+}
+
+// sortedKeys returns the regions or compartments of the image copies in a
+// stable order.
+func sortedKeys(copies map[string]string) []string {
+	keys := make([]string, 0, len(copies))
+	for key := range copies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Destroy deletes the custom image associated with the artifact, along with
+// its copies in other regions and compartments, its Marketplace publication
+// and the boot volume backup.
 func (a *Artifact) Destroy() error {
-	return a.driver.DeleteImage(context.TODO(), *a.Image.Id)
+	var errs error
+	if publicationID, ok := a.StateData["marketplace_publication_id"].(string); ok {
+		if err := a.driver.DeleteMarketplacePublication(context.TODO(), publicationID); err != nil {
+			errs = packersdk.MultiErrorAppend(errs, err)
+		}
+	}
+	if backupID, ok := a.StateData["boot_volume_backup_id"].(string); ok {
+		if err := a.driver.DeleteBootVolumeBackup(context.TODO(), backupID); err != nil {
+			errs = packersdk.MultiErrorAppend(errs, err)
+		}
+	}
+	copies := a.regionImages()
+	for _, region := range sortedKeys(copies) {
+		if err := a.driver.DeleteImageInRegion(context.TODO(), region, copies[region]); err != nil {
+			errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("region %s: %s", region, err))
+		}
+	}
+	compartmentCopies := a.compartmentImages()
+	for _, compartment := range sortedKeys(compartmentCopies) {
+		if err := a.driver.DeleteImage(context.TODO(), compartmentCopies[compartment]); err != nil {
+			errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("compartment %s: %s", compartment, err))
+		}
+	}
+	if err := a.driver.DeleteImage(context.TODO(), *a.Image.Id); err != nil {
+		errs = packersdk.MultiErrorAppend(errs, err)
+	}
+	return errs
 }
 
 func (a *Artifact) buildHCPackerRegistryMetadata() interface{} {
@@ -81,6 +165,18 @@ func (a *Artifact) buildHCPackerRegistryMetadata() interface{} {
 		labels["launch_mode"] = string(a.Image.LaunchMode)
 	}
 
+	if gpus, ok := a.StateData["gpus"].(int); ok {
+		labels["gpus"] = strconv.Itoa(gpus)
+	}
+
+	if description, ok := a.StateData["gpu_description"].(string); ok {
+		labels["gpu_description"] = description
+	}
+
+	if uri, ok := a.StateData["export_uri"].(string); ok {
+		labels["export_uri"] = uri
+	}
+
 	if a.Image.OperatingSystem != nil {
 		labels["operating_system"] = *a.Image.OperatingSystem
 	}
@@ -89,12 +185,34 @@ func (a *Artifact) buildHCPackerRegistryMetadata() interface{} {
 		labels["operating_system_version"] = *a.Image.OperatingSystemVersion
 	}
 
-	img, err := image.FromArtifact(a, image.WithRegion(a.Region), image.WithSourceID(*a.Image.BaseImageId), image.SetLabels(labels))
+	// Images built from a boot volume have no base image
+	var sourceID string
+	if a.Image.BaseImageId != nil {
+		sourceID = *a.Image.BaseImageId
+	}
+
+	img, err := image.FromArtifact(a, image.WithRegion(a.Region), image.WithSourceID(sourceID), image.SetLabels(labels))
 
 	if err != nil {
 		log.Printf("[TRACE] error encountered when creating HCP Packer registry image for artifact: %s", err)
 		return nil
 	}
 
-	return img
+	copies := a.regionImages()
+	if len(copies) == 0 {
+		return img
+	}
+
+	// Each copy is an image of the artifact in its region
+	images := []*image.Image{img}
+	for _, region := range sortedKeys(copies) {
+		copyImg, err := image.FromArtifact(a, image.WithID(copies[region]), image.WithRegion(region), image.WithSourceID(sourceID), image.SetLabels(labels))
+		if err != nil {
+			log.Printf("[TRACE] error encountered when creating HCP Packer registry image for artifact: %s", err)
+			return nil
+		}
+		images = append(images, copyImg)
+	}
+
+	return images
 }
@@ -5,7 +5,9 @@ package oci
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
 	"github.com/hashicorp/packer-plugin-sdk/packer/registry/image"
@@ -20,6 +22,14 @@ func TestArtifactImpl(t *testing.T) {
 	}
 }
 
+func TestBootVolumeBackupArtifactImpl(t *testing.T) {
+	var raw interface{}
+	raw = &BootVolumeBackupArtifact{}
+	if _, ok := raw.(packersdk.Artifact); !ok {
+		t.Fatalf("BootVolumeBackupArtifact should be artifact")
+	}
+}
+
 func TestArtifactState_StateData(t *testing.T) {
 	expectedData := "this is the data"
 	artifact := &Artifact{
@@ -81,6 +91,159 @@ func TestArtifactState_hcpPackerRegistryMetadata(t *testing.T) {
 	}
 }
 
+func TestArtifactState_hcpPackerRegistryMetadataWithoutBaseImage(t *testing.T) {
+	artifact := &Artifact{
+		Image: core.Image{
+			Id: stringPtr("ocid1.image.oc1.phx.aaa"),
+		},
+		Region: "us-phoenix-1",
+	}
+
+	result, ok := artifact.State(image.ArtifactStateURI).(*image.Image)
+	if !ok {
+		t.Fatalf("Bad: no HCP registry metadata")
+	}
+	if result.SourceImageID != "" {
+		t.Fatalf("Bad: unexpected source image %q", result.SourceImageID)
+	}
+}
+
+func TestArtifactString_exportURI(t *testing.T) {
+	artifact := &Artifact{
+		Image:  core.Image{Id: stringPtr("ocid1.image.oc1.phx.aaa")},
+		Region: "us-phoenix-1",
+		StateData: map[string]interface{}{
+			"export_uri": "https://objectstorage.us-phoenix-1.oraclecloud.com/n/ns/b/images/o/golden",
+		},
+	}
+
+	if !strings.Contains(artifact.String(), "exported to https://objectstorage.us-phoenix-1.oraclecloud.com/n/ns/b/images/o/golden") {
+		t.Fatalf("Bad: artifact string %q should include the export URI", artifact.String())
+	}
+}
+
+func TestArtifactState_hcpPackerRegistryMetadataWithCopies(t *testing.T) {
+	artifact := &Artifact{
+		Image: core.Image{
+			Id: stringPtr("ocid1.image.oc1.phx.aaa"),
+		},
+		Region: "us-phoenix-1",
+		StateData: map[string]interface{}{
+			"region_images": map[string]string{
+				"us-ashburn-1":   "ocid1.image.oc1.iad.aaa",
+				"eu-frankfurt-1": "ocid1.image.oc1.fra.aaa",
+			},
+		},
+	}
+
+	result, ok := artifact.State(image.ArtifactStateURI).([]*image.Image)
+	if !ok || len(result) != 3 {
+		t.Fatalf("Bad: HCP registry metadata should have an image per region: %#v", artifact.State(image.ArtifactStateURI))
+	}
+
+	regions := map[string]string{}
+	for _, img := range result {
+		regions[img.ProviderRegion] = img.ImageID
+	}
+	expected := map[string]string{
+		"us-phoenix-1":   "ocid1.image.oc1.phx.aaa",
+		"us-ashburn-1":   "ocid1.image.oc1.iad.aaa",
+		"eu-frankfurt-1": "ocid1.image.oc1.fra.aaa",
+	}
+	if !reflect.DeepEqual(regions, expected) {
+		t.Fatalf("Bad: HCP registry images were %v instead of %v", regions, expected)
+	}
+}
+
+func TestArtifactDestroy_copies(t *testing.T) {
+	driver := &driverMock{}
+	artifact := &Artifact{
+		Image:  core.Image{Id: stringPtr("ocid1.image.oc1.phx.aaa")},
+		Region: "us-phoenix-1",
+		driver: driver,
+		StateData: map[string]interface{}{
+			"region_images":      map[string]string{"us-ashburn-1": "ocid1.image.oc1.iad.aaa"},
+			"region_image_names": map[string]string{"us-ashburn-1": "golden-iad"},
+		},
+	}
+
+	if !strings.Contains(artifact.String(), "copied to region 'us-ashburn-1' as 'golden-iad' (OCID: ocid1.image.oc1.iad.aaa)") {
+		t.Fatalf("Bad: artifact string %q should include the image copy", artifact.String())
+	}
+
+	if err := artifact.Destroy(); err != nil {
+		t.Fatalf("Unexpected error destroying artifact: %s", err)
+	}
+	if driver.DeleteImageID != "ocid1.image.oc1.phx.aaa" {
+		t.Fatalf("Bad: should've deleted the image: %q", driver.DeleteImageID)
+	}
+	if !reflect.DeepEqual(driver.DeleteImageInRegionIDs, []string{"ocid1.image.oc1.iad.aaa"}) {
+		t.Fatalf("Bad: should've deleted the image copies: %q", driver.DeleteImageInRegionIDs)
+	}
+}
+
+func TestArtifactDestroy_compartmentCopies(t *testing.T) {
+	driver := &driverMock{}
+	artifact := &Artifact{
+		Image:  core.Image{Id: stringPtr("ocid1.image.oc1.phx.aaa")},
+		Region: "us-phoenix-1",
+		driver: driver,
+		StateData: map[string]interface{}{
+			"compartment_images": map[string]string{"ocid1.compartment.prod": "ocid1.image.oc1.phx.bbb"},
+		},
+	}
+
+	if !strings.Contains(artifact.String(), "copied to compartment 'ocid1.compartment.prod' (OCID: ocid1.image.oc1.phx.bbb)") {
+		t.Fatalf("Bad: artifact string %q should include the image copy", artifact.String())
+	}
+
+	if err := artifact.Destroy(); err != nil {
+		t.Fatalf("Unexpected error destroying artifact: %s", err)
+	}
+	if !reflect.DeepEqual(driver.DeleteImageIDs, []string{"ocid1.image.oc1.phx.bbb", "ocid1.image.oc1.phx.aaa"}) {
+		t.Fatalf("Bad: should've deleted the image and its copies: %q", driver.DeleteImageIDs)
+	}
+}
+
+func TestArtifactString_shareURL(t *testing.T) {
+	artifact := &Artifact{
+		Image:  core.Image{Id: stringPtr("ocid1.image.oc1.phx.aaa")},
+		Region: "us-phoenix-1",
+		StateData: map[string]interface{}{
+			"share_url":         "https://objectstorage.us-phoenix-1.oraclecloud.com/p/token/n/ns/b/images/o/golden",
+			"share_url_expires": time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	expected := "import the image until 2024-01-08T00:00:00Z from https://objectstorage.us-phoenix-1.oraclecloud.com/p/token/n/ns/b/images/o/golden"
+	if !strings.Contains(artifact.String(), expected) {
+		t.Fatalf("Bad: artifact string %q should include the share URL", artifact.String())
+	}
+}
+
+func TestArtifactDestroy_bootVolumeBackup(t *testing.T) {
+	driver := &driverMock{}
+	artifact := &Artifact{
+		Image:  core.Image{Id: stringPtr("ocid1.image.oc1.phx.aaa")},
+		Region: "us-phoenix-1",
+		driver: driver,
+		StateData: map[string]interface{}{
+			"boot_volume_backup_id": "ocid1.bootvolumebackup.oc1.phx.aaa",
+		},
+	}
+
+	if !strings.Contains(artifact.String(), "boot volume was backed up (OCID: ocid1.bootvolumebackup.oc1.phx.aaa)") {
+		t.Fatalf("Bad: artifact string %q should include the boot volume backup", artifact.String())
+	}
+
+	if err := artifact.Destroy(); err != nil {
+		t.Fatalf("Unexpected error destroying artifact: %s", err)
+	}
+	if driver.DeleteBootVolumeBackupID != "ocid1.bootvolumebackup.oc1.phx.aaa" {
+		t.Fatalf("Bad: should've deleted the boot volume backup: %q", driver.DeleteBootVolumeBackupID)
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
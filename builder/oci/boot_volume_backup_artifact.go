@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+)
+
+// BootVolumeBackupArtifact is an artifact implementation that contains a
+// boot volume backup, built with boot_volume_backup and skip_create_image.
+type BootVolumeBackupArtifact struct {
+	BackupID string
+	Region   string
+	driver   Driver
+
+	// StateData should store data such as GeneratedData
+	// to be shared with post-processors
+	StateData map[string]interface{}
+}
+
+// BuilderId uniquely identifies the builder.
+func (a *BootVolumeBackupArtifact) BuilderId() string {
+	return BuilderId
+}
+
+// Files lists the files associated with an artifact. We don't have any files
+// as the backup is stored server side.
+func (a *BootVolumeBackupArtifact) Files() []string {
+	return nil
+}
+
+// Id returns the OCID of the associated boot volume backup.
+func (a *BootVolumeBackupArtifact) Id() string {
+	return a.BackupID
+}
+
+func (a *BootVolumeBackupArtifact) String() string {
+	return fmt.Sprintf(
+		"A boot volume backup was created: %v in region '%v'",
+		a.BackupID, a.Region,
+	)
+}
+
+func (a *BootVolumeBackupArtifact) State(name string) interface{} {
+	return a.StateData[name]
+}
+
+// Destroy deletes the boot volume backup associated with the artifact.
+func (a *BootVolumeBackupArtifact) Destroy() error {
+	return a.driver.DeleteBootVolumeBackup(context.TODO(), a.BackupID)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+// buildFailureClasses are the classes of transient infrastructure failures
+// after which a build can be retried with build_retry_attempts.
+var buildFailureClasses = []string{"capacity", "preemption", "maintenance"}
+
+// buildFailureClass classifies the failure of a build from its final state,
+// returning one of buildFailureClasses or "" if the failure is not known to
+// be transient.
+func buildFailureClass(state multistep.StateBag) string {
+	rawErr, ok := state.GetOk("error")
+	if !ok {
+		return ""
+	}
+
+	// The instance terminated for exceeding max_run_duration is not a
+	// preempted one
+	if _, exceeded := state.GetOk("max_run_duration_exceeded"); exceeded {
+		return ""
+	}
+
+	// Retrying after the image was created would publish it twice
+	if _, ok := state.GetOk("image"); ok {
+		return ""
+	}
+
+	if isCapacityError(rawErr.(error)) {
+		return "capacity"
+	}
+
+	// The lifecycle state the instance was found in once the build failed,
+	// as recorded by stepCreateInstance before terminating it.
+	if instanceState, ok := state.GetOk("instance_failure_state"); ok {
+		switch instanceState.(string) {
+		case "TERMINATING", "TERMINATED":
+			return "preemption"
+		case "STOPPING", "STOPPED":
+			// Not maintenance if the build stopped the instance itself
+			if _, ok := state.GetOk("instance_stopped_by_build"); ok {
+				return ""
+			}
+			return "maintenance"
+		case "MOVING":
+			return "maintenance"
+		}
+	}
+
+	return ""
+}
+
+// isCapacityError reports whether err is an OCI API error caused by a lack
+// of host capacity.
+func isCapacityError(err error) bool {
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		return false
+	}
+
+	msg := strings.ToLower(reqErr.Error())
+	return strings.Contains(msg, "out of host capacity") || strings.Contains(msg, "out of capacity")
+}
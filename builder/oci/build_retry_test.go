@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestBuildFailureClass(t *testing.T) {
+	capacityErr := newRequestError("LaunchInstance", nil, testServiceError{
+		statusCode: 500,
+		code:       "InternalError",
+		message:    "Out of host capacity.",
+	})
+
+	tests := []struct {
+		name          string
+		err           error
+		instanceState string
+		stoppedBy     bool
+		imageCreated  bool
+		expected      string
+	}{
+		{name: "NoError", expected: ""},
+		{name: "Capacity", err: fmt.Errorf("Problem creating instance: %w", capacityErr), expected: "capacity"},
+		{name: "Preemption", err: errors.New("connection lost"), instanceState: "TERMINATED", expected: "preemption"},
+		{name: "Maintenance", err: errors.New("connection lost"), instanceState: "STOPPED", expected: "maintenance"},
+		{name: "Other", err: errors.New("provisioner failed"), instanceState: "RUNNING", expected: ""},
+		{name: "StoppedByBuild", err: errors.New("image creation failed"), instanceState: "STOPPED", stoppedBy: true, expected: ""},
+		{name: "ImageCreated", err: fmt.Errorf("Error exporting image: %w", capacityErr), instanceState: "STOPPED", imageCreated: true, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := new(multistep.BasicStateBag)
+			if tt.err != nil {
+				state.Put("error", tt.err)
+			}
+			if tt.instanceState != "" {
+				state.Put("instance_failure_state", tt.instanceState)
+			}
+			if tt.stoppedBy {
+				state.Put("instance_stopped_by_build", true)
+			}
+			if tt.imageCreated {
+				state.Put("image", core.Image{})
+			}
+
+			if class := buildFailureClass(state); class != tt.expected {
+				t.Fatalf("expected class %q, got %q", tt.expected, class)
+			}
+		})
+	}
+}
@@ -29,6 +29,10 @@ const ociAPIVersion = "20160918"
 type Builder struct {
 	config Config
 	runner multistep.Runner
+
+	// keepInstance keeps the instance running after a successful build
+	// instead of capturing an image from it.
+	keepInstance bool
 }
 
 func (b *Builder) ConfigSpec() hcldec.ObjectSpec { return b.config.FlatMapstructure().HCL2Spec() }
@@ -39,78 +43,234 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 		return nil, nil, err
 	}
 
-	return nil, nil, nil
+	return []string{"ISCSIAttachCommands", "ISCSIDetachCommands"}, b.config.warnings, nil
 }
 
 func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook) (packersdk.Artifact, error) {
-	driver, err := NewDriverOCI(&b.config)
+	driver, state, err := b.run(ctx, ui, hook)
 	if err != nil {
 		return nil, err
 	}
 
-	// Populate the state bag
+	region, err := b.config.configProvider.Region()
+	if err != nil {
+		return nil, err
+	}
+
+	image, ok := state.GetOk("image")
+	if !ok {
+		// With skip_create_image the boot volume backup is the artifact
+		if backupID, ok := state.GetOk("boot_volume_backup_id"); ok {
+			return &BootVolumeBackupArtifact{
+				BackupID:  backupID.(string),
+				Region:    region,
+				driver:    driver,
+				StateData: map[string]interface{}{"generated_data": state.Get("generated_data")},
+			}, nil
+		}
+		return nil, err
+	}
+
+	var files []string
+	if rawFiles, ok := state.GetOk("first_boot_validation_files"); ok {
+		files = rawFiles.([]string)
+	}
+	if _, ok := state.GetOk("manifest"); ok {
+		files = append(files, b.config.ManifestPath)
+	}
+
+	stateData := map[string]interface{}{"generated_data": state.Get("generated_data")}
+	if uri, ok := state.GetOk("image_export_uri"); ok {
+		stateData["export_uri"] = uri
+	}
+	if shareURL, ok := state.GetOk("image_share_url"); ok {
+		stateData["share_url"] = shareURL
+		stateData["share_url_expires"] = state.Get("image_share_url_expires")
+	}
+	if copies, ok := state.GetOk("region_images"); ok {
+		stateData["region_images"] = copies
+	}
+	if names, ok := state.GetOk("region_image_names"); ok {
+		stateData["region_image_names"] = names
+	}
+	if copies, ok := state.GetOk("compartment_images"); ok {
+		stateData["compartment_images"] = copies
+	}
+	if manifest, ok := state.GetOk("manifest"); ok {
+		stateData["manifest"] = manifest
+	}
+	if publicationID, ok := state.GetOk("marketplace_publication_id"); ok {
+		stateData["marketplace_publication_id"] = publicationID
+	}
+	if backupID, ok := state.GetOk("boot_volume_backup_id"); ok {
+		stateData["boot_volume_backup_id"] = backupID
+	}
+
+	// Build the artifact and return it
+	artifact := &Artifact{
+		Image:     image.(core.Image),
+		Region:    region,
+		driver:    driver,
+		files:     files,
+		StateData: gpuStateData(state, stateData),
+	}
+
+	return artifact, nil
+}
+
+// run runs the build steps, retrying them as configured, and returns the
+// state of the successful attempt.
+func (b *Builder) run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook) (Driver, multistep.StateBag, error) {
+	driver, err := NewDriverOCI(&b.config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var state *multistep.BasicStateBag
+	for attempt := 0; ; attempt++ {
+		state = b.newState(driver, ui, hook)
+
+		// Run the steps. stepCreateInstance cancels the attempt once the
+		// instance exceeded max_run_duration.
+		attemptCtx, cancel := context.WithCancel(ctx)
+		state.Put("cancel_attempt", cancel)
+		b.runner = commonsteps.NewRunnerWithPauseFn(b.steps(), b.config.PackerConfig, ui, state)
+		b.runner.Run(attemptCtx, state)
+		cancel()
+
+		if _, exceeded := state.GetOk("max_run_duration_exceeded"); exceeded {
+			return nil, nil, fmt.Errorf("Build exceeded max_run_duration of %s, its instance was terminated", b.config.MaxRunDuration)
+		}
+
+		// If there was an error, retry the whole build if it was caused by a
+		// transient infrastructure failure, otherwise return that
+		rawErr, ok := state.GetOk("error")
+		if !ok {
+			break
+		}
+		class := buildFailureClass(state)
+		if attempt >= b.config.BuildRetryAttempts || ctx.Err() != nil || !stringSliceContains(b.config.BuildRetryOn, class) {
+			return nil, nil, rawErr.(error)
+		}
+		ui.Error(fmt.Sprintf("Build failed due to %s, retrying from scratch (retry %d of %d)...",
+			class, attempt+1, b.config.BuildRetryAttempts))
+	}
+
+	return driver, state, nil
+}
+
+// newState returns a fresh state bag for a build attempt.
+func (b *Builder) newState(driver Driver, ui packersdk.Ui, hook packersdk.Hook) *multistep.BasicStateBag {
+	b.config.resetAttempt()
+
 	state := new(multistep.BasicStateBag)
 	state.Put("config", &b.config)
 	state.Put("driver", driver)
 	state.Put("hook", hook)
 	state.Put("ui", ui)
+	state.Put("keep_instance", b.keepInstance || b.config.KeepInstanceAlive)
+	return state
+}
+
+// steps returns the steps of a build attempt. Steps keep track of the
+// resources they created, so every attempt needs new ones.
+func (b *Builder) steps() []multistep.Step {
+	if b.config.Diagnostics {
+		return []multistep.Step{&stepDiagnostics{}}
+	}
+
+	blockVolumes := &stepBlockVolumes{}
 
-	// Build the steps
 	steps := []multistep.Step{
+		&stepPreflight{},
+		&stepGPUShape{},
+	}
+
+	// A kept instance is the artifact, so there is no image to lock or name
+	if !b.keepInstance {
+		steps = append(steps,
+			&stepImageLock{},
+			&stepImageName{},
+		)
+	}
+
+	steps = append(steps,
 		&ocommon.StepKeyPair{
 			Debug:        b.config.PackerDebug,
 			Comm:         &b.config.Comm,
 			DebugKeyPath: fmt.Sprintf("oci_%s.pem", b.config.PackerBuildName),
 		},
+		&stepImportImage{},
+		&stepSourceBootVolume{},
 		&stepCreateInstance{},
+		&stepBootVolumePerformance{},
+		&stepBootVolumeTags{},
+		&stepConsoleHistory{
+			Path: fmt.Sprintf("oci_%s_console.log", b.config.PackerBuildName),
+		},
+		&stepConsoleConnection{
+			Debug: b.config.PackerDebug,
+		},
+		&stepStreamConsole{},
+		&stepImageConnectionHints{},
+		&stepSecurityListRule{},
+		blockVolumes,
+		&stepAttachVlan{},
+		&stepReservedPublicIP{},
 		&stepInstanceInfo{},
 		&stepGetDefaultCredentials{
 			Debug:     b.config.PackerDebug,
 			Comm:      &b.config.Comm,
 			BuildName: b.config.PackerBuildName,
 		},
+		&stepWaitForAgentPlugins{},
+		&stepBootstrapScript{},
 		&communicator.StepConnect{
 			Config:    &b.config.Comm,
 			Host:      communicator.CommHost(b.config.Comm.Host(), "instance_ip"),
 			SSHConfig: b.config.Comm.SSHConfigFunc(),
 		},
+		&stepWaitForCloudInit{},
+		&stepWaitForGPUDriver{},
+		&stepProvisionerLog{},
 		&commonsteps.StepProvision{},
-		&commonsteps.StepCleanupTempKeys{
-			Comm: &b.config.Comm,
-		},
-		&stepImage{
-			SkipCreateImage: b.config.SkipCreateImage,
-		},
-	}
-
-	// Run the steps
-	b.runner = commonsteps.NewRunnerWithPauseFn(steps, b.config.PackerConfig, ui, state)
-	b.runner.Run(ctx, state)
+		&stepPauseBeforeCapture{},
+	)
 
-	// If there was an error, return that
-	if rawErr, ok := state.GetOk("error"); ok {
-		return nil, rawErr.(error)
+	// The block volumes stay attached to a kept instance, but must not be
+	// referenced by an image
+	if !b.keepInstance {
+		steps = append(steps, &stepDetachBlockVolumes{volumes: blockVolumes})
 	}
 
-	region, err := b.config.configProvider.Region()
-	if err != nil {
-		return nil, err
-	}
-
-	image, ok := state.GetOk("image")
-	if !ok {
-		return nil, err
-	}
+	steps = append(steps,
+		&commonsteps.StepCleanupTempKeys{
+			Comm: &b.config.Comm,
+		},
+	)
 
-	// Build the artifact and return it
-	artifact := &Artifact{
-		Image:     image.(core.Image),
-		Region:    region,
-		driver:    driver,
-		StateData: map[string]interface{}{"generated_data": state.Get("generated_data")},
+	// Nor is there an image to capture
+	if b.keepInstance {
+		return steps
 	}
 
-	return artifact, nil
+	return append(steps,
+		&stepScrubWinRMPassword{},
+		&stepCaptureShape{},
+		&stepShutdown{},
+		&stepImage{
+			SkipCreateImage: b.config.SkipCreateImage,
+		},
+		&stepBootVolumeBackup{},
+		&stepRestartInstance{},
+		&stepMoveImage{},
+		&stepExportImage{},
+		&stepCopyImage{},
+		&stepMarketplacePublication{},
+		&stepFirstBootValidation{},
+		&stepManifest{},
+		&stepPruneImages{},
+	)
 }
 
 // Cancel terminates a running build.
@@ -1,7 +1,7 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: MPL-2.0
 
-//go:generate packer-sdc mapstructure-to-hcl2 -type Config,CreateVNICDetails,ListImagesRequest,FlexShapeConfig,InstanceOptionsConfig
+//go:generate packer-sdc mapstructure-to-hcl2 -type Config,CreateVNICDetails,ListImagesRequest,FlexShapeConfig,InstanceOptionsConfig,LaunchOptionsConfig,InstanceAgentConfig,InstanceAgentPluginConfig,PreemptibleInstanceConfig,TimeoutsConfig,HTTPClientConfig,FirstBootValidationConfig,BlockVolumeConfig,PlatformConfig,ImageExportConfig,ImageImportConfig,ImageRetentionConfig,MarketplacePublicationConfig
 
 package oci
 
@@ -13,18 +13,22 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
-	"os/user"
-	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-version"
+	ocommon "github.com/hashicorp/packer-plugin-oracle/builder/common"
 	"github.com/hashicorp/packer-plugin-sdk/common"
 	"github.com/hashicorp/packer-plugin-sdk/communicator"
 	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
-	"github.com/hashicorp/packer-plugin-sdk/pathing"
 	"github.com/hashicorp/packer-plugin-sdk/template/config"
 	"github.com/hashicorp/packer-plugin-sdk/template/interpolate"
+	"github.com/hashicorp/packer-plugin-sdk/uuid"
+
 	ocicommon "github.com/oracle/oci-go-sdk/v65/common"
 	ociauth "github.com/oracle/oci-go-sdk/v65/common/auth"
+	core "github.com/oracle/oci-go-sdk/v65/core"
 )
 
 type CreateVNICDetails struct {
@@ -40,6 +44,7 @@ type CreateVNICDetails struct {
 	FreeformTags        map[string]string                 `mapstructure:"tags" required:"false"`
 	HostnameLabel       *string                           `mapstructure:"hostname_label" required:"false"`
 	NsgIds              []string                          `mapstructure:"nsg_ids" required:"false"`
+	NsgNames            []string                          `mapstructure:"nsg_names" required:"false"`
 	PrivateIp           *string                           `mapstructure:"private_ip" required:"false"`
 	SkipSourceDestCheck *bool                             `mapstructure:"skip_source_dest_check" required:"false"`
 	SubnetId            *string                           `mapstructure:"subnet_id" required:"false"`
@@ -53,18 +58,237 @@ type ListImagesRequest struct {
 	OperatingSystem        *string `mapstructure:"operating_system"`
 	OperatingSystemVersion *string `mapstructure:"operating_system_version"`
 	Shape                  *string `mapstructure:"shape"`
+	MaxResults             *int    `mapstructure:"max_results"`
+	PageSize               *int    `mapstructure:"page_size"`
+
+	// FreeformTags are freeform tags the image must have, e.g.
+	// approved = "true".
+	FreeformTags map[string]string `mapstructure:"tags"`
+	// DefinedTags are defined tags the image must have, keyed by
+	// namespace.key, e.g. "Security.approved" = "true".
+	DefinedTags map[string]string `mapstructure:"defined_tags"`
+	// CreatedAfter fails the build when the newest matching image was
+	// created before it, either an RFC 3339 timestamp or a maximum age such
+	// as "720h".
+	CreatedAfter *string `mapstructure:"created_after"`
+	// DisplayNameExclude is a regular expression for display names of images
+	// to skip, even when they match DisplayNameSearch.
+	DisplayNameExclude *string `mapstructure:"display_name_exclude"`
+	// VersionRegex is a regular expression extracting a version from the
+	// display name with its capture groups, joined with dots. The matching
+	// image with the highest version is used rather than the most recent
+	// one.
+	VersionRegex *string `mapstructure:"version_regex"`
+}
+
+// isSet returns whether any field of the filter is set.
+func (r ListImagesRequest) isSet() bool {
+	return r.CompartmentId != nil || r.DisplayName != nil || r.DisplayNameSearch != nil ||
+		r.OperatingSystem != nil || r.OperatingSystemVersion != nil || r.Shape != nil ||
+		r.MaxResults != nil || r.PageSize != nil || len(r.FreeformTags) > 0 || len(r.DefinedTags) > 0 ||
+		r.CreatedAfter != nil || r.DisplayNameExclude != nil || r.VersionRegex != nil
 }
 
 type InstanceOptionsConfig struct {
 	AreLegacyImdsEndpointsDisabled *bool `mapstructure:"are_legacy_imds_endpoints_disabled" required:"false"`
 }
 
+type LaunchOptionsConfig struct {
+	BootVolumeType                  string `mapstructure:"boot_volume_type" required:"false"`
+	Firmware                        string `mapstructure:"firmware" required:"false"`
+	NetworkType                     string `mapstructure:"network_type" required:"false"`
+	RemoteDataVolumeType            string `mapstructure:"remote_data_volume_type" required:"false"`
+	IsPvEncryptionInTransitEnabled  *bool  `mapstructure:"is_pv_encryption_in_transit_enabled" required:"false"`
+	IsConsistentVolumeNamingEnabled *bool  `mapstructure:"is_consistent_volume_naming_enabled" required:"false"`
+}
+
+type PlatformConfig struct {
+	// Type of the platform, e.g. "AMD_MILAN_BM" or "INTEL_ICELAKE_BM",
+	// which must match the shape. Required when any option is set.
+	Type string `mapstructure:"type" required:"false"`
+	// Whether symmetric multithreading (hyper-threading) is enabled.
+	IsSymmetricMultiThreadingEnabled *bool `mapstructure:"is_symmetric_multi_threading_enabled" required:"false"`
+	// Number of NUMA nodes per socket, e.g. "NPS1".
+	NumaNodesPerSocket string `mapstructure:"numa_nodes_per_socket" required:"false"`
+	// Percentage of the cores of the bare metal host that are enabled.
+	PercentageOfCoresEnabled *int `mapstructure:"percentage_of_cores_enabled" required:"false"`
+	// Whether Secure Boot, the Trusted Platform Module and Measured Boot
+	// are enabled, for Shielded Instances.
+	IsSecureBootEnabled            *bool `mapstructure:"is_secure_boot_enabled" required:"false"`
+	IsTrustedPlatformModuleEnabled *bool `mapstructure:"is_trusted_platform_module_enabled" required:"false"`
+	IsMeasuredBootEnabled          *bool `mapstructure:"is_measured_boot_enabled" required:"false"`
+}
+
+type InstanceAgentConfig struct {
+	IsMonitoringDisabled  *bool                       `mapstructure:"is_monitoring_disabled" required:"false"`
+	IsManagementDisabled  *bool                       `mapstructure:"is_management_disabled" required:"false"`
+	AreAllPluginsDisabled *bool                       `mapstructure:"are_all_plugins_disabled" required:"false"`
+	PluginsConfig         []InstanceAgentPluginConfig `mapstructure:"plugins_config" required:"false"`
+}
+
+type InstanceAgentPluginConfig struct {
+	Name         string `mapstructure:"name" required:"true"`
+	DesiredState string `mapstructure:"desired_state" required:"true"`
+}
+
 type FlexShapeConfig struct {
 	Ocpus                   *float32 `mapstructure:"ocpus" required:"false"`
 	MemoryInGBs             *float32 `mapstructure:"memory_in_gbs" required:"false"`
 	BaselineOcpuUtilization *string  `mapstructure:"baseline_ocpu_utilization" required:"false"`
 }
 
+type PreemptibleInstanceConfig struct {
+	// Whether to preserve the boot volume when the instance is preempted.
+	// Defaults to false.
+	PreserveBootVolume *bool `mapstructure:"preserve_boot_volume" required:"false"`
+}
+
+type FirstBootValidationConfig struct {
+	// Shell commands run at first boot of an instance launched from the
+	// image, each of which must exit 0 for the validation to pass.
+	Assertions []string `mapstructure:"assertions" required:"false"`
+	// Directory the validation bundle is written to. Defaults to
+	// "first-boot-validation".
+	OutputDirectory string `mapstructure:"output_directory" required:"false"`
+}
+
+type BlockVolumeConfig struct {
+	// Display name of the block volume.
+	DisplayName *string `mapstructure:"display_name" required:"false"`
+	// Size of the block volume in GBs, between 50 and 32768. Required
+	// unless VolumeID is set.
+	SizeInGBs int64 `mapstructure:"size_in_gbs" required:"false"`
+	// Performance of the block volume in volume performance units per GB.
+	// Defaults to the service default of 10 (balanced).
+	VpusPerGB *int64 `mapstructure:"vpus_per_gb" required:"false"`
+	// OCID of the Vault key encrypting the block volume, independent of
+	// the boot volume key. Defaults to an Oracle-managed key.
+	KmsKeyID string `mapstructure:"kms_key_ocid" required:"false"`
+	// How the block volume is attached, either "paravirtualized" or
+	// "iscsi". Defaults to "paravirtualized".
+	AttachmentType string `mapstructure:"attachment_type" required:"false"`
+	// OCID of an existing block volume to attach instead of creating one.
+	// The volume is detached but not deleted after the build.
+	VolumeID string `mapstructure:"volume_ocid" required:"false"`
+}
+
+type ImageExportConfig struct {
+	// Object Storage bucket the image is exported to.
+	Bucket string `mapstructure:"bucket" required:"true"`
+	// Object Storage namespace of the bucket. Defaults to the namespace of
+	// the tenancy.
+	Namespace string `mapstructure:"namespace" required:"false"`
+	// Name of the exported object. Defaults to the image name.
+	ObjectName string `mapstructure:"object_name" required:"false"`
+	// Format of the exported image, one of "QCOW2", "VMDK", "OCI", "VHD" or
+	// "VDI". Defaults to "OCI".
+	ExportFormat string `mapstructure:"export_format" required:"false"`
+	// How long a pre-authenticated request to read the exported image,
+	// included in the artifact, remains valid. Lets other tenancies import
+	// the image without IAM policies granting them access to the bucket.
+	// Defaults to 0, creating no pre-authenticated request.
+	ShareURLLifetime time.Duration `mapstructure:"share_url_lifetime" required:"false"`
+	// OCID of the Vault key the bucket must be encrypted with. Object
+	// Storage encrypts the exported object with the key of the bucket, so
+	// the export fails when the bucket uses another key.
+	KmsKeyID string `mapstructure:"kms_key_ocid" required:"false"`
+}
+
+type ImageRetentionConfig struct {
+	// Number of images matching NameRegex to keep, including the new image.
+	KeepReleases int `mapstructure:"keep_releases" required:"true"`
+	// Regular expression matching the names of the images produced by the
+	// template, such as "^golden-ol8-".
+	NameRegex string `mapstructure:"name_regex" required:"true"`
+	// OCID of the compartment to prune. Defaults to the compartment of the
+	// new image.
+	CompartmentID string `mapstructure:"compartment_ocid" required:"false"`
+}
+
+type MarketplacePublicationConfig struct {
+	// Name of the listing. Defaults to the image name.
+	Name string `mapstructure:"name" required:"false"`
+	// Short description of the listing.
+	ShortDescription string `mapstructure:"short_description" required:"true"`
+	// Long description of the listing.
+	LongDescription string `mapstructure:"long_description" required:"false"`
+	// Version of the listing package. Defaults to the image name.
+	PackageVersion string `mapstructure:"package_version" required:"false"`
+	// Operating system shown in the listing. Defaults to the operating
+	// system of the image.
+	OperatingSystem string `mapstructure:"operating_system" required:"false"`
+	// Text of the end user license agreement users accept to launch the
+	// image.
+	EulaText string `mapstructure:"eula_text" required:"false"`
+	// Name and email address users of the listing contact for support.
+	SupportName  string `mapstructure:"support_name" required:"false"`
+	SupportEmail string `mapstructure:"support_email" required:"true"`
+	// OCID of the compartment of the publication. Defaults to the compartment
+	// of the image.
+	CompartmentID string `mapstructure:"compartment_ocid" required:"false"`
+	// Acknowledge the Oracle Marketplace publisher agreement, which is
+	// required to publish the image.
+	AgreementAcknowledged bool `mapstructure:"agreement_acknowledged" required:"true"`
+}
+
+type ImageImportConfig struct {
+	// Object Storage URL of the disk image to import, such as a
+	// pre-authenticated request URL.
+	SourceURI string `mapstructure:"source_uri" required:"true"`
+	// Format of the disk image, either "QCOW2" or "VMDK". Defaults to
+	// "VMDK" for a ".vmdk" URL, "QCOW2" otherwise.
+	SourceImageType string `mapstructure:"source_image_type" required:"false"`
+	// Launch mode of the imported image, e.g. "PARAVIRTUALIZED". Defaults
+	// to the service default.
+	LaunchMode string `mapstructure:"launch_mode" required:"false"`
+	// Operating system and version of the disk image.
+	OperatingSystem        string `mapstructure:"operating_system" required:"false"`
+	OperatingSystemVersion string `mapstructure:"operating_system_version" required:"false"`
+}
+
+type TimeoutsConfig struct {
+	// Maximum duration of a single compute operation, including waiting for
+	// instances and images to reach a given state. Unlimited when unset.
+	Compute time.Duration `mapstructure:"compute" required:"false"`
+	// Maximum duration of a single networking operation, such as looking up
+	// the VNIC and subnet of the instance. Unlimited when unset.
+	Network time.Duration `mapstructure:"network" required:"false"`
+	// Maximum duration of a single Object Storage operation. Unlimited when
+	// unset.
+	ObjectStorage time.Duration `mapstructure:"object_storage" required:"false"`
+	// Interval between two polls of a resource while waiting for it to reach
+	// a given state. Defaults to 5s.
+	PollingInterval time.Duration `mapstructure:"polling_interval" required:"false"`
+	// Adapt the polling interval of instance launches and image captures to
+	// how long they took in previous builds with the same shape: poll less
+	// often early on, and every `polling_interval` near the expected
+	// completion. Defaults to false.
+	AdaptivePolling bool `mapstructure:"adaptive_polling" required:"false"`
+	// File in which the durations observed with `adaptive_polling` are kept.
+	// Defaults to `packer-plugin-oracle/durations.json` in the user cache
+	// directory.
+	AdaptivePollingCache string `mapstructure:"adaptive_polling_cache" required:"false"`
+}
+
+type HTTPClientConfig struct {
+	// Maximum number of idle connections kept open across all OCI endpoints.
+	// Defaults to 100.
+	MaxIdleConns int `mapstructure:"max_idle_conns" required:"false"`
+	// Maximum number of idle connections kept open per OCI endpoint.
+	// Defaults to 10.
+	MaxIdleConnsPerHost int `mapstructure:"max_idle_conns_per_host" required:"false"`
+	// How long an idle connection is kept open. Defaults to 90s.
+	IdleConnTimeout time.Duration `mapstructure:"idle_conn_timeout" required:"false"`
+	// Maximum duration of a TLS handshake. Defaults to 10s.
+	TLSHandshakeTimeout time.Duration `mapstructure:"tls_handshake_timeout" required:"false"`
+	// Maximum duration of a single HTTP request, including reading the
+	// response body. Defaults to 60s.
+	RequestTimeout time.Duration `mapstructure:"request_timeout" required:"false"`
+	// Disable TLS session resumption, forcing a full handshake on every new
+	// connection. Defaults to false.
+	DisableTLSSessionReuse bool `mapstructure:"disable_tls_session_reuse" required:"false"`
+}
+
 type Config struct {
 	common.PackerConfig `mapstructure:",squash"`
 	Comm                communicator.Config `mapstructure:",squash"`
@@ -87,6 +311,28 @@ type Config struct {
 	// during a build test stage. Default `false`.
 	SkipCreateImage bool `mapstructure:"skip_create_image" required:"false"`
 
+	// If true, Packer deletes the image and its capability schema when the
+	// build fails or is cancelled while the image is being created, rather
+	// than leaving an unusable image in the compartment. Default `false`.
+	DeleteFailedImage bool `mapstructure:"delete_failed_image" required:"false"`
+
+	// If true, Packer also creates a full backup of the boot volume of the
+	// instance, named and tagged like the image, after creating the image.
+	// With `skip_create_image` the backup is the only artifact of the build.
+	// Default `false`.
+	BootVolumeBackup bool `mapstructure:"boot_volume_backup" required:"false"`
+
+	// If true, Packer will not check that the compartment is active and that
+	// quotas and service limits leave enough OCPUs for the shape before
+	// launching the instance. Default `false`.
+	SkipPreflightChecks bool `mapstructure:"skip_preflight_checks" required:"false"`
+
+	// If true, Packer checks the credentials, the reachability of the OCI
+	// endpoints, the local clock and the configured compartments, subnet and
+	// base image, prints a report and stops without building anything.
+	// Default `false`.
+	Diagnostics bool `mapstructure:"diagnostics" required:"false"`
+
 	AccessCfgFile        string `mapstructure:"access_cfg_file"`
 	AccessCfgFileAccount string `mapstructure:"access_cfg_file_account"`
 
@@ -98,18 +344,91 @@ type Config struct {
 	KeyFile      string `mapstructure:"key_file"`
 	PassPhrase   string `mapstructure:"pass_phrase"`
 	UsePrivateIP bool   `mapstructure:"use_private_ip"`
+	// UsePrivateFQDN connects to the instance using its private FQDN, built
+	// from the VNIC hostname label and the subnet DNS domain, instead of an IP.
+	UsePrivateFQDN bool `mapstructure:"use_private_fqdn"`
+	// UseImageConnectionHints reads the SSH username and port from the
+	// packer_ssh_username and packer_ssh_port freeform tags of the base
+	// image, unless they are set in the template.
+	UseImageConnectionHints bool `mapstructure:"use_image_connection_hints"`
+	// UseIPv6 connects to the instance using an IPv6 address of its primary
+	// VNIC, assigning one from the subnet if the VNIC has none.
+	UseIPv6 bool `mapstructure:"use_ipv6"`
 
 	SecurityTokenFilePath string `mapstructure:"security_token_file"`
 	AvailabilityDomain    string `mapstructure:"availability_domain"`
 	CompartmentID         string `mapstructure:"compartment_ocid"`
+	// AvailabilityDomains are tried in order, moving on to the next one when
+	// an availability domain is out of host capacity.
+	AvailabilityDomains []string `mapstructure:"availability_domains" required:"false"`
+	// CapacityRetryTimeout is how long to keep retrying the launch while
+	// every availability domain is out of host capacity. Defaults to 0, not
+	// retrying.
+	CapacityRetryTimeout time.Duration `mapstructure:"capacity_retry_timeout" required:"false"`
+	// CapacityRetryInterval is the interval between two launch attempts
+	// while out of host capacity. Defaults to 1m.
+	CapacityRetryInterval time.Duration `mapstructure:"capacity_retry_interval" required:"false"`
 
 	// Image
-	BaseImageID        string            `mapstructure:"base_image_ocid"`
-	BaseImageFilter    ListImagesRequest `mapstructure:"base_image_filter"`
+	BaseImageID     string            `mapstructure:"base_image_ocid"`
+	BaseImageFilter ListImagesRequest `mapstructure:"base_image_filter"`
+	// BaseImage is a platform image alias such as "Oracle-Linux-9-latest",
+	// resolved to the most recent platform image of that name supporting
+	// the shape in the region of the build.
+	BaseImage string `mapstructure:"base_image" required:"false"`
+	// SourceBootVolumeID is the OCID of a boot volume to build from instead
+	// of an image, such as the boot volume of the last known good machine.
+	// The boot volume is cloned and the instance launched from the clone,
+	// which is deleted along with the instance after the build.
+	SourceBootVolumeID string `mapstructure:"source_boot_volume_ocid" required:"false"`
+	// BootVolumeID is the OCID of a boot volume to launch the instance from
+	// as is, without cloning it. The boot volume is preserved when the
+	// instance is terminated and keeps the changes made by the build.
+	BootVolumeID string `mapstructure:"boot_volume_ocid" required:"false"`
+	// BaseImageImport imports the base image from a disk image in Object
+	// Storage. The imported image is deleted after the build.
+	BaseImageImport    ImageImportConfig `mapstructure:"base_image_import" required:"false"`
 	ImageName          string            `mapstructure:"image_name"`
 	ImageCompartmentID string            `mapstructure:"image_compartment_ocid"`
 	LaunchMode         string            `mapstructure:"image_launch_mode"`
 	NicAttachmentType  string            `mapstructure:"nic_attachment_type"`
+	// ImageCapabilities sets capabilities of the image capability schema by
+	// key, such as "Compute.SecureBoot" = "true". The values of boolean and
+	// enum capabilities are given as strings.
+	ImageCapabilities map[string]string `mapstructure:"image_capabilities" required:"false"`
+	// IscsiMultipathDeviceSupported sets the
+	// Storage.Iscsi.MultipathDeviceSupported capability of the image, which
+	// instances need to attach Ultra High Performance block volumes.
+	IscsiMultipathDeviceSupported bool `mapstructure:"iscsi_multipath_device_supported" required:"false"`
+	// ConsistentVolumeNaming sets the Storage.ConsistentVolumeNaming
+	// capability of the image, so that block volumes can be attached with
+	// a consistent /dev/oracleoci device path.
+	ConsistentVolumeNaming bool `mapstructure:"consistent_volume_naming" required:"false"`
+	// ParavirtualizationAttachmentVersion sets the
+	// Storage.ParaVirtualization.AttachmentVersion capability of the image,
+	// 1 or 2.
+	ParavirtualizationAttachmentVersion int `mapstructure:"paravirtualization_attachment_version" required:"false"`
+	// ImageFirmware sets the Compute.Firmware capability of the image, the
+	// firmware instances launched from it boot with, "BIOS" or "UEFI_64".
+	// Defaults to "UEFI_64" on Ampere shapes.
+	ImageFirmware string `mapstructure:"image_firmware" required:"false"`
+	// ImageOperatingSystem and ImageOperatingSystemVersion set the
+	// operating system of the image shown in the console, instead of the
+	// one inherited from the base image.
+	ImageOperatingSystem        string `mapstructure:"image_operating_system" required:"false"`
+	ImageOperatingSystemVersion string `mapstructure:"image_operating_system_version" required:"false"`
+
+	// ImageVersionScheme appends a version to ImageName that is greater
+	// than the versions of the existing images, one of "timestamp",
+	// "counter" or "semver".
+	ImageVersionScheme string `mapstructure:"image_version_scheme" required:"false"`
+	// ImageVersion is the version appended with the "semver" scheme, such
+	// as "1.4.0".
+	ImageVersion string `mapstructure:"image_version" required:"false"`
+	// ImageDestinationCompartmentID is the OCID of the compartment the image
+	// is moved to once it is available, e.g. to publish images built in a
+	// sandbox compartment into a shared compartment.
+	ImageDestinationCompartmentID string `mapstructure:"image_destination_compartment_ocid" required:"false"`
 
 	// Instance
 	InstanceName *string           `mapstructure:"instance_name"`
@@ -120,9 +439,45 @@ type Config struct {
 	InstanceDefinedTagsJson string                            `mapstructure:"instance_defined_tags_json" required:"false"`
 	InstanceDefinedTags     map[string]map[string]interface{} `mapstructure:"instance_defined_tags" mapstructure-to-hcl2:",skip"`
 	InstanceOptions         InstanceOptionsConfig             `mapstructure:"instance_options"`
+	LaunchOptions           LaunchOptionsConfig               `mapstructure:"launch_options"`
+	PlatformConfig          PlatformConfig                    `mapstructure:"platform_config"`
+	AgentConfig             InstanceAgentConfig               `mapstructure:"agent_config"`
 	Shape                   string                            `mapstructure:"shape"`
 	ShapeConfig             FlexShapeConfig                   `mapstructure:"shape_config"`
+	CaptureShapeConfig      FlexShapeConfig                   `mapstructure:"capture_shape_config"`
 	BootVolumeSizeInGBs     int64                             `mapstructure:"disk_size"`
+	// BootVolumeKmsKeyID is the OCID of the Vault key encrypting the boot
+	// volume of the instance.
+	BootVolumeKmsKeyID string `mapstructure:"boot_volume_kms_key_ocid" required:"false"`
+	// BootVolumeVpusPerGB is the performance of the boot volume of the
+	// instance in volume performance units per GB.
+	BootVolumeVpusPerGB *int64 `mapstructure:"boot_volume_vpus_per_gb" required:"false"`
+	// PreserveBootVolume keeps the boot volume of the instance when it is
+	// terminated.
+	PreserveBootVolume bool `mapstructure:"preserve_boot_volume" required:"false"`
+	// InstanceDisposal is what happens to the instance after the build,
+	// either "terminate" or "stop". Defaults to "terminate".
+	InstanceDisposal string `mapstructure:"instance_disposal" required:"false"`
+	// KeepInstanceAlive keeps the instance running after a successful build
+	// instead of terminating it, to inspect the machine the image was taken
+	// from. Defaults to false.
+	KeepInstanceAlive bool `mapstructure:"keep_instance_alive" required:"false"`
+	// FreeTier defaults the shape to an Always Free one and checks that the
+	// build stays within the Always Free limits.
+	FreeTier bool `mapstructure:"free_tier" required:"false"`
+	// BlockVolumes are created and attached to the instance for the duration
+	// of the build, then detached and deleted.
+	BlockVolumes []BlockVolumeConfig `mapstructure:"block_volumes" required:"false"`
+	// CapacityReservationID is the OCID of a compute capacity reservation to
+	// launch the instance into.
+	CapacityReservationID string `mapstructure:"capacity_reservation_ocid" required:"false"`
+	// FaultDomain is the name of the fault domain to launch the instance in,
+	// e.g. "FAULT-DOMAIN-1". Defaults to a fault domain chosen by OCI.
+	FaultDomain string `mapstructure:"fault_domain" required:"false"`
+	// IsPreemptible launches the instance on preemptible capacity, where it
+	// is terminated when the capacity is reclaimed.
+	IsPreemptible             bool                      `mapstructure:"is_preemptible" required:"false"`
+	PreemptibleInstanceConfig PreemptibleInstanceConfig `mapstructure:"preemptible_instance_config" required:"false"`
 
 	// Metadata optionally contains custom metadata key/value pairs provided in the
 	// configuration. While this can be used to set metadata["user_data"] the explicit
@@ -135,12 +490,182 @@ type Config struct {
 	UserData     string `mapstructure:"user_data"`
 	UserDataFile string `mapstructure:"user_data_file"`
 
+	// ConfigureWinRM sets the user_data of a Windows instance to a script
+	// enabling a WinRM HTTPS listener with a self-signed certificate.
+	ConfigureWinRM bool `mapstructure:"configure_winrm" required:"false"`
+	// RotateWinRMPassword generates a password for the WinRM user, set by
+	// the user_data of the instance instead of waiting for its initial
+	// credentials, and replaces it with an unknown one before the image is
+	// captured.
+	RotateWinRMPassword bool `mapstructure:"rotate_winrm_password" required:"false"`
+
 	// Networking
 	SubnetID          string            `mapstructure:"subnet_ocid"`
 	CreateVnicDetails CreateVNICDetails `mapstructure:"create_vnic_details"`
+	// VlanID is the OCID of a VLAN to attach the instance to. When a subnet is
+	// also configured the VLAN is attached as a secondary VNIC, otherwise the
+	// primary VNIC is created in the VLAN.
+	VlanID string `mapstructure:"vlan_ocid" required:"false"`
+	// PublicIPLifetime is either EPHEMERAL (the default), where the public IP
+	// is released together with the VNIC, or RESERVED, where a reserved public
+	// IP is assigned to the instance after launch.
+	PublicIPLifetime string `mapstructure:"public_ip_lifetime" required:"false"`
+	// ReservedPublicIPID is the OCID of an existing reserved public IP to
+	// assign to the instance. It is unassigned, but kept, on teardown.
+	ReservedPublicIPID string `mapstructure:"reserved_public_ip_ocid" required:"false"`
+	// RetainReservedPublicIP keeps a reserved public IP created by the build
+	// instead of deleting it on teardown.
+	RetainReservedPublicIP bool `mapstructure:"retain_reserved_public_ip" required:"false"`
+	// SecurityListID is the OCID of a security list to which a temporary
+	// ingress rule for the communicator port is added for the duration of the
+	// build.
+	SecurityListID string `mapstructure:"security_list_ocid" required:"false"`
+	// SecurityListSourceCidrs are the source CIDR blocks allowed by the
+	// temporary ingress rule. Defaults to 0.0.0.0/0.
+	SecurityListSourceCidrs []string `mapstructure:"security_list_source_cidrs" required:"false"`
+
+	// ProvisionerLogID is the OCID of an OCI Logging custom log to which the
+	// provisioner output is mirrored while the build runs.
+	ProvisionerLogID string `mapstructure:"provisioner_log_ocid" required:"false"`
+
+	// ImageLockBucket is the Object Storage bucket holding the locks that
+	// prevent concurrent builds of the same image name.
+	ImageLockBucket string `mapstructure:"image_lock_bucket" required:"false"`
+	// ImageLockTimeout is how long to wait for a lock held by another build.
+	// Defaults to 0, failing immediately.
+	ImageLockTimeout time.Duration `mapstructure:"image_lock_timeout" required:"false"`
+	// ImageLockTTL is how long after it was last modified a lock is stale,
+	// left by a build that was killed before releasing it, and replaced.
+	// Defaults to 0, never expiring.
+	ImageLockTTL time.Duration `mapstructure:"image_lock_ttl" required:"false"`
+
+	// ImageCreationTimeout is how long to wait for the image to be created,
+	// replacing `timeouts.compute` for this wait, so it may be longer.
+	// Defaults to 0, waiting until `timeouts.compute` if set.
+	ImageCreationTimeout time.Duration `mapstructure:"image_creation_timeout" required:"false"`
+	// ImagePollingInterval is the interval between two polls of the image
+	// being created. Defaults to `timeouts.polling_interval`.
+	ImagePollingInterval time.Duration `mapstructure:"image_polling_interval" required:"false"`
+
+	// FirstBootValidation generates a bundle to validate the first boot of
+	// instances launched from the image.
+	FirstBootValidation FirstBootValidationConfig `mapstructure:"first_boot_validation" required:"false"`
+
+	// ExportToObjectStorage exports the image to an Object Storage bucket
+	// once it is created.
+	ExportToObjectStorage ImageExportConfig `mapstructure:"export_to_object_storage" required:"false"`
+	// ImageCopyRegions are regions the image is copied to once exported
+	// with ExportToObjectStorage, by importing the exported object.
+	ImageCopyRegions []string `mapstructure:"image_copy_regions" required:"false"`
+	// ImageCopyNames are the display names of the image copies by region,
+	// for the regions of ImageCopyRegions where they differ from ImageName.
+	ImageCopyNames map[string]string `mapstructure:"image_copy_names" required:"false"`
+	// ImageCopyCompartments are compartments of the region of the build the
+	// image is copied to once exported with ExportToObjectStorage, by
+	// importing the exported object.
+	ImageCopyCompartments []string `mapstructure:"image_copy_compartment_ocids" required:"false"`
+	// ImageRetention deletes older images produced by the template once the
+	// build succeeded, keeping the most recent ones.
+	ImageRetention ImageRetentionConfig `mapstructure:"image_retention" required:"false"`
+	// MarketplacePublication publishes the image as an OCI Marketplace
+	// community listing once it is created.
+	MarketplacePublication MarketplacePublicationConfig `mapstructure:"marketplace_publication" required:"false"`
+	// ManifestPath is the path of a local JSON file describing the image,
+	// written once the build succeeded and attached to the artifact.
+	ManifestPath string `mapstructure:"manifest_path" required:"false"`
+	// ManifestExportSHA256 records the SHA256 digest of the object exported
+	// with ExportToObjectStorage in the manifest. Object Storage does not
+	// record SHA256 digests, so the object is downloaded through the Packer
+	// host to compute it, bounded by timeouts.object_storage only.
+	ManifestExportSHA256 bool `mapstructure:"manifest_export_sha256" required:"false"`
+
+	// CreateConsoleConnection creates a console connection to the instance
+	// and prints how to reach its serial console and VNC display. A console
+	// connection is always created in debug mode.
+	CreateConsoleConnection bool `mapstructure:"create_console_connection" required:"false"`
+
+	// StreamConsoleOutput prints the serial console output of the instance
+	// while waiting for the communicator.
+	StreamConsoleOutput bool `mapstructure:"stream_console_output" required:"false"`
+
+	// WaitForCloudInit waits for cloud-init to finish before running the
+	// provisioners.
+	WaitForCloudInit bool `mapstructure:"wait_for_cloud_init" required:"false"`
+	// CloudInitTimeout is how long to wait for cloud-init. Unlimited when
+	// unset.
+	CloudInitTimeout time.Duration `mapstructure:"cloud_init_timeout" required:"false"`
+
+	// WaitForGPUDriver waits for the NVIDIA driver and fabric manager to be
+	// ready before running the provisioners.
+	WaitForGPUDriver bool `mapstructure:"wait_for_gpu_driver" required:"false"`
+	// GPUDriverTimeout is how long to wait for the GPU driver. Defaults to
+	// 15m.
+	GPUDriverTimeout time.Duration `mapstructure:"gpu_driver_timeout" required:"false"`
+
+	// WaitForAgentPlugins are the names of the Oracle Cloud Agent plugins to
+	// wait for to be running before connecting to the instance.
+	WaitForAgentPlugins []string `mapstructure:"wait_for_agent_plugins" required:"false"`
+	// AgentPluginsTimeout is how long to wait for WaitForAgentPlugins.
+	// Defaults to 10m.
+	AgentPluginsTimeout time.Duration `mapstructure:"agent_plugins_timeout" required:"false"`
+
+	// BootstrapScript is run on the instance through the Run Command plugin
+	// of the Compute Instance Agent before connecting to it, e.g. to open
+	// the firewall or enable WinRM. {{ .SSHPublicKey }} is the public key
+	// the communicator authenticates with.
+	BootstrapScript string `mapstructure:"bootstrap_script" required:"false"`
+	// BootstrapTimeout is how long to wait for BootstrapScript to be
+	// delivered to the instance and to run. Defaults to 10m.
+	BootstrapTimeout time.Duration `mapstructure:"bootstrap_timeout" required:"false"`
+
+	// PauseBeforeCapture holds the provisioned instance before the image is
+	// captured, either for a duration or, when set to "prompt", until the
+	// user confirms.
+	PauseBeforeCapture string `mapstructure:"pause_before_capture" required:"false"`
+
+	// ShutdownBeforeImage stops the instance before the image is captured,
+	// with a soft stop or ShutdownCommand.
+	ShutdownBeforeImage bool `mapstructure:"shutdown_before_image" required:"false"`
+	// ShutdownCommand is run on the instance to shut it down with
+	// ShutdownBeforeImage, instead of a soft stop through the API.
+	ShutdownCommand string `mapstructure:"shutdown_command" required:"false"`
+	// RestartAfterImage starts the instance stopped by ShutdownBeforeImage
+	// again once the image is captured, and keeps it running after the
+	// build with the "stop" InstanceDisposal.
+	RestartAfterImage bool `mapstructure:"restart_after_image" required:"false"`
+
+	// Timeouts
+	Timeouts TimeoutsConfig `mapstructure:"timeouts" required:"false"`
+
+	// FIPSMode restricts OCI API calls to FIPS approved TLS settings and to
+	// endpoints within the realm of the region.
+	FIPSMode bool `mapstructure:"fips_mode" required:"false"`
+
+	// HTTPClient tunes the HTTP client shared by all OCI API calls made in
+	// the plugin process.
+	HTTPClient HTTPClientConfig `mapstructure:"http_client" required:"false"`
+
+	// MaxRunDuration is how long the instance may run before Packer
+	// force-terminates it and fails the build. Unlimited when unset.
+	MaxRunDuration time.Duration `mapstructure:"max_run_duration" required:"false"`
+
+	// BuildRetryAttempts is the number of times the whole launch, provision
+	// and capture sequence is retried from scratch after a transient
+	// infrastructure failure. Defaults to 0 (no retries).
+	BuildRetryAttempts int `mapstructure:"build_retry_attempts" required:"false"`
+	// BuildRetryOn lists the classes of failures that are retried, any of
+	// "capacity", "preemption" and "maintenance". Defaults to all of them.
+	BuildRetryOn []string `mapstructure:"build_retry_on" required:"false"`
 
 	// Tagging
 	Tags map[string]string `mapstructure:"tags"`
+	// SourceControlTags adds the git commit and branch of the checkout Packer
+	// runs from, and the path of the template within it, to the image tags.
+	SourceControlTags bool `mapstructure:"source_control_tags" required:"false"`
+	// ProvenanceTags adds the tags of SourceControlTags, along with the
+	// Packer version, template, base image and build time, to the image tags
+	// so that instances can be traced back to their build.
+	ProvenanceTags bool `mapstructure:"provenance_tags" required:"false"`
 	// HCL cannot be decoded into an interface so for HCL templates you must use the DefinedTagsJson option,
 	// To be used with https://www.packer.io/docs/templates/hcl_templates/functions/encoding/jsonencode
 	// ref: https://github.com/hashicorp/hcl/issues/291#issuecomment-496347585
@@ -149,6 +674,42 @@ type Config struct {
 	DefinedTags map[string]map[string]interface{} `mapstructure:"defined_tags" required:"false" mapstructure-to-hcl2:",skip"`
 
 	ctx interpolate.Context
+
+	// imageNameTemplate is ImageName before rendering.
+	imageNameTemplate string
+	// instanceNameTemplate is InstanceName before rendering, kept when it
+	// needs the base image.
+	instanceNameTemplate string
+
+	// sshUsernameFromImage and sshPortFromImage are set when the SSH
+	// username and port are read from the base image tags.
+	sshUsernameFromImage bool
+	sshPortFromImage     bool
+
+	// pauseBeforeCapture is PauseBeforeCapture parsed as a duration. It is
+	// zero when prompting.
+	pauseBeforeCapture time.Duration
+
+	// launchBootVolumeID is the OCID of the boot volume the instance is
+	// launched from, either BootVolumeID or the clone of SourceBootVolumeID,
+	// and sourceBootVolumeName the display name of the source boot volume.
+	launchBootVolumeID   string
+	sourceBootVolumeName string
+
+	// attemptImageName and attemptImageTags are the name and freeform tags
+	// of the image of the current build attempt, as rendered by
+	// stepImageName. ImageName and Tags are left untouched so that every
+	// attempt renders them again.
+	attemptImageName string
+	attemptImageTags map[string]string
+
+	// baseImageCreatedAfter is BaseImageFilter.CreatedAfter parsed as a
+	// time, a maximum age being counted back from the start of the build.
+	baseImageCreatedAfter time.Time
+
+	// warnings collects non fatal configuration problems, such as options
+	// that are silently ignored, found while preparing the configuration.
+	warnings []string
 }
 
 func (c *Config) ConfigProvider() ocicommon.ConfigurationProvider {
@@ -161,22 +722,50 @@ func (c *Config) Prepare(raws ...interface{}) error {
 	err := config.Decode(c, &config.DecodeOpts{
 		Interpolate:        true,
 		InterpolateContext: &c.ctx,
+		InterpolateFilter: &interpolate.RenderFilter{
+			Exclude: []string{
+				"image_name",
+				"instance_name",
+				"bootstrap_script",
+			},
+		},
 	}, raws...)
 	if err != nil {
 		return fmt.Errorf("Failed to mapstructure Config: %+v", err)
 	}
 
+	// The SSH username is only known once the base image is, so it is
+	// filled in for validation and cleared again
+	if c.UseImageConnectionHints && (c.Comm.Type == "" || c.Comm.Type == "ssh") {
+		c.sshUsernameFromImage = c.Comm.SSHUsername == ""
+		c.sshPortFromImage = c.Comm.SSHPort == 0
+		if c.sshUsernameFromImage {
+			c.Comm.SSHUsername = "image-connection-hint"
+		}
+	}
+
 	var errs *packersdk.MultiError
 	if es := c.Comm.Prepare(&c.ctx); len(es) > 0 {
 		errs = packersdk.MultiErrorAppend(errs, es...)
 	}
 
+	if c.sshUsernameFromImage {
+		c.Comm.SSHUsername = ""
+	}
+
 	if c.InstanceDefinedTagsJson != "" {
 		if err := json.Unmarshal([]byte(c.InstanceDefinedTagsJson), &c.InstanceDefinedTags); err != nil {
 			return fmt.Errorf("Failed to unmarshal 'instance_defined_tags_json': %s", err.Error())
 		}
 	}
 
+	// Tags set in the template take precedence
+	instanceTags := map[string]string{buildUUIDTag: uuid.TimeOrderedUUID()}
+	for k, v := range c.InstanceTags {
+		instanceTags[k] = v
+	}
+	c.InstanceTags = instanceTags
+
 	if c.DefinedTagsJson != "" {
 		if err := json.Unmarshal([]byte(c.DefinedTagsJson), &c.DefinedTags); err != nil {
 			return fmt.Errorf("Failed to unmarshal 'defined_tags': %s", err.Error())
@@ -240,7 +829,7 @@ func (c *Config) Prepare(raws ...interface{}) error {
 	} else {
 		// Determine where the SDK config is located
 		if c.AccessCfgFile == "" {
-			c.AccessCfgFile, err = getDefaultOCISettingsPath()
+			c.AccessCfgFile, err = ocommon.DefaultOCISettingsPath()
 			if err != nil {
 				log.Println("Default OCI settings file not found")
 			}
@@ -250,41 +839,8 @@ func (c *Config) Prepare(raws ...interface{}) error {
 			c.AccessCfgFileAccount = "DEFAULT"
 		}
 
-		var keyContent []byte
-		if c.KeyFile != "" {
-			path, err := pathing.ExpandUser(c.KeyFile)
-			if err != nil {
-				return err
-			}
-
-			// Read API signing key
-			keyContent, err = ioutil.ReadFile(path)
-			if err != nil {
-				return err
-			}
-		}
-
-		fileProvider, _ := ocicommon.ConfigurationProviderFromFileWithProfile(c.AccessCfgFile, c.AccessCfgFileAccount, c.PassPhrase)
-		if c.Region == "" {
-			var region string
-			if fileProvider != nil {
-				region, _ = fileProvider.Region()
-			}
-			if region == "" {
-				c.Region = "us-phoenix-1"
-			}
-		}
-
-		providers := []ocicommon.ConfigurationProvider{
-			ocicommon.NewRawConfigurationProvider(c.TenancyID, c.UserID, c.Region, c.Fingerprint, string(keyContent), &c.PassPhrase),
-		}
-
-		if fileProvider != nil {
-			providers = append(providers, fileProvider)
-		}
-
 		// Load API access configuration from SDK
-		configProvider, err := ocicommon.ComposingConfigurationProvider(providers)
+		configProvider, err := c.apiKeyConfig().ConfigurationProvider("us-phoenix-1")
 		if err != nil {
 			return err
 		}
@@ -318,9 +874,23 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		c.configProvider = configProvider
 	}
 
-	if c.AvailabilityDomain == "" {
+	if c.AvailabilityDomain != "" && len(c.AvailabilityDomains) > 0 {
 		errs = packersdk.MultiErrorAppend(
-			errs, errors.New("'availability_domain' must be specified"))
+			errs, errors.New("Only one of availability_domain or availability_domains can be specified."))
+	} else if len(c.AvailabilityDomains) > 0 {
+		c.AvailabilityDomain = c.AvailabilityDomains[0]
+	} else if c.AvailabilityDomain == "" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'availability_domain' or 'availability_domains' must be specified"))
+	}
+
+	if c.CapacityRetryTimeout < 0 || c.CapacityRetryInterval < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'capacity_retry_timeout' and 'capacity_retry_interval' must not be negative"))
+	}
+
+	if c.CapacityRetryInterval == 0 {
+		c.CapacityRetryInterval = time.Minute
 	}
 
 	if c.CompartmentID == "" && tenancyOCID != "" {
@@ -331,6 +901,15 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		c.ImageCompartmentID = c.CompartmentID
 	}
 
+	if c.ImageDestinationCompartmentID != "" && c.ImageDestinationCompartmentID == c.ImageCompartmentID {
+		c.warnings = append(c.warnings, "'image_destination_compartment_ocid' is ignored as it is the 'image_compartment_ocid'")
+		c.ImageDestinationCompartmentID = ""
+	}
+
+	if c.FreeTier {
+		errs = packersdk.MultiErrorAppend(errs, c.prepareFreeTier()...)
+	}
+
 	if c.Shape == "" {
 		errs = packersdk.MultiErrorAppend(
 			errs, errors.New("'shape' must be specified"))
@@ -343,6 +922,11 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		}
 	}
 
+	if !strings.HasSuffix(c.Shape, "Flex") && (c.ShapeConfig != FlexShapeConfig{}) {
+		c.warnings = append(c.warnings, fmt.Sprintf(
+			"'shape_config' is only supported by flexible shapes and is likely to be rejected for shape %q", c.Shape))
+	}
+
 	if c.ShapeConfig.MemoryInGBs != nil && c.ShapeConfig.Ocpus == nil {
 		errs = packersdk.MultiErrorAppend(
 			errs, errors.New("'Ocpus' must be specified if memory_in_gbs is specified"))
@@ -353,21 +937,170 @@ func (c *Config) Prepare(raws ...interface{}) error {
 			errs, errors.New("'Ocpus' must be specified if baseline_ocpu_utilization is specified"))
 	}
 
-	if (c.SubnetID == "") && (c.CreateVnicDetails.SubnetId == nil) {
+	if (c.CaptureShapeConfig != FlexShapeConfig{}) {
+		if !strings.HasSuffix(c.Shape, "Flex") {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'capture_shape_config' is only supported by flexible shapes"))
+		}
+		if c.CaptureShapeConfig.Ocpus == nil {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'capture_shape_config.ocpus' must be specified"))
+		}
+	}
+
+	if (c.SubnetID == "") && (c.CreateVnicDetails.SubnetId == nil) && (c.VlanID == "") {
 		errs = packersdk.MultiErrorAppend(
-			errs, errors.New("'subnet_ocid' must be specified"))
+			errs, errors.New("'subnet_ocid' or 'vlan_ocid' must be specified"))
 	}
 
 	if c.CreateVnicDetails.SubnetId == nil {
-		c.CreateVnicDetails.SubnetId = &c.SubnetID
+		if c.SubnetID != "" {
+			c.CreateVnicDetails.SubnetId = &c.SubnetID
+		}
 	} else if (*c.CreateVnicDetails.SubnetId != c.SubnetID) && (c.SubnetID != "") {
 		errs = packersdk.MultiErrorAppend(
 			errs, errors.New("'create_vnic_details[subnet]' must match 'subnet_ocid' if both are specified"))
 	}
 
-	if (c.BaseImageID == "") && (c.BaseImageFilter == ListImagesRequest{}) {
+	// A platform image alias is resolved like a display name search, for
+	// names such as Oracle-Linux-9.3-2024.01.26-0. Variants such as Minimal
+	// images are only matched when part of the alias, except the aarch64 and
+	// GPU ones, which the shape filter already selects.
+	if c.BaseImage != "" {
+		if c.BaseImageID != "" || c.BaseImageFilter.isSet() {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'base_image' cannot be combined with 'base_image_ocid' or 'base_image_filter'"))
+		} else if prefix, ok := strings.CutSuffix(c.BaseImage, "-latest"); ok && prefix != "" {
+			search := "^" + regexp.QuoteMeta(prefix) + `(\.[0-9]+)*(-aarch64|-Gen2-GPU)?-[0-9]{4}\.[0-9]{2}\.[0-9]{2}-[0-9]+$`
+			c.BaseImageFilter.DisplayNameSearch = &search
+		} else {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'base_image' %q must be a platform image alias ending with -latest, e.g. Oracle-Linux-9-latest", c.BaseImage))
+		}
+	}
+
+	imageSource := c.BaseImage != "" || c.BaseImageID != "" || c.BaseImageFilter.isSet()
+	importSource := c.BaseImageImport != ImageImportConfig{}
+
+	if c.BootVolumeID != "" {
+		if imageSource || importSource || c.SourceBootVolumeID != "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'boot_volume_ocid' cannot be combined with 'base_image', 'base_image_ocid', 'base_image_filter', 'base_image_import' or 'source_boot_volume_ocid'"))
+		}
+		if c.UseImageConnectionHints {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'use_image_connection_hints' requires an image source and cannot be used with 'boot_volume_ocid'"))
+		}
+		if c.BootVolumeSizeInGBs != 0 || c.BootVolumeKmsKeyID != "" {
+			c.warnings = append(c.warnings,
+				"'disk_size' and 'boot_volume_kms_key_ocid' are ignored when 'boot_volume_ocid' is specified")
+		}
+		if c.IsPreemptible && c.PreemptibleInstanceConfig.PreserveBootVolume != nil && !*c.PreemptibleInstanceConfig.PreserveBootVolume {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'preemptible_instance_config.preserve_boot_volume' cannot be false with 'boot_volume_ocid'"))
+		}
+
+		// The boot volume belongs to the user, so it must outlive the instance
+		c.PreserveBootVolume = true
+		if c.IsPreemptible {
+			c.PreemptibleInstanceConfig.PreserveBootVolume = ocicommon.Bool(true)
+		}
+	} else if c.SourceBootVolumeID != "" {
+		if imageSource || importSource {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'source_boot_volume_ocid' cannot be combined with 'base_image', 'base_image_ocid', 'base_image_filter' or 'base_image_import'"))
+		}
+		if c.UseImageConnectionHints {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'use_image_connection_hints' requires an image source and cannot be used with 'source_boot_volume_ocid'"))
+		}
+	} else if importSource {
+		if imageSource {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'base_image_import' cannot be combined with 'base_image', 'base_image_ocid' or 'base_image_filter'"))
+		}
+		if c.BaseImageImport.SourceURI == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'base_image_import.source_uri' must be specified"))
+		}
+		if c.BaseImageImport.SourceImageType == "" {
+			c.BaseImageImport.SourceImageType = string(core.ImageSourceDetailsSourceImageTypeQcow2)
+			if strings.HasSuffix(strings.ToLower(c.BaseImageImport.SourceURI), ".vmdk") {
+				c.BaseImageImport.SourceImageType = string(core.ImageSourceDetailsSourceImageTypeVmdk)
+			}
+		}
+		if v, ok := core.GetMappingImageSourceDetailsSourceImageTypeEnum(c.BaseImageImport.SourceImageType); ok {
+			c.BaseImageImport.SourceImageType = string(v)
+		} else {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'base_image_import.source_image_type' must be one of QCOW2 or VMDK"))
+		}
+		if c.BaseImageImport.LaunchMode != "" {
+			if v, ok := core.GetMappingCreateImageDetailsLaunchModeEnum(c.BaseImageImport.LaunchMode); ok {
+				c.BaseImageImport.LaunchMode = string(v)
+			} else {
+				errs = packersdk.MultiErrorAppend(
+					errs, errors.New("'base_image_import.launch_mode' must be one of NATIVE, EMULATED, PARAVIRTUALIZED, or CUSTOM"))
+			}
+		}
+	} else if !imageSource {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'base_image', 'base_image_ocid', 'base_image_filter', 'base_image_import', 'source_boot_volume_ocid' or 'boot_volume_ocid' must be specified"))
+	}
+
+	if c.BaseImageID != "" && c.BaseImageFilter.isSet() {
+		c.warnings = append(c.warnings,
+			"'base_image_filter' is ignored when 'base_image_ocid' is specified")
+	}
+
+	if c.BaseImageFilter.DisplayName != nil && c.BaseImageFilter.DisplayNameSearch != nil {
+		c.warnings = append(c.warnings,
+			"'base_image_filter.display_name_search' is redundant when 'base_image_filter.display_name' is specified")
+	}
+
+	if c.BaseImageFilter.MaxResults != nil && *c.BaseImageFilter.MaxResults < 1 {
 		errs = packersdk.MultiErrorAppend(
-			errs, errors.New("'base_image_ocid' or 'base_image_filter' must be specified"))
+			errs, errors.New("'base_image_filter.max_results' must be greater than 0"))
+	}
+
+	if c.BaseImageFilter.PageSize != nil && *c.BaseImageFilter.PageSize < 1 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'base_image_filter.page_size' must be greater than 0"))
+	}
+
+	if c.BaseImageFilter.DisplayNameExclude != nil {
+		if _, err := regexp.Compile(*c.BaseImageFilter.DisplayNameExclude); err != nil {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'base_image_filter.display_name_exclude' is invalid: %s", err))
+		}
+	}
+
+	if c.BaseImageFilter.VersionRegex != nil {
+		if re, err := regexp.Compile(*c.BaseImageFilter.VersionRegex); err != nil {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'base_image_filter.version_regex' is invalid: %s", err))
+		} else if re.NumSubexp() == 0 {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'base_image_filter.version_regex' must have a capture group for the version"))
+		}
+	}
+
+	if c.BaseImageFilter.CreatedAfter != nil {
+		if age, err := time.ParseDuration(*c.BaseImageFilter.CreatedAfter); err == nil && age > 0 {
+			c.baseImageCreatedAfter = time.Now().Add(-age)
+		} else if after, err := time.Parse(time.RFC3339, *c.BaseImageFilter.CreatedAfter); err == nil {
+			c.baseImageCreatedAfter = after
+		} else {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'base_image_filter.created_after' must be an RFC 3339 timestamp or a positive duration"))
+		}
+	}
+
+	for key := range c.BaseImageFilter.DefinedTags {
+		if namespace, name, ok := strings.Cut(key, "."); !ok || namespace == "" || name == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'base_image_filter.defined_tags' key %q must be of the form namespace.key", key))
+		}
 	}
 
 	if c.BaseImageFilter.CompartmentId == nil {
@@ -402,6 +1135,196 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		}
 	}
 
+	if c.CloudInitTimeout < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'cloud_init_timeout' must not be negative"))
+	}
+
+	if c.WaitForCloudInit && c.Comm.Type == "winrm" {
+		c.warnings = append(c.warnings,
+			"'wait_for_cloud_init' runs 'cloud-init status --wait', which is not available on Windows instances")
+	}
+
+	if c.GPUDriverTimeout < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'gpu_driver_timeout' must not be negative"))
+	}
+
+	if c.GPUDriverTimeout == 0 {
+		c.GPUDriverTimeout = 15 * time.Minute
+	}
+
+	if c.WaitForGPUDriver && !isGPUShape(c.Shape) {
+		c.warnings = append(c.warnings, fmt.Sprintf(
+			"'wait_for_gpu_driver' waits for GPUs, which shape %q is not known to have", c.Shape))
+	}
+
+	// The public key is only known once the key pair was created, so the
+	// script is rendered with a placeholder to validate it
+	if c.BootstrapScript != "" {
+		if _, err := c.renderBootstrapScript("ssh-rsa AAAA"); err != nil {
+			errs = packersdk.MultiErrorAppend(errs,
+				fmt.Errorf("unable to parse bootstrap script: %s", err))
+		}
+	}
+
+	// The bootstrap script races the startup of the Run Command plugin
+	if c.BootstrapScript != "" && !stringSliceContains(c.WaitForAgentPlugins, runCommandPlugin) {
+		c.WaitForAgentPlugins = append(c.WaitForAgentPlugins, runCommandPlugin)
+	}
+
+	if c.AgentPluginsTimeout < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'agent_plugins_timeout' must not be negative"))
+	}
+
+	if c.AgentPluginsTimeout == 0 {
+		c.AgentPluginsTimeout = 10 * time.Minute
+	}
+
+	if c.BootstrapTimeout < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'bootstrap_timeout' must not be negative"))
+	}
+
+	if c.BootstrapTimeout == 0 {
+		c.BootstrapTimeout = 10 * time.Minute
+	}
+
+	if c.PauseBeforeCapture != "" && c.PauseBeforeCapture != "prompt" {
+		c.pauseBeforeCapture, err = time.ParseDuration(c.PauseBeforeCapture)
+		if err != nil || c.pauseBeforeCapture <= 0 {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'pause_before_capture' must be a positive duration or \"prompt\""))
+		}
+	}
+
+	if c.ShutdownCommand != "" && !c.ShutdownBeforeImage {
+		c.warnings = append(c.warnings, "'shutdown_command' is ignored unless 'shutdown_before_image' is set")
+	}
+
+	// The WinRM password is scrubbed before the shutdown, after which the
+	// communicator can no longer run the shutdown command
+	if c.RotateWinRMPassword && c.ShutdownBeforeImage && c.ShutdownCommand != "" && !c.SkipCreateImage {
+		errs = packersdk.MultiErrorAppend(errs,
+			errors.New("'rotate_winrm_password' changes the WinRM password before the shutdown, so 'shutdown_command' cannot be set; 'shutdown_before_image' stops the instance through the API without it"))
+	}
+
+	if c.SkipCreateImage {
+		imageOptions := []struct {
+			key string
+			set bool
+		}{
+			{"image_name", c.ImageName != "" && !c.BootVolumeBackup},
+			{"image_version_scheme", c.ImageVersionScheme != ""},
+			{"image_compartment_ocid", c.ImageCompartmentID != c.CompartmentID},
+			{"image_destination_compartment_ocid", c.ImageDestinationCompartmentID != ""},
+			{"image_launch_mode", c.LaunchMode != ""},
+			{"nic_attachment_type", c.NicAttachmentType != ""},
+			{"image_capabilities", len(c.ImageCapabilities) > 0},
+			{"iscsi_multipath_device_supported", c.IscsiMultipathDeviceSupported},
+			{"consistent_volume_naming", c.ConsistentVolumeNaming},
+			{"paravirtualization_attachment_version", c.ParavirtualizationAttachmentVersion != 0},
+			{"image_firmware", c.ImageFirmware != ""},
+			{"image_operating_system", c.ImageOperatingSystem != ""},
+			{"image_operating_system_version", c.ImageOperatingSystemVersion != ""},
+			{"tags", len(c.Tags) > 0 && !c.BootVolumeBackup},
+			{"defined_tags", len(c.DefinedTags) > 0 && !c.BootVolumeBackup},
+			{"image_lock_bucket", c.ImageLockBucket != ""},
+			{"delete_failed_image", c.DeleteFailedImage},
+			{"image_creation_timeout", c.ImageCreationTimeout != 0},
+			{"image_polling_interval", c.ImagePollingInterval != 0},
+			{"first_boot_validation", len(c.FirstBootValidation.Assertions) > 0},
+			{"pause_before_capture", c.PauseBeforeCapture != ""},
+			{"capture_shape_config", c.CaptureShapeConfig != FlexShapeConfig{}},
+			{"source_control_tags", c.SourceControlTags},
+			{"provenance_tags", c.ProvenanceTags},
+			{"shutdown_before_image", c.ShutdownBeforeImage},
+			{"restart_after_image", c.RestartAfterImage},
+			{"export_to_object_storage", c.ExportToObjectStorage != ImageExportConfig{}},
+			{"image_copy_regions", len(c.ImageCopyRegions) > 0},
+			{"image_copy_names", len(c.ImageCopyNames) > 0},
+			{"image_copy_compartment_ocids", len(c.ImageCopyCompartments) > 0},
+			{"image_retention", c.ImageRetention != ImageRetentionConfig{}},
+			{"marketplace_publication", c.MarketplacePublication != MarketplacePublicationConfig{}},
+			{"manifest_path", c.ManifestPath != ""},
+			{"manifest_export_sha256", c.ManifestExportSHA256},
+		}
+		for _, o := range imageOptions {
+			if o.set {
+				c.warnings = append(c.warnings, fmt.Sprintf("'%s' is ignored when 'skip_create_image' is set", o.key))
+			}
+		}
+	}
+
+	// Tags set in the template take precedence
+	if (c.SourceControlTags || c.ProvenanceTags) && !c.SkipCreateImage {
+		tags := sourceControlTags(c.ctx.TemplatePath)
+		if c.ProvenanceTags {
+			tags = provenanceTags(tags, c.ctx.TemplatePath, c.PackerCoreVersion, time.Now())
+		}
+		for k, v := range tags {
+			if _, ok := c.Tags[k]; ok {
+				continue
+			}
+			if c.Tags == nil {
+				c.Tags = map[string]string{}
+			}
+			c.Tags[k] = v
+		}
+	}
+
+	if c.ImageName != "" {
+		c.imageNameTemplate = c.ImageName
+		c.ctx.Data = newImageNameData(c.ImageName)
+		name, err := interpolate.Render(c.ImageName, &c.ctx)
+		c.ctx.Data = nil
+		if err != nil {
+			errs = packersdk.MultiErrorAppend(errs,
+				fmt.Errorf("unable to parse image name: %s", err))
+		} else {
+			c.ImageName = name
+		}
+	}
+
+	switch c.ImageVersionScheme {
+	case "", imageVersionTimestamp, imageVersionCounter:
+		if c.ImageVersion != "" {
+			c.warnings = append(c.warnings, "'image_version' is ignored unless 'image_version_scheme' is semver")
+		}
+	case imageVersionSemver:
+		if _, err := version.NewSemver(c.ImageVersion); err != nil {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'image_version' must be a semantic version with the semver 'image_version_scheme': %s", err))
+		}
+	default:
+		errs = packersdk.MultiErrorAppend(
+			errs, fmt.Errorf("'image_version_scheme' must be one of %s, %s or %s", imageVersionTimestamp, imageVersionCounter, imageVersionSemver))
+	}
+	if c.ImageVersionScheme != "" && c.usesBuildCounter() {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'image_version_scheme' cannot be combined with {{ .BuildCounter }} in 'image_name'"))
+	}
+
+	// The base image needed by the instance name is only known at launch,
+	// so the name is rendered with a placeholder to validate it
+	if c.InstanceName != nil {
+		c.instanceNameTemplate = *c.InstanceName
+		name, err := c.renderInstanceName("source-image")
+		if err != nil {
+			errs = packersdk.MultiErrorAppend(errs,
+				fmt.Errorf("unable to parse instance name: %s", err))
+		} else if !c.usesSourceImageName() {
+			c.InstanceName = &name
+			c.instanceNameTemplate = ""
+		}
+	}
+
+	if label := c.CreateVnicDetails.HostnameLabel; label != nil && !hostnameLabelRe.MatchString(*label) {
+		errs = packersdk.MultiErrorAppend(errs, fmt.Errorf(
+			"'create_vnic_details.hostname_label' must start with a letter and contain at most 63 letters, digits and hyphens, got %q", *label))
+	}
+
 	if c.ImageName == "" {
 		name, err := interpolate.Render("packer-{{timestamp}}", nil)
 		if err != nil {
@@ -412,6 +1335,47 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		}
 	}
 
+	if _, ok := c.Metadata["user_data"]; ok && (c.UserData != "" || c.UserDataFile != "") {
+		c.warnings = append(c.warnings,
+			"'metadata[\"user_data\"]' is overridden by 'user_data' or 'user_data_file'")
+	}
+
+	if c.ReservedPublicIPID != "" && c.PublicIPLifetime == "" {
+		c.PublicIPLifetime = "RESERVED"
+	}
+
+	switch c.PublicIPLifetime {
+	case "", "EPHEMERAL":
+		if c.ReservedPublicIPID != "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'reserved_public_ip_ocid' cannot be used with an EPHEMERAL 'public_ip_lifetime'"))
+		}
+		if c.RetainReservedPublicIP {
+			c.warnings = append(c.warnings,
+				"'retain_reserved_public_ip' is ignored unless 'public_ip_lifetime' is RESERVED")
+		}
+	case "RESERVED":
+		// A VNIC cannot have both an ephemeral and a reserved public IP
+		if c.CreateVnicDetails.AssignPublicIp != nil && *c.CreateVnicDetails.AssignPublicIp {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'create_vnic_details.assign_public_ip' cannot be true when 'public_ip_lifetime' is RESERVED"))
+		}
+		assignPublicIp := false
+		c.CreateVnicDetails.AssignPublicIp = &assignPublicIp
+		if c.UsePrivateIP || c.UsePrivateFQDN {
+			c.warnings = append(c.warnings,
+				"a RESERVED 'public_ip_lifetime' is not used to connect when 'use_private_ip' or 'use_private_fqdn' is set")
+		}
+	default:
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'public_ip_lifetime' must be one of EPHEMERAL or RESERVED"))
+	}
+
+	if c.CreateVnicDetails.AssignPublicIp != nil && !*c.CreateVnicDetails.AssignPublicIp && c.PublicIPLifetime != "RESERVED" && !c.UsePrivateIP && !c.UsePrivateFQDN && !c.UseIPv6 {
+		c.warnings = append(c.warnings,
+			"'create_vnic_details.assign_public_ip' is false but neither 'use_private_ip' nor 'use_private_fqdn' is set; the instance will have no address to connect to")
+	}
+
 	// Optional UserData config
 	if c.UserData != "" && c.UserDataFile != "" {
 		errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("Only one of user_data or user_data_file can be specified."))
@@ -436,18 +1400,441 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		}
 	}
 
+	if c.ConfigureWinRM || c.RotateWinRMPassword {
+		switch {
+		case c.Comm.Type != "winrm":
+			errs = packersdk.MultiErrorAppend(errs,
+				errors.New("'configure_winrm' and 'rotate_winrm_password' require the winrm communicator"))
+		case c.UserData != "":
+			errs = packersdk.MultiErrorAppend(errs,
+				errors.New("'configure_winrm' and 'rotate_winrm_password' set the user_data of the instance, so 'user_data' and 'user_data_file' cannot be set"))
+		case c.RotateWinRMPassword && c.Comm.WinRMPassword != "":
+			errs = packersdk.MultiErrorAppend(errs,
+				errors.New("'rotate_winrm_password' generates the WinRM password, so 'winrm_password' cannot be set"))
+		default:
+			var password string
+			if c.RotateWinRMPassword {
+				password, err = generatePassword()
+				if err != nil {
+					errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("Error generating WinRM password: %s", err))
+				}
+				c.Comm.WinRMPassword = password
+				packersdk.LogSecretFilter.Set(password)
+			}
+			c.UserData = winrmUserData(c.ConfigureWinRM, c.Comm.WinRMUser, password)
+		}
+
+		if c.ConfigureWinRM && !c.Comm.WinRMUseSSL {
+			c.warnings = append(c.warnings,
+				"'configure_winrm' only enables a WinRM HTTPS listener; set 'winrm_use_ssl' and 'winrm_insecure' to connect to it")
+		}
+	}
+
+	// Compress user data that would not fit in the instance metadata
+	if c.UserData != "" && metadataSize(c.Metadata, c.UserData) > maxMetadataSize {
+		compressed, err := gzipUserData(c.UserData)
+		if err != nil {
+			errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("Problem compressing user data: %s", err))
+		} else {
+			log.Printf("[DEBUG] gzip compressed user data from %d to %d bytes", len(c.UserData), len(compressed))
+			c.UserData = compressed
+			if size := metadataSize(c.Metadata, c.UserData); size > maxMetadataSize {
+				errs = packersdk.MultiErrorAppend(errs, fmt.Errorf(
+					"user data is too large: instance metadata would be %d bytes after gzip and base64 encoding, the maximum is %d bytes", size, maxMetadataSize))
+			}
+		}
+	}
+
+	if c.SecurityListID != "" && len(c.SecurityListSourceCidrs) == 0 {
+		if c.UseIPv6 {
+			c.SecurityListSourceCidrs = []string{"::/0"}
+		} else {
+			c.SecurityListSourceCidrs = []string{"0.0.0.0/0"}
+		}
+	}
+
+	if c.SecurityListID == "" && len(c.SecurityListSourceCidrs) > 0 {
+		c.warnings = append(c.warnings,
+			"'security_list_source_cidrs' is ignored when 'security_list_ocid' is not specified")
+	}
+
+	if c.UsePrivateIP && c.UsePrivateFQDN {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("Only one of use_private_ip or use_private_fqdn can be specified."))
+	}
+
+	if c.UseIPv6 && (c.UsePrivateIP || c.UsePrivateFQDN) {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'use_ipv6' cannot be used along with 'use_private_ip' or 'use_private_fqdn'."))
+	}
+
 	// Validate LaunchMode
 	if c.LaunchMode != "" && c.LaunchMode != "NATIVE" && c.LaunchMode != "EMULATED" && c.LaunchMode != "PARAVIRTUALIZED" && c.LaunchMode != "CUSTOM" {
 		errs = packersdk.MultiErrorAppend(
 			errs, errors.New("LaunchMode must be one of NATIVE, EMULATED, PARAVIRTUALIZED, or CUSTOM"))
 	}
 
+	// Validate LaunchOptions
+	if c.LaunchOptions.BootVolumeType != "" {
+		if v, ok := core.GetMappingLaunchOptionsBootVolumeTypeEnum(c.LaunchOptions.BootVolumeType); ok {
+			c.LaunchOptions.BootVolumeType = string(v)
+		} else {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'launch_options.boot_volume_type' must be one of ISCSI, SCSI, IDE, VFIO, or PARAVIRTUALIZED"))
+		}
+	}
+
+	if c.LaunchOptions.Firmware != "" {
+		if v, ok := core.GetMappingLaunchOptionsFirmwareEnum(c.LaunchOptions.Firmware); ok {
+			c.LaunchOptions.Firmware = string(v)
+		} else {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'launch_options.firmware' must be one of BIOS or UEFI_64"))
+		}
+	}
+
+	if c.LaunchOptions.NetworkType != "" {
+		if v, ok := core.GetMappingLaunchOptionsNetworkTypeEnum(c.LaunchOptions.NetworkType); ok {
+			c.LaunchOptions.NetworkType = string(v)
+		} else {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'launch_options.network_type' must be one of E1000, VFIO, or PARAVIRTUALIZED"))
+		}
+	}
+
+	if c.LaunchOptions.RemoteDataVolumeType != "" {
+		if v, ok := core.GetMappingLaunchOptionsRemoteDataVolumeTypeEnum(c.LaunchOptions.RemoteDataVolumeType); ok {
+			c.LaunchOptions.RemoteDataVolumeType = string(v)
+		} else {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'launch_options.remote_data_volume_type' must be one of ISCSI, SCSI, IDE, VFIO, or PARAVIRTUALIZED"))
+		}
+	}
+
+	if c.LaunchOptions.IsPvEncryptionInTransitEnabled != nil && *c.LaunchOptions.IsPvEncryptionInTransitEnabled &&
+		c.LaunchOptions.BootVolumeType != "" && c.LaunchOptions.BootVolumeType != "PARAVIRTUALIZED" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'launch_options.is_pv_encryption_in_transit_enabled' requires a PARAVIRTUALIZED 'launch_options.boot_volume_type'"))
+	}
+
+	if c.PlatformConfig != (PlatformConfig{}) {
+		if v, ok := core.GetMappingLaunchInstancePlatformConfigTypeEnum(c.PlatformConfig.Type); ok {
+			c.PlatformConfig.Type = string(v)
+			if _, err := launchPlatformConfig(c.PlatformConfig); err != nil {
+				errs = packersdk.MultiErrorAppend(errs, err)
+			}
+		} else {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'platform_config.type' must be one of %s", strings.Join(core.GetLaunchInstancePlatformConfigTypeEnumStringValues(), ", ")))
+		}
+	}
+
+	// Validate AgentConfig
+	for i, plugin := range c.AgentConfig.PluginsConfig {
+		if plugin.Name == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'agent_config.plugins_config[%d].name' must be specified", i))
+		}
+		if v, ok := core.GetMappingInstanceAgentPluginConfigDetailsDesiredStateEnum(plugin.DesiredState); ok {
+			c.AgentConfig.PluginsConfig[i].DesiredState = string(v)
+		} else {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'agent_config.plugins_config[%d].desired_state' must be one of ENABLED or DISABLED", i))
+		}
+	}
+
 	// Validate NicAttachmentType
 	if c.NicAttachmentType != "" && c.NicAttachmentType != "VFIO" && c.NicAttachmentType != "E1000" && c.NicAttachmentType != "PARAVIRTUALIZED" {
 		errs = packersdk.MultiErrorAppend(
 			errs, errors.New("NicAttachmentType must be one of VFIO, E1000, or PARAVIRTUALIZED"))
 	}
 
+	if c.ParavirtualizationAttachmentVersion != 0 && c.ParavirtualizationAttachmentVersion != 1 && c.ParavirtualizationAttachmentVersion != 2 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'paravirtualization_attachment_version' must be 1 or 2"))
+	}
+
+	if c.ImageFirmware != "" {
+		if v, ok := core.GetMappingLaunchOptionsFirmwareEnum(c.ImageFirmware); ok {
+			c.ImageFirmware = string(v)
+		} else {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'image_firmware' must be one of BIOS or UEFI_64"))
+		}
+	}
+
+	// The dedicated options set the same capabilities
+	dedicatedCapabilities := []struct {
+		key, option string
+		set         bool
+	}{
+		{"Compute.LaunchMode", "image_launch_mode", c.LaunchMode != ""},
+		{"Network.AttachmentType", "nic_attachment_type", c.NicAttachmentType != ""},
+		{iscsiMultipathCapability, "iscsi_multipath_device_supported", c.IscsiMultipathDeviceSupported},
+		{consistentVolumeNamingCapability, "consistent_volume_naming", c.ConsistentVolumeNaming},
+		{paravirtualizationAttachmentVersionCapability, "paravirtualization_attachment_version", c.ParavirtualizationAttachmentVersion != 0},
+		{firmwareCapability, "image_firmware", c.ImageFirmware != ""},
+	}
+	for _, d := range dedicatedCapabilities {
+		if _, ok := c.ImageCapabilities[d.key]; ok && d.set {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'image_capabilities' cannot set %s along with '%s'", d.key, d.option))
+		}
+	}
+
+	if c.Timeouts.Compute < 0 || c.Timeouts.Network < 0 || c.Timeouts.ObjectStorage < 0 || c.Timeouts.PollingInterval < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'timeouts' durations must not be negative"))
+	}
+
+	if c.Timeouts.PollingInterval == 0 {
+		c.Timeouts.PollingInterval = 5 * time.Second
+	}
+
+	if c.Timeouts.AdaptivePolling && c.Timeouts.AdaptivePollingCache == "" {
+		c.Timeouts.AdaptivePollingCache = defaultDurationCachePath()
+	}
+
+	if c.HTTPClient.MaxIdleConns < 0 || c.HTTPClient.MaxIdleConnsPerHost < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'http_client' connection limits must not be negative"))
+	}
+
+	if c.HTTPClient.IdleConnTimeout < 0 || c.HTTPClient.TLSHandshakeTimeout < 0 || c.HTTPClient.RequestTimeout < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'http_client' durations must not be negative"))
+	}
+
+	if c.HTTPClient.MaxIdleConns == 0 {
+		c.HTTPClient.MaxIdleConns = 100
+	}
+
+	if c.HTTPClient.MaxIdleConnsPerHost == 0 {
+		c.HTTPClient.MaxIdleConnsPerHost = 10
+	}
+
+	if c.HTTPClient.IdleConnTimeout == 0 {
+		c.HTTPClient.IdleConnTimeout = 90 * time.Second
+	}
+
+	if c.HTTPClient.TLSHandshakeTimeout == 0 {
+		c.HTTPClient.TLSHandshakeTimeout = 10 * time.Second
+	}
+
+	if c.HTTPClient.RequestTimeout == 0 {
+		c.HTTPClient.RequestTimeout = 60 * time.Second
+	}
+
+	switch c.InstanceDisposal {
+	case "":
+		c.InstanceDisposal = instanceDisposalTerminate
+	case instanceDisposalTerminate:
+	case instanceDisposalStop:
+		if c.PreserveBootVolume && c.BootVolumeID == "" {
+			c.warnings = append(c.warnings,
+				"'preserve_boot_volume' is ignored when 'instance_disposal' is \"stop\"")
+		}
+	default:
+		errs = packersdk.MultiErrorAppend(
+			errs, fmt.Errorf("'instance_disposal' must be %q or %q", instanceDisposalTerminate, instanceDisposalStop))
+	}
+
+	if c.RestartAfterImage && (!c.ShutdownBeforeImage || (c.InstanceDisposal != instanceDisposalStop && !c.KeepInstanceAlive)) {
+		c.warnings = append(c.warnings,
+			"'restart_after_image' is ignored unless 'shutdown_before_image' is set and 'instance_disposal' is \"stop\" or 'keep_instance_alive' is set")
+		c.RestartAfterImage = false
+	}
+
+	if c.PreemptibleInstanceConfig.PreserveBootVolume != nil && !c.IsPreemptible {
+		c.warnings = append(c.warnings,
+			"'preemptible_instance_config' is ignored unless 'is_preemptible' is set")
+	}
+
+	if c.IsPreemptible && (c.BuildRetryAttempts == 0 || (len(c.BuildRetryOn) > 0 && !stringSliceContains(c.BuildRetryOn, "preemption"))) {
+		c.warnings = append(c.warnings,
+			"a preemptible instance may be terminated mid-build; set 'build_retry_attempts' to retry the build after a preemption")
+	}
+
+	if (c.ExportToObjectStorage != ImageExportConfig{}) {
+		if c.ExportToObjectStorage.Bucket == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'export_to_object_storage.bucket' must be specified"))
+		}
+		if c.ExportToObjectStorage.ExportFormat == "" {
+			c.ExportToObjectStorage.ExportFormat = string(core.ExportImageDetailsExportFormatOci)
+		}
+		if v, ok := core.GetMappingExportImageDetailsExportFormatEnum(c.ExportToObjectStorage.ExportFormat); ok {
+			c.ExportToObjectStorage.ExportFormat = string(v)
+		} else {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'export_to_object_storage.export_format' must be one of %s", strings.Join(core.GetExportImageDetailsExportFormatEnumStringValues(), ", ")))
+		}
+		if c.ExportToObjectStorage.ShareURLLifetime < 0 {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'export_to_object_storage.share_url_lifetime' must not be negative"))
+		}
+	}
+
+	if c.ManifestExportSHA256 {
+		if c.ManifestPath == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'manifest_export_sha256' requires 'manifest_path'"))
+		}
+		if (c.ExportToObjectStorage == ImageExportConfig{}) {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'manifest_export_sha256' requires 'export_to_object_storage'"))
+		}
+	}
+
+	copied := map[string]bool{}
+	if len(c.ImageCopyRegions) > 0 {
+		switch c.ExportToObjectStorage.ExportFormat {
+		case "":
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'image_copy_regions' requires 'export_to_object_storage'"))
+		case string(core.ExportImageDetailsExportFormatVhd), string(core.ExportImageDetailsExportFormatVdi):
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'image_copy_regions' requires an 'export_to_object_storage.export_format' of OCI, QCOW2 or VMDK"))
+		}
+		for _, region := range c.ImageCopyRegions {
+			if copied[region] {
+				errs = packersdk.MultiErrorAppend(
+					errs, fmt.Errorf("'image_copy_regions' contains region %s more than once", region))
+			}
+			copied[region] = true
+		}
+	}
+	for region := range c.ImageCopyNames {
+		if !copied[region] {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'image_copy_names' contains region %s, which is not in 'image_copy_regions'", region))
+		}
+	}
+
+	if len(c.ImageCopyCompartments) > 0 {
+		switch c.ExportToObjectStorage.ExportFormat {
+		case "":
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'image_copy_compartment_ocids' requires 'export_to_object_storage'"))
+		case string(core.ExportImageDetailsExportFormatVhd), string(core.ExportImageDetailsExportFormatVdi):
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'image_copy_compartment_ocids' requires an 'export_to_object_storage.export_format' of OCI, QCOW2 or VMDK"))
+		}
+		imageCompartment := c.ImageCompartmentID
+		if c.ImageDestinationCompartmentID != "" {
+			imageCompartment = c.ImageDestinationCompartmentID
+		}
+		copiedTo := map[string]bool{imageCompartment: true}
+		for _, compartment := range c.ImageCopyCompartments {
+			if compartment == imageCompartment {
+				errs = packersdk.MultiErrorAppend(
+					errs, fmt.Errorf("'image_copy_compartment_ocids' contains compartment %s of the image", compartment))
+			} else if copiedTo[compartment] {
+				errs = packersdk.MultiErrorAppend(
+					errs, fmt.Errorf("'image_copy_compartment_ocids' contains compartment %s more than once", compartment))
+			}
+			copiedTo[compartment] = true
+		}
+	}
+
+	if (c.ImageRetention != ImageRetentionConfig{}) {
+		if c.ImageRetention.KeepReleases < 1 {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'image_retention.keep_releases' must be at least 1"))
+		}
+		if c.ImageRetention.NameRegex == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'image_retention.name_regex' must be specified"))
+		} else if _, err := regexp.Compile(c.ImageRetention.NameRegex); err != nil {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'image_retention.name_regex' is invalid: %s", err))
+		}
+	}
+
+	if (c.MarketplacePublication != MarketplacePublicationConfig{}) {
+		if c.MarketplacePublication.ShortDescription == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'marketplace_publication.short_description' must be specified"))
+		}
+		if c.MarketplacePublication.SupportEmail == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'marketplace_publication.support_email' must be specified"))
+		}
+		if !c.MarketplacePublication.AgreementAcknowledged {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'marketplace_publication.agreement_acknowledged' must be true to publish the image"))
+		}
+		if c.MarketplacePublication.CompartmentID == "" {
+			c.MarketplacePublication.CompartmentID = c.ImageCompartmentID
+		}
+	}
+
+	if c.FirstBootValidation.OutputDirectory == "" {
+		c.FirstBootValidation.OutputDirectory = "first-boot-validation"
+	}
+
+	for i := range c.BlockVolumes {
+		volume := &c.BlockVolumes[i]
+		switch volume.AttachmentType {
+		case "":
+			volume.AttachmentType = volumeAttachmentTypeParavirtualized
+		case volumeAttachmentTypeParavirtualized, volumeAttachmentTypeISCSI:
+		default:
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'block_volumes[%d].attachment_type' must be %q or %q", i, volumeAttachmentTypeParavirtualized, volumeAttachmentTypeISCSI))
+		}
+		if volume.VolumeID != "" {
+			if volume.SizeInGBs != 0 || volume.VpusPerGB != nil || volume.KmsKeyID != "" || volume.DisplayName != nil {
+				errs = packersdk.MultiErrorAppend(
+					errs, fmt.Errorf("'block_volumes[%d].volume_ocid' cannot be combined with the options of a new volume", i))
+			}
+			continue
+		}
+		if volume.SizeInGBs < 50 || volume.SizeInGBs > 32768 {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'block_volumes[%d].size_in_gbs' must be between 50 and 32768 GBs", i))
+		}
+		if volume.VpusPerGB != nil && (*volume.VpusPerGB < 0 || *volume.VpusPerGB > 120 || *volume.VpusPerGB%10 != 0) {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'block_volumes[%d].vpus_per_gb' must be a multiple of 10 between 0 and 120", i))
+		}
+	}
+
+	if c.BootVolumeVpusPerGB != nil && (*c.BootVolumeVpusPerGB < 10 || *c.BootVolumeVpusPerGB > 120 || *c.BootVolumeVpusPerGB%10 != 0) {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'boot_volume_vpus_per_gb' must be a multiple of 10 between 10 and 120"))
+	}
+
+	if c.ImageLockTimeout < 0 || c.ImageLockTTL < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'image_lock_timeout' and 'image_lock_ttl' must not be negative"))
+	}
+
+	if c.ImageCreationTimeout < 0 || c.ImagePollingInterval < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'image_creation_timeout' and 'image_polling_interval' must not be negative"))
+	}
+
+	if c.MaxRunDuration < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'max_run_duration' must not be negative"))
+	}
+
+	if c.BuildRetryAttempts < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'build_retry_attempts' must not be negative"))
+	}
+
+	if len(c.BuildRetryOn) == 0 {
+		c.BuildRetryOn = buildFailureClasses
+	}
+	for _, class := range c.BuildRetryOn {
+		if !stringSliceContains(buildFailureClasses, class) {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'build_retry_on' values must be one of %s, got %q", strings.Join(buildFailureClasses, ", "), class))
+		}
+	}
+
 	// Set default boot volume size to 50 if not set
 	// Check if size set is allowed by OCI
 	if c.BootVolumeSizeInGBs != 0 && (c.BootVolumeSizeInGBs < 50 || c.BootVolumeSizeInGBs > 16384) {
@@ -462,22 +1849,24 @@ func (c *Config) Prepare(raws ...interface{}) error {
 	return nil
 }
 
-// getDefaultOCISettingsPath uses os/user to compute the default
-// config file location ($HOME/.oci/config).
-func getDefaultOCISettingsPath() (string, error) {
-	u, err := user.Current()
-	if err != nil {
-		return "", err
-	}
-
-	if u.HomeDir == "" {
-		return "", fmt.Errorf("Unable to determine the home directory for the current user.")
+// imageCreationTimeout returns how long to wait for the image to be created.
+func (c *Config) imageCreationTimeout() time.Duration {
+	if c.ImageCreationTimeout != 0 {
+		return c.ImageCreationTimeout
 	}
+	return c.Timeouts.Compute
+}
 
-	path := filepath.Join(u.HomeDir, ".oci", "config")
-	if _, err := os.Stat(path); err != nil {
-		return "", err
+// apiKeyConfig returns the API signing key access options of the config.
+func (c *Config) apiKeyConfig() ocommon.APIKeyConfig {
+	return ocommon.APIKeyConfig{
+		ConfigFile:        c.AccessCfgFile,
+		ConfigFileProfile: c.AccessCfgFileAccount,
+		UserID:            c.UserID,
+		TenancyID:         c.TenancyID,
+		Region:            c.Region,
+		Fingerprint:       c.Fingerprint,
+		KeyFile:           c.KeyFile,
+		PassPhrase:        c.PassPhrase,
 	}
-
-	return path, nil
 }
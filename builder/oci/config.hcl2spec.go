@@ -7,100 +7,217 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
+// FlatBlockVolumeConfig is an auto-generated flat version of BlockVolumeConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatBlockVolumeConfig struct {
+	DisplayName    *string `mapstructure:"display_name" required:"false" cty:"display_name" hcl:"display_name"`
+	SizeInGBs      *int64  `mapstructure:"size_in_gbs" required:"false" cty:"size_in_gbs" hcl:"size_in_gbs"`
+	VpusPerGB      *int64  `mapstructure:"vpus_per_gb" required:"false" cty:"vpus_per_gb" hcl:"vpus_per_gb"`
+	KmsKeyID       *string `mapstructure:"kms_key_ocid" required:"false" cty:"kms_key_ocid" hcl:"kms_key_ocid"`
+	AttachmentType *string `mapstructure:"attachment_type" required:"false" cty:"attachment_type" hcl:"attachment_type"`
+	VolumeID       *string `mapstructure:"volume_ocid" required:"false" cty:"volume_ocid" hcl:"volume_ocid"`
+}
+
+// FlatMapstructure returns a new FlatBlockVolumeConfig.
+// FlatBlockVolumeConfig is an auto-generated flat version of BlockVolumeConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*BlockVolumeConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatBlockVolumeConfig)
+}
+
+// HCL2Spec returns the hcl spec of a BlockVolumeConfig.
+// This spec is used by HCL to read the fields of BlockVolumeConfig.
+// The decoded values from this spec will then be applied to a FlatBlockVolumeConfig.
+func (*FlatBlockVolumeConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"display_name":    &hcldec.AttrSpec{Name: "display_name", Type: cty.String, Required: false},
+		"size_in_gbs":     &hcldec.AttrSpec{Name: "size_in_gbs", Type: cty.Number, Required: false},
+		"vpus_per_gb":     &hcldec.AttrSpec{Name: "vpus_per_gb", Type: cty.Number, Required: false},
+		"kms_key_ocid":    &hcldec.AttrSpec{Name: "kms_key_ocid", Type: cty.String, Required: false},
+		"attachment_type": &hcldec.AttrSpec{Name: "attachment_type", Type: cty.String, Required: false},
+		"volume_ocid":     &hcldec.AttrSpec{Name: "volume_ocid", Type: cty.String, Required: false},
+	}
+	return s
+}
+
 // FlatConfig is an auto-generated flat version of Config.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatConfig struct {
-	PackerBuildName           *string                    `mapstructure:"packer_build_name" cty:"packer_build_name" hcl:"packer_build_name"`
-	PackerBuilderType         *string                    `mapstructure:"packer_builder_type" cty:"packer_builder_type" hcl:"packer_builder_type"`
-	PackerCoreVersion         *string                    `mapstructure:"packer_core_version" cty:"packer_core_version" hcl:"packer_core_version"`
-	PackerDebug               *bool                      `mapstructure:"packer_debug" cty:"packer_debug" hcl:"packer_debug"`
-	PackerForce               *bool                      `mapstructure:"packer_force" cty:"packer_force" hcl:"packer_force"`
-	PackerOnError             *string                    `mapstructure:"packer_on_error" cty:"packer_on_error" hcl:"packer_on_error"`
-	PackerUserVars            map[string]string          `mapstructure:"packer_user_variables" cty:"packer_user_variables" hcl:"packer_user_variables"`
-	PackerSensitiveVars       []string                   `mapstructure:"packer_sensitive_variables" cty:"packer_sensitive_variables" hcl:"packer_sensitive_variables"`
-	Type                      *string                    `mapstructure:"communicator" cty:"communicator" hcl:"communicator"`
-	PauseBeforeConnect        *string                    `mapstructure:"pause_before_connecting" cty:"pause_before_connecting" hcl:"pause_before_connecting"`
-	SSHHost                   *string                    `mapstructure:"ssh_host" cty:"ssh_host" hcl:"ssh_host"`
-	SSHPort                   *int                       `mapstructure:"ssh_port" cty:"ssh_port" hcl:"ssh_port"`
-	SSHUsername               *string                    `mapstructure:"ssh_username" cty:"ssh_username" hcl:"ssh_username"`
-	SSHPassword               *string                    `mapstructure:"ssh_password" cty:"ssh_password" hcl:"ssh_password"`
-	SSHKeyPairName            *string                    `mapstructure:"ssh_keypair_name" undocumented:"true" cty:"ssh_keypair_name" hcl:"ssh_keypair_name"`
-	SSHTemporaryKeyPairName   *string                    `mapstructure:"temporary_key_pair_name" undocumented:"true" cty:"temporary_key_pair_name" hcl:"temporary_key_pair_name"`
-	SSHTemporaryKeyPairType   *string                    `mapstructure:"temporary_key_pair_type" cty:"temporary_key_pair_type" hcl:"temporary_key_pair_type"`
-	SSHTemporaryKeyPairBits   *int                       `mapstructure:"temporary_key_pair_bits" cty:"temporary_key_pair_bits" hcl:"temporary_key_pair_bits"`
-	SSHCiphers                []string                   `mapstructure:"ssh_ciphers" cty:"ssh_ciphers" hcl:"ssh_ciphers"`
-	SSHClearAuthorizedKeys    *bool                      `mapstructure:"ssh_clear_authorized_keys" cty:"ssh_clear_authorized_keys" hcl:"ssh_clear_authorized_keys"`
-	SSHKEXAlgos               []string                   `mapstructure:"ssh_key_exchange_algorithms" cty:"ssh_key_exchange_algorithms" hcl:"ssh_key_exchange_algorithms"`
-	SSHPrivateKeyFile         *string                    `mapstructure:"ssh_private_key_file" undocumented:"true" cty:"ssh_private_key_file" hcl:"ssh_private_key_file"`
-	SSHCertificateFile        *string                    `mapstructure:"ssh_certificate_file" cty:"ssh_certificate_file" hcl:"ssh_certificate_file"`
-	SSHPty                    *bool                      `mapstructure:"ssh_pty" cty:"ssh_pty" hcl:"ssh_pty"`
-	SSHTimeout                *string                    `mapstructure:"ssh_timeout" cty:"ssh_timeout" hcl:"ssh_timeout"`
-	SSHWaitTimeout            *string                    `mapstructure:"ssh_wait_timeout" undocumented:"true" cty:"ssh_wait_timeout" hcl:"ssh_wait_timeout"`
-	SSHAgentAuth              *bool                      `mapstructure:"ssh_agent_auth" undocumented:"true" cty:"ssh_agent_auth" hcl:"ssh_agent_auth"`
-	SSHDisableAgentForwarding *bool                      `mapstructure:"ssh_disable_agent_forwarding" cty:"ssh_disable_agent_forwarding" hcl:"ssh_disable_agent_forwarding"`
-	SSHHandshakeAttempts      *int                       `mapstructure:"ssh_handshake_attempts" cty:"ssh_handshake_attempts" hcl:"ssh_handshake_attempts"`
-	SSHBastionHost            *string                    `mapstructure:"ssh_bastion_host" cty:"ssh_bastion_host" hcl:"ssh_bastion_host"`
-	SSHBastionPort            *int                       `mapstructure:"ssh_bastion_port" cty:"ssh_bastion_port" hcl:"ssh_bastion_port"`
-	SSHBastionAgentAuth       *bool                      `mapstructure:"ssh_bastion_agent_auth" cty:"ssh_bastion_agent_auth" hcl:"ssh_bastion_agent_auth"`
-	SSHBastionUsername        *string                    `mapstructure:"ssh_bastion_username" cty:"ssh_bastion_username" hcl:"ssh_bastion_username"`
-	SSHBastionPassword        *string                    `mapstructure:"ssh_bastion_password" cty:"ssh_bastion_password" hcl:"ssh_bastion_password"`
-	SSHBastionInteractive     *bool                      `mapstructure:"ssh_bastion_interactive" cty:"ssh_bastion_interactive" hcl:"ssh_bastion_interactive"`
-	SSHBastionPrivateKeyFile  *string                    `mapstructure:"ssh_bastion_private_key_file" cty:"ssh_bastion_private_key_file" hcl:"ssh_bastion_private_key_file"`
-	SSHBastionCertificateFile *string                    `mapstructure:"ssh_bastion_certificate_file" cty:"ssh_bastion_certificate_file" hcl:"ssh_bastion_certificate_file"`
-	SSHFileTransferMethod     *string                    `mapstructure:"ssh_file_transfer_method" cty:"ssh_file_transfer_method" hcl:"ssh_file_transfer_method"`
-	SSHProxyHost              *string                    `mapstructure:"ssh_proxy_host" cty:"ssh_proxy_host" hcl:"ssh_proxy_host"`
-	SSHProxyPort              *int                       `mapstructure:"ssh_proxy_port" cty:"ssh_proxy_port" hcl:"ssh_proxy_port"`
-	SSHProxyUsername          *string                    `mapstructure:"ssh_proxy_username" cty:"ssh_proxy_username" hcl:"ssh_proxy_username"`
-	SSHProxyPassword          *string                    `mapstructure:"ssh_proxy_password" cty:"ssh_proxy_password" hcl:"ssh_proxy_password"`
-	SSHKeepAliveInterval      *string                    `mapstructure:"ssh_keep_alive_interval" cty:"ssh_keep_alive_interval" hcl:"ssh_keep_alive_interval"`
-	SSHReadWriteTimeout       *string                    `mapstructure:"ssh_read_write_timeout" cty:"ssh_read_write_timeout" hcl:"ssh_read_write_timeout"`
-	SSHRemoteTunnels          []string                   `mapstructure:"ssh_remote_tunnels" cty:"ssh_remote_tunnels" hcl:"ssh_remote_tunnels"`
-	SSHLocalTunnels           []string                   `mapstructure:"ssh_local_tunnels" cty:"ssh_local_tunnels" hcl:"ssh_local_tunnels"`
-	SSHPublicKey              []byte                     `mapstructure:"ssh_public_key" undocumented:"true" cty:"ssh_public_key" hcl:"ssh_public_key"`
-	SSHPrivateKey             []byte                     `mapstructure:"ssh_private_key" undocumented:"true" cty:"ssh_private_key" hcl:"ssh_private_key"`
-	WinRMUser                 *string                    `mapstructure:"winrm_username" cty:"winrm_username" hcl:"winrm_username"`
-	WinRMPassword             *string                    `mapstructure:"winrm_password" cty:"winrm_password" hcl:"winrm_password"`
-	WinRMHost                 *string                    `mapstructure:"winrm_host" cty:"winrm_host" hcl:"winrm_host"`
-	WinRMNoProxy              *bool                      `mapstructure:"winrm_no_proxy" cty:"winrm_no_proxy" hcl:"winrm_no_proxy"`
-	WinRMPort                 *int                       `mapstructure:"winrm_port" cty:"winrm_port" hcl:"winrm_port"`
-	WinRMTimeout              *string                    `mapstructure:"winrm_timeout" cty:"winrm_timeout" hcl:"winrm_timeout"`
-	WinRMUseSSL               *bool                      `mapstructure:"winrm_use_ssl" cty:"winrm_use_ssl" hcl:"winrm_use_ssl"`
-	WinRMInsecure             *bool                      `mapstructure:"winrm_insecure" cty:"winrm_insecure" hcl:"winrm_insecure"`
-	WinRMUseNTLM              *bool                      `mapstructure:"winrm_use_ntlm" cty:"winrm_use_ntlm" hcl:"winrm_use_ntlm"`
-	InstancePrincipals        *bool                      `mapstructure:"use_instance_principals" cty:"use_instance_principals" hcl:"use_instance_principals"`
-	SkipCreateImage           *bool                      `mapstructure:"skip_create_image" required:"false" cty:"skip_create_image" hcl:"skip_create_image"`
-	AccessCfgFile             *string                    `mapstructure:"access_cfg_file" cty:"access_cfg_file" hcl:"access_cfg_file"`
-	AccessCfgFileAccount      *string                    `mapstructure:"access_cfg_file_account" cty:"access_cfg_file_account" hcl:"access_cfg_file_account"`
-	UserID                    *string                    `mapstructure:"user_ocid" cty:"user_ocid" hcl:"user_ocid"`
-	TenancyID                 *string                    `mapstructure:"tenancy_ocid" cty:"tenancy_ocid" hcl:"tenancy_ocid"`
-	Region                    *string                    `mapstructure:"region" cty:"region" hcl:"region"`
-	Fingerprint               *string                    `mapstructure:"fingerprint" cty:"fingerprint" hcl:"fingerprint"`
-	KeyFile                   *string                    `mapstructure:"key_file" cty:"key_file" hcl:"key_file"`
-	PassPhrase                *string                    `mapstructure:"pass_phrase" cty:"pass_phrase" hcl:"pass_phrase"`
-	UsePrivateIP              *bool                      `mapstructure:"use_private_ip" cty:"use_private_ip" hcl:"use_private_ip"`
-	SecurityTokenFilePath     *string                    `mapstructure:"security_token_file" cty:"security_token_file" hcl:"security_token_file"`
-	AvailabilityDomain        *string                    `mapstructure:"availability_domain" cty:"availability_domain" hcl:"availability_domain"`
-	CompartmentID             *string                    `mapstructure:"compartment_ocid" cty:"compartment_ocid" hcl:"compartment_ocid"`
-	BaseImageID               *string                    `mapstructure:"base_image_ocid" cty:"base_image_ocid" hcl:"base_image_ocid"`
-	BaseImageFilter           *FlatListImagesRequest     `mapstructure:"base_image_filter" cty:"base_image_filter" hcl:"base_image_filter"`
-	ImageName                 *string                    `mapstructure:"image_name" cty:"image_name" hcl:"image_name"`
-	ImageCompartmentID        *string                    `mapstructure:"image_compartment_ocid" cty:"image_compartment_ocid" hcl:"image_compartment_ocid"`
-	LaunchMode                *string                    `mapstructure:"image_launch_mode" cty:"image_launch_mode" hcl:"image_launch_mode"`
-	NicAttachmentType         *string                    `mapstructure:"nic_attachment_type" cty:"nic_attachment_type" hcl:"nic_attachment_type"`
-	InstanceName              *string                    `mapstructure:"instance_name" cty:"instance_name" hcl:"instance_name"`
-	InstanceTags              map[string]string          `mapstructure:"instance_tags" cty:"instance_tags" hcl:"instance_tags"`
-	InstanceDefinedTagsJson   *string                    `mapstructure:"instance_defined_tags_json" required:"false" cty:"instance_defined_tags_json" hcl:"instance_defined_tags_json"`
-	InstanceOptions           *FlatInstanceOptionsConfig `mapstructure:"instance_options" cty:"instance_options" hcl:"instance_options"`
-	Shape                     *string                    `mapstructure:"shape" cty:"shape" hcl:"shape"`
-	ShapeConfig               *FlatFlexShapeConfig       `mapstructure:"shape_config" cty:"shape_config" hcl:"shape_config"`
-	BootVolumeSizeInGBs       *int64                     `mapstructure:"disk_size" cty:"disk_size" hcl:"disk_size"`
-	Metadata                  map[string]string          `mapstructure:"metadata" cty:"metadata" hcl:"metadata"`
-	UserData                  *string                    `mapstructure:"user_data" cty:"user_data" hcl:"user_data"`
-	UserDataFile              *string                    `mapstructure:"user_data_file" cty:"user_data_file" hcl:"user_data_file"`
-	SubnetID                  *string                    `mapstructure:"subnet_ocid" cty:"subnet_ocid" hcl:"subnet_ocid"`
-	CreateVnicDetails         *FlatCreateVNICDetails     `mapstructure:"create_vnic_details" cty:"create_vnic_details" hcl:"create_vnic_details"`
-	Tags                      map[string]string          `mapstructure:"tags" cty:"tags" hcl:"tags"`
-	DefinedTagsJson           *string                    `mapstructure:"defined_tags_json" required:"false" cty:"defined_tags_json" hcl:"defined_tags_json"`
+	PackerBuildName                     *string                           `mapstructure:"packer_build_name" cty:"packer_build_name" hcl:"packer_build_name"`
+	PackerBuilderType                   *string                           `mapstructure:"packer_builder_type" cty:"packer_builder_type" hcl:"packer_builder_type"`
+	PackerCoreVersion                   *string                           `mapstructure:"packer_core_version" cty:"packer_core_version" hcl:"packer_core_version"`
+	PackerDebug                         *bool                             `mapstructure:"packer_debug" cty:"packer_debug" hcl:"packer_debug"`
+	PackerForce                         *bool                             `mapstructure:"packer_force" cty:"packer_force" hcl:"packer_force"`
+	PackerOnError                       *string                           `mapstructure:"packer_on_error" cty:"packer_on_error" hcl:"packer_on_error"`
+	PackerUserVars                      map[string]string                 `mapstructure:"packer_user_variables" cty:"packer_user_variables" hcl:"packer_user_variables"`
+	PackerSensitiveVars                 []string                          `mapstructure:"packer_sensitive_variables" cty:"packer_sensitive_variables" hcl:"packer_sensitive_variables"`
+	Type                                *string                           `mapstructure:"communicator" cty:"communicator" hcl:"communicator"`
+	PauseBeforeConnect                  *string                           `mapstructure:"pause_before_connecting" cty:"pause_before_connecting" hcl:"pause_before_connecting"`
+	SSHHost                             *string                           `mapstructure:"ssh_host" cty:"ssh_host" hcl:"ssh_host"`
+	SSHPort                             *int                              `mapstructure:"ssh_port" cty:"ssh_port" hcl:"ssh_port"`
+	SSHUsername                         *string                           `mapstructure:"ssh_username" cty:"ssh_username" hcl:"ssh_username"`
+	SSHPassword                         *string                           `mapstructure:"ssh_password" cty:"ssh_password" hcl:"ssh_password"`
+	SSHKeyPairName                      *string                           `mapstructure:"ssh_keypair_name" undocumented:"true" cty:"ssh_keypair_name" hcl:"ssh_keypair_name"`
+	SSHTemporaryKeyPairName             *string                           `mapstructure:"temporary_key_pair_name" undocumented:"true" cty:"temporary_key_pair_name" hcl:"temporary_key_pair_name"`
+	SSHTemporaryKeyPairType             *string                           `mapstructure:"temporary_key_pair_type" cty:"temporary_key_pair_type" hcl:"temporary_key_pair_type"`
+	SSHTemporaryKeyPairBits             *int                              `mapstructure:"temporary_key_pair_bits" cty:"temporary_key_pair_bits" hcl:"temporary_key_pair_bits"`
+	SSHCiphers                          []string                          `mapstructure:"ssh_ciphers" cty:"ssh_ciphers" hcl:"ssh_ciphers"`
+	SSHClearAuthorizedKeys              *bool                             `mapstructure:"ssh_clear_authorized_keys" cty:"ssh_clear_authorized_keys" hcl:"ssh_clear_authorized_keys"`
+	SSHKEXAlgos                         []string                          `mapstructure:"ssh_key_exchange_algorithms" cty:"ssh_key_exchange_algorithms" hcl:"ssh_key_exchange_algorithms"`
+	SSHPrivateKeyFile                   *string                           `mapstructure:"ssh_private_key_file" undocumented:"true" cty:"ssh_private_key_file" hcl:"ssh_private_key_file"`
+	SSHCertificateFile                  *string                           `mapstructure:"ssh_certificate_file" cty:"ssh_certificate_file" hcl:"ssh_certificate_file"`
+	SSHPty                              *bool                             `mapstructure:"ssh_pty" cty:"ssh_pty" hcl:"ssh_pty"`
+	SSHTimeout                          *string                           `mapstructure:"ssh_timeout" cty:"ssh_timeout" hcl:"ssh_timeout"`
+	SSHWaitTimeout                      *string                           `mapstructure:"ssh_wait_timeout" undocumented:"true" cty:"ssh_wait_timeout" hcl:"ssh_wait_timeout"`
+	SSHAgentAuth                        *bool                             `mapstructure:"ssh_agent_auth" undocumented:"true" cty:"ssh_agent_auth" hcl:"ssh_agent_auth"`
+	SSHDisableAgentForwarding           *bool                             `mapstructure:"ssh_disable_agent_forwarding" cty:"ssh_disable_agent_forwarding" hcl:"ssh_disable_agent_forwarding"`
+	SSHHandshakeAttempts                *int                              `mapstructure:"ssh_handshake_attempts" cty:"ssh_handshake_attempts" hcl:"ssh_handshake_attempts"`
+	SSHBastionHost                      *string                           `mapstructure:"ssh_bastion_host" cty:"ssh_bastion_host" hcl:"ssh_bastion_host"`
+	SSHBastionPort                      *int                              `mapstructure:"ssh_bastion_port" cty:"ssh_bastion_port" hcl:"ssh_bastion_port"`
+	SSHBastionAgentAuth                 *bool                             `mapstructure:"ssh_bastion_agent_auth" cty:"ssh_bastion_agent_auth" hcl:"ssh_bastion_agent_auth"`
+	SSHBastionUsername                  *string                           `mapstructure:"ssh_bastion_username" cty:"ssh_bastion_username" hcl:"ssh_bastion_username"`
+	SSHBastionPassword                  *string                           `mapstructure:"ssh_bastion_password" cty:"ssh_bastion_password" hcl:"ssh_bastion_password"`
+	SSHBastionInteractive               *bool                             `mapstructure:"ssh_bastion_interactive" cty:"ssh_bastion_interactive" hcl:"ssh_bastion_interactive"`
+	SSHBastionPrivateKeyFile            *string                           `mapstructure:"ssh_bastion_private_key_file" cty:"ssh_bastion_private_key_file" hcl:"ssh_bastion_private_key_file"`
+	SSHBastionCertificateFile           *string                           `mapstructure:"ssh_bastion_certificate_file" cty:"ssh_bastion_certificate_file" hcl:"ssh_bastion_certificate_file"`
+	SSHFileTransferMethod               *string                           `mapstructure:"ssh_file_transfer_method" cty:"ssh_file_transfer_method" hcl:"ssh_file_transfer_method"`
+	SSHProxyHost                        *string                           `mapstructure:"ssh_proxy_host" cty:"ssh_proxy_host" hcl:"ssh_proxy_host"`
+	SSHProxyPort                        *int                              `mapstructure:"ssh_proxy_port" cty:"ssh_proxy_port" hcl:"ssh_proxy_port"`
+	SSHProxyUsername                    *string                           `mapstructure:"ssh_proxy_username" cty:"ssh_proxy_username" hcl:"ssh_proxy_username"`
+	SSHProxyPassword                    *string                           `mapstructure:"ssh_proxy_password" cty:"ssh_proxy_password" hcl:"ssh_proxy_password"`
+	SSHKeepAliveInterval                *string                           `mapstructure:"ssh_keep_alive_interval" cty:"ssh_keep_alive_interval" hcl:"ssh_keep_alive_interval"`
+	SSHReadWriteTimeout                 *string                           `mapstructure:"ssh_read_write_timeout" cty:"ssh_read_write_timeout" hcl:"ssh_read_write_timeout"`
+	SSHRemoteTunnels                    []string                          `mapstructure:"ssh_remote_tunnels" cty:"ssh_remote_tunnels" hcl:"ssh_remote_tunnels"`
+	SSHLocalTunnels                     []string                          `mapstructure:"ssh_local_tunnels" cty:"ssh_local_tunnels" hcl:"ssh_local_tunnels"`
+	SSHPublicKey                        []byte                            `mapstructure:"ssh_public_key" undocumented:"true" cty:"ssh_public_key" hcl:"ssh_public_key"`
+	SSHPrivateKey                       []byte                            `mapstructure:"ssh_private_key" undocumented:"true" cty:"ssh_private_key" hcl:"ssh_private_key"`
+	WinRMUser                           *string                           `mapstructure:"winrm_username" cty:"winrm_username" hcl:"winrm_username"`
+	WinRMPassword                       *string                           `mapstructure:"winrm_password" cty:"winrm_password" hcl:"winrm_password"`
+	WinRMHost                           *string                           `mapstructure:"winrm_host" cty:"winrm_host" hcl:"winrm_host"`
+	WinRMNoProxy                        *bool                             `mapstructure:"winrm_no_proxy" cty:"winrm_no_proxy" hcl:"winrm_no_proxy"`
+	WinRMPort                           *int                              `mapstructure:"winrm_port" cty:"winrm_port" hcl:"winrm_port"`
+	WinRMTimeout                        *string                           `mapstructure:"winrm_timeout" cty:"winrm_timeout" hcl:"winrm_timeout"`
+	WinRMUseSSL                         *bool                             `mapstructure:"winrm_use_ssl" cty:"winrm_use_ssl" hcl:"winrm_use_ssl"`
+	WinRMInsecure                       *bool                             `mapstructure:"winrm_insecure" cty:"winrm_insecure" hcl:"winrm_insecure"`
+	WinRMUseNTLM                        *bool                             `mapstructure:"winrm_use_ntlm" cty:"winrm_use_ntlm" hcl:"winrm_use_ntlm"`
+	InstancePrincipals                  *bool                             `mapstructure:"use_instance_principals" cty:"use_instance_principals" hcl:"use_instance_principals"`
+	SkipCreateImage                     *bool                             `mapstructure:"skip_create_image" required:"false" cty:"skip_create_image" hcl:"skip_create_image"`
+	DeleteFailedImage                   *bool                             `mapstructure:"delete_failed_image" required:"false" cty:"delete_failed_image" hcl:"delete_failed_image"`
+	BootVolumeBackup                    *bool                             `mapstructure:"boot_volume_backup" required:"false" cty:"boot_volume_backup" hcl:"boot_volume_backup"`
+	SkipPreflightChecks                 *bool                             `mapstructure:"skip_preflight_checks" required:"false" cty:"skip_preflight_checks" hcl:"skip_preflight_checks"`
+	Diagnostics                         *bool                             `mapstructure:"diagnostics" required:"false" cty:"diagnostics" hcl:"diagnostics"`
+	AccessCfgFile                       *string                           `mapstructure:"access_cfg_file" cty:"access_cfg_file" hcl:"access_cfg_file"`
+	AccessCfgFileAccount                *string                           `mapstructure:"access_cfg_file_account" cty:"access_cfg_file_account" hcl:"access_cfg_file_account"`
+	UserID                              *string                           `mapstructure:"user_ocid" cty:"user_ocid" hcl:"user_ocid"`
+	TenancyID                           *string                           `mapstructure:"tenancy_ocid" cty:"tenancy_ocid" hcl:"tenancy_ocid"`
+	Region                              *string                           `mapstructure:"region" cty:"region" hcl:"region"`
+	Fingerprint                         *string                           `mapstructure:"fingerprint" cty:"fingerprint" hcl:"fingerprint"`
+	KeyFile                             *string                           `mapstructure:"key_file" cty:"key_file" hcl:"key_file"`
+	PassPhrase                          *string                           `mapstructure:"pass_phrase" cty:"pass_phrase" hcl:"pass_phrase"`
+	UsePrivateIP                        *bool                             `mapstructure:"use_private_ip" cty:"use_private_ip" hcl:"use_private_ip"`
+	UsePrivateFQDN                      *bool                             `mapstructure:"use_private_fqdn" cty:"use_private_fqdn" hcl:"use_private_fqdn"`
+	UseImageConnectionHints             *bool                             `mapstructure:"use_image_connection_hints" cty:"use_image_connection_hints" hcl:"use_image_connection_hints"`
+	UseIPv6                             *bool                             `mapstructure:"use_ipv6" cty:"use_ipv6" hcl:"use_ipv6"`
+	SecurityTokenFilePath               *string                           `mapstructure:"security_token_file" cty:"security_token_file" hcl:"security_token_file"`
+	AvailabilityDomain                  *string                           `mapstructure:"availability_domain" cty:"availability_domain" hcl:"availability_domain"`
+	CompartmentID                       *string                           `mapstructure:"compartment_ocid" cty:"compartment_ocid" hcl:"compartment_ocid"`
+	AvailabilityDomains                 []string                          `mapstructure:"availability_domains" required:"false" cty:"availability_domains" hcl:"availability_domains"`
+	CapacityRetryTimeout                *string                           `mapstructure:"capacity_retry_timeout" required:"false" cty:"capacity_retry_timeout" hcl:"capacity_retry_timeout"`
+	CapacityRetryInterval               *string                           `mapstructure:"capacity_retry_interval" required:"false" cty:"capacity_retry_interval" hcl:"capacity_retry_interval"`
+	BaseImageID                         *string                           `mapstructure:"base_image_ocid" cty:"base_image_ocid" hcl:"base_image_ocid"`
+	BaseImageFilter                     *FlatListImagesRequest            `mapstructure:"base_image_filter" cty:"base_image_filter" hcl:"base_image_filter"`
+	BaseImage                           *string                           `mapstructure:"base_image" required:"false" cty:"base_image" hcl:"base_image"`
+	SourceBootVolumeID                  *string                           `mapstructure:"source_boot_volume_ocid" required:"false" cty:"source_boot_volume_ocid" hcl:"source_boot_volume_ocid"`
+	BootVolumeID                        *string                           `mapstructure:"boot_volume_ocid" required:"false" cty:"boot_volume_ocid" hcl:"boot_volume_ocid"`
+	BaseImageImport                     *FlatImageImportConfig            `mapstructure:"base_image_import" required:"false" cty:"base_image_import" hcl:"base_image_import"`
+	ImageName                           *string                           `mapstructure:"image_name" cty:"image_name" hcl:"image_name"`
+	ImageCompartmentID                  *string                           `mapstructure:"image_compartment_ocid" cty:"image_compartment_ocid" hcl:"image_compartment_ocid"`
+	LaunchMode                          *string                           `mapstructure:"image_launch_mode" cty:"image_launch_mode" hcl:"image_launch_mode"`
+	NicAttachmentType                   *string                           `mapstructure:"nic_attachment_type" cty:"nic_attachment_type" hcl:"nic_attachment_type"`
+	ImageCapabilities                   map[string]string                 `mapstructure:"image_capabilities" required:"false" cty:"image_capabilities" hcl:"image_capabilities"`
+	IscsiMultipathDeviceSupported       *bool                             `mapstructure:"iscsi_multipath_device_supported" required:"false" cty:"iscsi_multipath_device_supported" hcl:"iscsi_multipath_device_supported"`
+	ConsistentVolumeNaming              *bool                             `mapstructure:"consistent_volume_naming" required:"false" cty:"consistent_volume_naming" hcl:"consistent_volume_naming"`
+	ParavirtualizationAttachmentVersion *int                              `mapstructure:"paravirtualization_attachment_version" required:"false" cty:"paravirtualization_attachment_version" hcl:"paravirtualization_attachment_version"`
+	ImageFirmware                       *string                           `mapstructure:"image_firmware" required:"false" cty:"image_firmware" hcl:"image_firmware"`
+	ImageOperatingSystem                *string                           `mapstructure:"image_operating_system" required:"false" cty:"image_operating_system" hcl:"image_operating_system"`
+	ImageOperatingSystemVersion         *string                           `mapstructure:"image_operating_system_version" required:"false" cty:"image_operating_system_version" hcl:"image_operating_system_version"`
+	ImageVersionScheme                  *string                           `mapstructure:"image_version_scheme" required:"false" cty:"image_version_scheme" hcl:"image_version_scheme"`
+	ImageVersion                        *string                           `mapstructure:"image_version" required:"false" cty:"image_version" hcl:"image_version"`
+	ImageDestinationCompartmentID       *string                           `mapstructure:"image_destination_compartment_ocid" required:"false" cty:"image_destination_compartment_ocid" hcl:"image_destination_compartment_ocid"`
+	InstanceName                        *string                           `mapstructure:"instance_name" cty:"instance_name" hcl:"instance_name"`
+	InstanceTags                        map[string]string                 `mapstructure:"instance_tags" cty:"instance_tags" hcl:"instance_tags"`
+	InstanceDefinedTagsJson             *string                           `mapstructure:"instance_defined_tags_json" required:"false" cty:"instance_defined_tags_json" hcl:"instance_defined_tags_json"`
+	InstanceOptions                     *FlatInstanceOptionsConfig        `mapstructure:"instance_options" cty:"instance_options" hcl:"instance_options"`
+	LaunchOptions                       *FlatLaunchOptionsConfig          `mapstructure:"launch_options" cty:"launch_options" hcl:"launch_options"`
+	PlatformConfig                      *FlatPlatformConfig               `mapstructure:"platform_config" cty:"platform_config" hcl:"platform_config"`
+	AgentConfig                         *FlatInstanceAgentConfig          `mapstructure:"agent_config" cty:"agent_config" hcl:"agent_config"`
+	Shape                               *string                           `mapstructure:"shape" cty:"shape" hcl:"shape"`
+	ShapeConfig                         *FlatFlexShapeConfig              `mapstructure:"shape_config" cty:"shape_config" hcl:"shape_config"`
+	CaptureShapeConfig                  *FlatFlexShapeConfig              `mapstructure:"capture_shape_config" cty:"capture_shape_config" hcl:"capture_shape_config"`
+	BootVolumeSizeInGBs                 *int64                            `mapstructure:"disk_size" cty:"disk_size" hcl:"disk_size"`
+	BootVolumeKmsKeyID                  *string                           `mapstructure:"boot_volume_kms_key_ocid" required:"false" cty:"boot_volume_kms_key_ocid" hcl:"boot_volume_kms_key_ocid"`
+	BootVolumeVpusPerGB                 *int64                            `mapstructure:"boot_volume_vpus_per_gb" required:"false" cty:"boot_volume_vpus_per_gb" hcl:"boot_volume_vpus_per_gb"`
+	PreserveBootVolume                  *bool                             `mapstructure:"preserve_boot_volume" required:"false" cty:"preserve_boot_volume" hcl:"preserve_boot_volume"`
+	InstanceDisposal                    *string                           `mapstructure:"instance_disposal" required:"false" cty:"instance_disposal" hcl:"instance_disposal"`
+	KeepInstanceAlive                   *bool                             `mapstructure:"keep_instance_alive" required:"false" cty:"keep_instance_alive" hcl:"keep_instance_alive"`
+	FreeTier                            *bool                             `mapstructure:"free_tier" required:"false" cty:"free_tier" hcl:"free_tier"`
+	BlockVolumes                        []FlatBlockVolumeConfig           `mapstructure:"block_volumes" required:"false" cty:"block_volumes" hcl:"block_volumes"`
+	CapacityReservationID               *string                           `mapstructure:"capacity_reservation_ocid" required:"false" cty:"capacity_reservation_ocid" hcl:"capacity_reservation_ocid"`
+	FaultDomain                         *string                           `mapstructure:"fault_domain" required:"false" cty:"fault_domain" hcl:"fault_domain"`
+	IsPreemptible                       *bool                             `mapstructure:"is_preemptible" required:"false" cty:"is_preemptible" hcl:"is_preemptible"`
+	PreemptibleInstanceConfig           *FlatPreemptibleInstanceConfig    `mapstructure:"preemptible_instance_config" required:"false" cty:"preemptible_instance_config" hcl:"preemptible_instance_config"`
+	Metadata                            map[string]string                 `mapstructure:"metadata" cty:"metadata" hcl:"metadata"`
+	UserData                            *string                           `mapstructure:"user_data" cty:"user_data" hcl:"user_data"`
+	UserDataFile                        *string                           `mapstructure:"user_data_file" cty:"user_data_file" hcl:"user_data_file"`
+	ConfigureWinRM                      *bool                             `mapstructure:"configure_winrm" required:"false" cty:"configure_winrm" hcl:"configure_winrm"`
+	RotateWinRMPassword                 *bool                             `mapstructure:"rotate_winrm_password" required:"false" cty:"rotate_winrm_password" hcl:"rotate_winrm_password"`
+	SubnetID                            *string                           `mapstructure:"subnet_ocid" cty:"subnet_ocid" hcl:"subnet_ocid"`
+	CreateVnicDetails                   *FlatCreateVNICDetails            `mapstructure:"create_vnic_details" cty:"create_vnic_details" hcl:"create_vnic_details"`
+	VlanID                              *string                           `mapstructure:"vlan_ocid" required:"false" cty:"vlan_ocid" hcl:"vlan_ocid"`
+	PublicIPLifetime                    *string                           `mapstructure:"public_ip_lifetime" required:"false" cty:"public_ip_lifetime" hcl:"public_ip_lifetime"`
+	ReservedPublicIPID                  *string                           `mapstructure:"reserved_public_ip_ocid" required:"false" cty:"reserved_public_ip_ocid" hcl:"reserved_public_ip_ocid"`
+	RetainReservedPublicIP              *bool                             `mapstructure:"retain_reserved_public_ip" required:"false" cty:"retain_reserved_public_ip" hcl:"retain_reserved_public_ip"`
+	SecurityListID                      *string                           `mapstructure:"security_list_ocid" required:"false" cty:"security_list_ocid" hcl:"security_list_ocid"`
+	SecurityListSourceCidrs             []string                          `mapstructure:"security_list_source_cidrs" required:"false" cty:"security_list_source_cidrs" hcl:"security_list_source_cidrs"`
+	ProvisionerLogID                    *string                           `mapstructure:"provisioner_log_ocid" required:"false" cty:"provisioner_log_ocid" hcl:"provisioner_log_ocid"`
+	ImageLockBucket                     *string                           `mapstructure:"image_lock_bucket" required:"false" cty:"image_lock_bucket" hcl:"image_lock_bucket"`
+	ImageLockTimeout                    *string                           `mapstructure:"image_lock_timeout" required:"false" cty:"image_lock_timeout" hcl:"image_lock_timeout"`
+	ImageLockTTL                        *string                           `mapstructure:"image_lock_ttl" required:"false" cty:"image_lock_ttl" hcl:"image_lock_ttl"`
+	ImageCreationTimeout                *string                           `mapstructure:"image_creation_timeout" required:"false" cty:"image_creation_timeout" hcl:"image_creation_timeout"`
+	ImagePollingInterval                *string                           `mapstructure:"image_polling_interval" required:"false" cty:"image_polling_interval" hcl:"image_polling_interval"`
+	FirstBootValidation                 *FlatFirstBootValidationConfig    `mapstructure:"first_boot_validation" required:"false" cty:"first_boot_validation" hcl:"first_boot_validation"`
+	ExportToObjectStorage               *FlatImageExportConfig            `mapstructure:"export_to_object_storage" required:"false" cty:"export_to_object_storage" hcl:"export_to_object_storage"`
+	ImageCopyRegions                    []string                          `mapstructure:"image_copy_regions" required:"false" cty:"image_copy_regions" hcl:"image_copy_regions"`
+	ImageCopyNames                      map[string]string                 `mapstructure:"image_copy_names" required:"false" cty:"image_copy_names" hcl:"image_copy_names"`
+	ImageCopyCompartments               []string                          `mapstructure:"image_copy_compartment_ocids" required:"false" cty:"image_copy_compartment_ocids" hcl:"image_copy_compartment_ocids"`
+	ImageRetention                      *FlatImageRetentionConfig         `mapstructure:"image_retention" required:"false" cty:"image_retention" hcl:"image_retention"`
+	MarketplacePublication              *FlatMarketplacePublicationConfig `mapstructure:"marketplace_publication" required:"false" cty:"marketplace_publication" hcl:"marketplace_publication"`
+	ManifestPath                        *string                           `mapstructure:"manifest_path" required:"false" cty:"manifest_path" hcl:"manifest_path"`
+	ManifestExportSHA256                *bool                             `mapstructure:"manifest_export_sha256" required:"false" cty:"manifest_export_sha256" hcl:"manifest_export_sha256"`
+	CreateConsoleConnection             *bool                             `mapstructure:"create_console_connection" required:"false" cty:"create_console_connection" hcl:"create_console_connection"`
+	StreamConsoleOutput                 *bool                             `mapstructure:"stream_console_output" required:"false" cty:"stream_console_output" hcl:"stream_console_output"`
+	WaitForCloudInit                    *bool                             `mapstructure:"wait_for_cloud_init" required:"false" cty:"wait_for_cloud_init" hcl:"wait_for_cloud_init"`
+	CloudInitTimeout                    *string                           `mapstructure:"cloud_init_timeout" required:"false" cty:"cloud_init_timeout" hcl:"cloud_init_timeout"`
+	WaitForGPUDriver                    *bool                             `mapstructure:"wait_for_gpu_driver" required:"false" cty:"wait_for_gpu_driver" hcl:"wait_for_gpu_driver"`
+	GPUDriverTimeout                    *string                           `mapstructure:"gpu_driver_timeout" required:"false" cty:"gpu_driver_timeout" hcl:"gpu_driver_timeout"`
+	WaitForAgentPlugins                 []string                          `mapstructure:"wait_for_agent_plugins" required:"false" cty:"wait_for_agent_plugins" hcl:"wait_for_agent_plugins"`
+	AgentPluginsTimeout                 *string                           `mapstructure:"agent_plugins_timeout" required:"false" cty:"agent_plugins_timeout" hcl:"agent_plugins_timeout"`
+	BootstrapScript                     *string                           `mapstructure:"bootstrap_script" required:"false" cty:"bootstrap_script" hcl:"bootstrap_script"`
+	BootstrapTimeout                    *string                           `mapstructure:"bootstrap_timeout" required:"false" cty:"bootstrap_timeout" hcl:"bootstrap_timeout"`
+	PauseBeforeCapture                  *string                           `mapstructure:"pause_before_capture" required:"false" cty:"pause_before_capture" hcl:"pause_before_capture"`
+	ShutdownBeforeImage                 *bool                             `mapstructure:"shutdown_before_image" required:"false" cty:"shutdown_before_image" hcl:"shutdown_before_image"`
+	ShutdownCommand                     *string                           `mapstructure:"shutdown_command" required:"false" cty:"shutdown_command" hcl:"shutdown_command"`
+	RestartAfterImage                   *bool                             `mapstructure:"restart_after_image" required:"false" cty:"restart_after_image" hcl:"restart_after_image"`
+	Timeouts                            *FlatTimeoutsConfig               `mapstructure:"timeouts" required:"false" cty:"timeouts" hcl:"timeouts"`
+	FIPSMode                            *bool                             `mapstructure:"fips_mode" required:"false" cty:"fips_mode" hcl:"fips_mode"`
+	HTTPClient                          *FlatHTTPClientConfig             `mapstructure:"http_client" required:"false" cty:"http_client" hcl:"http_client"`
+	MaxRunDuration                      *string                           `mapstructure:"max_run_duration" required:"false" cty:"max_run_duration" hcl:"max_run_duration"`
+	BuildRetryAttempts                  *int                              `mapstructure:"build_retry_attempts" required:"false" cty:"build_retry_attempts" hcl:"build_retry_attempts"`
+	BuildRetryOn                        []string                          `mapstructure:"build_retry_on" required:"false" cty:"build_retry_on" hcl:"build_retry_on"`
+	Tags                                map[string]string                 `mapstructure:"tags" cty:"tags" hcl:"tags"`
+	SourceControlTags                   *bool                             `mapstructure:"source_control_tags" required:"false" cty:"source_control_tags" hcl:"source_control_tags"`
+	ProvenanceTags                      *bool                             `mapstructure:"provenance_tags" required:"false" cty:"provenance_tags" hcl:"provenance_tags"`
+	DefinedTagsJson                     *string                           `mapstructure:"defined_tags_json" required:"false" cty:"defined_tags_json" hcl:"defined_tags_json"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -115,97 +232,181 @@ func (*Config) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec }
 // The decoded values from this spec will then be applied to a FlatConfig.
 func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 	s := map[string]hcldec.Spec{
-		"packer_build_name":            &hcldec.AttrSpec{Name: "packer_build_name", Type: cty.String, Required: false},
-		"packer_builder_type":          &hcldec.AttrSpec{Name: "packer_builder_type", Type: cty.String, Required: false},
-		"packer_core_version":          &hcldec.AttrSpec{Name: "packer_core_version", Type: cty.String, Required: false},
-		"packer_debug":                 &hcldec.AttrSpec{Name: "packer_debug", Type: cty.Bool, Required: false},
-		"packer_force":                 &hcldec.AttrSpec{Name: "packer_force", Type: cty.Bool, Required: false},
-		"packer_on_error":              &hcldec.AttrSpec{Name: "packer_on_error", Type: cty.String, Required: false},
-		"packer_user_variables":        &hcldec.AttrSpec{Name: "packer_user_variables", Type: cty.Map(cty.String), Required: false},
-		"packer_sensitive_variables":   &hcldec.AttrSpec{Name: "packer_sensitive_variables", Type: cty.List(cty.String), Required: false},
-		"communicator":                 &hcldec.AttrSpec{Name: "communicator", Type: cty.String, Required: false},
-		"pause_before_connecting":      &hcldec.AttrSpec{Name: "pause_before_connecting", Type: cty.String, Required: false},
-		"ssh_host":                     &hcldec.AttrSpec{Name: "ssh_host", Type: cty.String, Required: false},
-		"ssh_port":                     &hcldec.AttrSpec{Name: "ssh_port", Type: cty.Number, Required: false},
-		"ssh_username":                 &hcldec.AttrSpec{Name: "ssh_username", Type: cty.String, Required: false},
-		"ssh_password":                 &hcldec.AttrSpec{Name: "ssh_password", Type: cty.String, Required: false},
-		"ssh_keypair_name":             &hcldec.AttrSpec{Name: "ssh_keypair_name", Type: cty.String, Required: false},
-		"temporary_key_pair_name":      &hcldec.AttrSpec{Name: "temporary_key_pair_name", Type: cty.String, Required: false},
-		"temporary_key_pair_type":      &hcldec.AttrSpec{Name: "temporary_key_pair_type", Type: cty.String, Required: false},
-		"temporary_key_pair_bits":      &hcldec.AttrSpec{Name: "temporary_key_pair_bits", Type: cty.Number, Required: false},
-		"ssh_ciphers":                  &hcldec.AttrSpec{Name: "ssh_ciphers", Type: cty.List(cty.String), Required: false},
-		"ssh_clear_authorized_keys":    &hcldec.AttrSpec{Name: "ssh_clear_authorized_keys", Type: cty.Bool, Required: false},
-		"ssh_key_exchange_algorithms":  &hcldec.AttrSpec{Name: "ssh_key_exchange_algorithms", Type: cty.List(cty.String), Required: false},
-		"ssh_private_key_file":         &hcldec.AttrSpec{Name: "ssh_private_key_file", Type: cty.String, Required: false},
-		"ssh_certificate_file":         &hcldec.AttrSpec{Name: "ssh_certificate_file", Type: cty.String, Required: false},
-		"ssh_pty":                      &hcldec.AttrSpec{Name: "ssh_pty", Type: cty.Bool, Required: false},
-		"ssh_timeout":                  &hcldec.AttrSpec{Name: "ssh_timeout", Type: cty.String, Required: false},
-		"ssh_wait_timeout":             &hcldec.AttrSpec{Name: "ssh_wait_timeout", Type: cty.String, Required: false},
-		"ssh_agent_auth":               &hcldec.AttrSpec{Name: "ssh_agent_auth", Type: cty.Bool, Required: false},
-		"ssh_disable_agent_forwarding": &hcldec.AttrSpec{Name: "ssh_disable_agent_forwarding", Type: cty.Bool, Required: false},
-		"ssh_handshake_attempts":       &hcldec.AttrSpec{Name: "ssh_handshake_attempts", Type: cty.Number, Required: false},
-		"ssh_bastion_host":             &hcldec.AttrSpec{Name: "ssh_bastion_host", Type: cty.String, Required: false},
-		"ssh_bastion_port":             &hcldec.AttrSpec{Name: "ssh_bastion_port", Type: cty.Number, Required: false},
-		"ssh_bastion_agent_auth":       &hcldec.AttrSpec{Name: "ssh_bastion_agent_auth", Type: cty.Bool, Required: false},
-		"ssh_bastion_username":         &hcldec.AttrSpec{Name: "ssh_bastion_username", Type: cty.String, Required: false},
-		"ssh_bastion_password":         &hcldec.AttrSpec{Name: "ssh_bastion_password", Type: cty.String, Required: false},
-		"ssh_bastion_interactive":      &hcldec.AttrSpec{Name: "ssh_bastion_interactive", Type: cty.Bool, Required: false},
-		"ssh_bastion_private_key_file": &hcldec.AttrSpec{Name: "ssh_bastion_private_key_file", Type: cty.String, Required: false},
-		"ssh_bastion_certificate_file": &hcldec.AttrSpec{Name: "ssh_bastion_certificate_file", Type: cty.String, Required: false},
-		"ssh_file_transfer_method":     &hcldec.AttrSpec{Name: "ssh_file_transfer_method", Type: cty.String, Required: false},
-		"ssh_proxy_host":               &hcldec.AttrSpec{Name: "ssh_proxy_host", Type: cty.String, Required: false},
-		"ssh_proxy_port":               &hcldec.AttrSpec{Name: "ssh_proxy_port", Type: cty.Number, Required: false},
-		"ssh_proxy_username":           &hcldec.AttrSpec{Name: "ssh_proxy_username", Type: cty.String, Required: false},
-		"ssh_proxy_password":           &hcldec.AttrSpec{Name: "ssh_proxy_password", Type: cty.String, Required: false},
-		"ssh_keep_alive_interval":      &hcldec.AttrSpec{Name: "ssh_keep_alive_interval", Type: cty.String, Required: false},
-		"ssh_read_write_timeout":       &hcldec.AttrSpec{Name: "ssh_read_write_timeout", Type: cty.String, Required: false},
-		"ssh_remote_tunnels":           &hcldec.AttrSpec{Name: "ssh_remote_tunnels", Type: cty.List(cty.String), Required: false},
-		"ssh_local_tunnels":            &hcldec.AttrSpec{Name: "ssh_local_tunnels", Type: cty.List(cty.String), Required: false},
-		"ssh_public_key":               &hcldec.AttrSpec{Name: "ssh_public_key", Type: cty.List(cty.Number), Required: false},
-		"ssh_private_key":              &hcldec.AttrSpec{Name: "ssh_private_key", Type: cty.List(cty.Number), Required: false},
-		"winrm_username":               &hcldec.AttrSpec{Name: "winrm_username", Type: cty.String, Required: false},
-		"winrm_password":               &hcldec.AttrSpec{Name: "winrm_password", Type: cty.String, Required: false},
-		"winrm_host":                   &hcldec.AttrSpec{Name: "winrm_host", Type: cty.String, Required: false},
-		"winrm_no_proxy":               &hcldec.AttrSpec{Name: "winrm_no_proxy", Type: cty.Bool, Required: false},
-		"winrm_port":                   &hcldec.AttrSpec{Name: "winrm_port", Type: cty.Number, Required: false},
-		"winrm_timeout":                &hcldec.AttrSpec{Name: "winrm_timeout", Type: cty.String, Required: false},
-		"winrm_use_ssl":                &hcldec.AttrSpec{Name: "winrm_use_ssl", Type: cty.Bool, Required: false},
-		"winrm_insecure":               &hcldec.AttrSpec{Name: "winrm_insecure", Type: cty.Bool, Required: false},
-		"winrm_use_ntlm":               &hcldec.AttrSpec{Name: "winrm_use_ntlm", Type: cty.Bool, Required: false},
-		"use_instance_principals":      &hcldec.AttrSpec{Name: "use_instance_principals", Type: cty.Bool, Required: false},
-		"skip_create_image":            &hcldec.AttrSpec{Name: "skip_create_image", Type: cty.Bool, Required: false},
-		"access_cfg_file":              &hcldec.AttrSpec{Name: "access_cfg_file", Type: cty.String, Required: false},
-		"access_cfg_file_account":      &hcldec.AttrSpec{Name: "access_cfg_file_account", Type: cty.String, Required: false},
-		"user_ocid":                    &hcldec.AttrSpec{Name: "user_ocid", Type: cty.String, Required: false},
-		"tenancy_ocid":                 &hcldec.AttrSpec{Name: "tenancy_ocid", Type: cty.String, Required: false},
-		"region":                       &hcldec.AttrSpec{Name: "region", Type: cty.String, Required: false},
-		"fingerprint":                  &hcldec.AttrSpec{Name: "fingerprint", Type: cty.String, Required: false},
-		"key_file":                     &hcldec.AttrSpec{Name: "key_file", Type: cty.String, Required: false},
-		"pass_phrase":                  &hcldec.AttrSpec{Name: "pass_phrase", Type: cty.String, Required: false},
-		"use_private_ip":               &hcldec.AttrSpec{Name: "use_private_ip", Type: cty.Bool, Required: false},
-		"security_token_file":          &hcldec.AttrSpec{Name: "security_token_file", Type: cty.String, Required: false},
-		"availability_domain":          &hcldec.AttrSpec{Name: "availability_domain", Type: cty.String, Required: false},
-		"compartment_ocid":             &hcldec.AttrSpec{Name: "compartment_ocid", Type: cty.String, Required: false},
-		"base_image_ocid":              &hcldec.AttrSpec{Name: "base_image_ocid", Type: cty.String, Required: false},
-		"base_image_filter":            &hcldec.BlockSpec{TypeName: "base_image_filter", Nested: hcldec.ObjectSpec((*FlatListImagesRequest)(nil).HCL2Spec())},
-		"image_name":                   &hcldec.AttrSpec{Name: "image_name", Type: cty.String, Required: false},
-		"image_compartment_ocid":       &hcldec.AttrSpec{Name: "image_compartment_ocid", Type: cty.String, Required: false},
-		"image_launch_mode":            &hcldec.AttrSpec{Name: "image_launch_mode", Type: cty.String, Required: false},
-		"nic_attachment_type":          &hcldec.AttrSpec{Name: "nic_attachment_type", Type: cty.String, Required: false},
-		"instance_name":                &hcldec.AttrSpec{Name: "instance_name", Type: cty.String, Required: false},
-		"instance_tags":                &hcldec.AttrSpec{Name: "instance_tags", Type: cty.Map(cty.String), Required: false},
-		"instance_defined_tags_json":   &hcldec.AttrSpec{Name: "instance_defined_tags_json", Type: cty.String, Required: false},
-		"instance_options":             &hcldec.BlockSpec{TypeName: "instance_options", Nested: hcldec.ObjectSpec((*FlatInstanceOptionsConfig)(nil).HCL2Spec())},
-		"shape":                        &hcldec.AttrSpec{Name: "shape", Type: cty.String, Required: false},
-		"shape_config":                 &hcldec.BlockSpec{TypeName: "shape_config", Nested: hcldec.ObjectSpec((*FlatFlexShapeConfig)(nil).HCL2Spec())},
-		"disk_size":                    &hcldec.AttrSpec{Name: "disk_size", Type: cty.Number, Required: false},
-		"metadata":                     &hcldec.AttrSpec{Name: "metadata", Type: cty.Map(cty.String), Required: false},
-		"user_data":                    &hcldec.AttrSpec{Name: "user_data", Type: cty.String, Required: false},
-		"user_data_file":               &hcldec.AttrSpec{Name: "user_data_file", Type: cty.String, Required: false},
-		"subnet_ocid":                  &hcldec.AttrSpec{Name: "subnet_ocid", Type: cty.String, Required: false},
-		"create_vnic_details":          &hcldec.BlockSpec{TypeName: "create_vnic_details", Nested: hcldec.ObjectSpec((*FlatCreateVNICDetails)(nil).HCL2Spec())},
-		"tags":                         &hcldec.AttrSpec{Name: "tags", Type: cty.Map(cty.String), Required: false},
-		"defined_tags_json":            &hcldec.AttrSpec{Name: "defined_tags_json", Type: cty.String, Required: false},
+		"packer_build_name":                     &hcldec.AttrSpec{Name: "packer_build_name", Type: cty.String, Required: false},
+		"packer_builder_type":                   &hcldec.AttrSpec{Name: "packer_builder_type", Type: cty.String, Required: false},
+		"packer_core_version":                   &hcldec.AttrSpec{Name: "packer_core_version", Type: cty.String, Required: false},
+		"packer_debug":                          &hcldec.AttrSpec{Name: "packer_debug", Type: cty.Bool, Required: false},
+		"packer_force":                          &hcldec.AttrSpec{Name: "packer_force", Type: cty.Bool, Required: false},
+		"packer_on_error":                       &hcldec.AttrSpec{Name: "packer_on_error", Type: cty.String, Required: false},
+		"packer_user_variables":                 &hcldec.AttrSpec{Name: "packer_user_variables", Type: cty.Map(cty.String), Required: false},
+		"packer_sensitive_variables":            &hcldec.AttrSpec{Name: "packer_sensitive_variables", Type: cty.List(cty.String), Required: false},
+		"communicator":                          &hcldec.AttrSpec{Name: "communicator", Type: cty.String, Required: false},
+		"pause_before_connecting":               &hcldec.AttrSpec{Name: "pause_before_connecting", Type: cty.String, Required: false},
+		"ssh_host":                              &hcldec.AttrSpec{Name: "ssh_host", Type: cty.String, Required: false},
+		"ssh_port":                              &hcldec.AttrSpec{Name: "ssh_port", Type: cty.Number, Required: false},
+		"ssh_username":                          &hcldec.AttrSpec{Name: "ssh_username", Type: cty.String, Required: false},
+		"ssh_password":                          &hcldec.AttrSpec{Name: "ssh_password", Type: cty.String, Required: false},
+		"ssh_keypair_name":                      &hcldec.AttrSpec{Name: "ssh_keypair_name", Type: cty.String, Required: false},
+		"temporary_key_pair_name":               &hcldec.AttrSpec{Name: "temporary_key_pair_name", Type: cty.String, Required: false},
+		"temporary_key_pair_type":               &hcldec.AttrSpec{Name: "temporary_key_pair_type", Type: cty.String, Required: false},
+		"temporary_key_pair_bits":               &hcldec.AttrSpec{Name: "temporary_key_pair_bits", Type: cty.Number, Required: false},
+		"ssh_ciphers":                           &hcldec.AttrSpec{Name: "ssh_ciphers", Type: cty.List(cty.String), Required: false},
+		"ssh_clear_authorized_keys":             &hcldec.AttrSpec{Name: "ssh_clear_authorized_keys", Type: cty.Bool, Required: false},
+		"ssh_key_exchange_algorithms":           &hcldec.AttrSpec{Name: "ssh_key_exchange_algorithms", Type: cty.List(cty.String), Required: false},
+		"ssh_private_key_file":                  &hcldec.AttrSpec{Name: "ssh_private_key_file", Type: cty.String, Required: false},
+		"ssh_certificate_file":                  &hcldec.AttrSpec{Name: "ssh_certificate_file", Type: cty.String, Required: false},
+		"ssh_pty":                               &hcldec.AttrSpec{Name: "ssh_pty", Type: cty.Bool, Required: false},
+		"ssh_timeout":                           &hcldec.AttrSpec{Name: "ssh_timeout", Type: cty.String, Required: false},
+		"ssh_wait_timeout":                      &hcldec.AttrSpec{Name: "ssh_wait_timeout", Type: cty.String, Required: false},
+		"ssh_agent_auth":                        &hcldec.AttrSpec{Name: "ssh_agent_auth", Type: cty.Bool, Required: false},
+		"ssh_disable_agent_forwarding":          &hcldec.AttrSpec{Name: "ssh_disable_agent_forwarding", Type: cty.Bool, Required: false},
+		"ssh_handshake_attempts":                &hcldec.AttrSpec{Name: "ssh_handshake_attempts", Type: cty.Number, Required: false},
+		"ssh_bastion_host":                      &hcldec.AttrSpec{Name: "ssh_bastion_host", Type: cty.String, Required: false},
+		"ssh_bastion_port":                      &hcldec.AttrSpec{Name: "ssh_bastion_port", Type: cty.Number, Required: false},
+		"ssh_bastion_agent_auth":                &hcldec.AttrSpec{Name: "ssh_bastion_agent_auth", Type: cty.Bool, Required: false},
+		"ssh_bastion_username":                  &hcldec.AttrSpec{Name: "ssh_bastion_username", Type: cty.String, Required: false},
+		"ssh_bastion_password":                  &hcldec.AttrSpec{Name: "ssh_bastion_password", Type: cty.String, Required: false},
+		"ssh_bastion_interactive":               &hcldec.AttrSpec{Name: "ssh_bastion_interactive", Type: cty.Bool, Required: false},
+		"ssh_bastion_private_key_file":          &hcldec.AttrSpec{Name: "ssh_bastion_private_key_file", Type: cty.String, Required: false},
+		"ssh_bastion_certificate_file":          &hcldec.AttrSpec{Name: "ssh_bastion_certificate_file", Type: cty.String, Required: false},
+		"ssh_file_transfer_method":              &hcldec.AttrSpec{Name: "ssh_file_transfer_method", Type: cty.String, Required: false},
+		"ssh_proxy_host":                        &hcldec.AttrSpec{Name: "ssh_proxy_host", Type: cty.String, Required: false},
+		"ssh_proxy_port":                        &hcldec.AttrSpec{Name: "ssh_proxy_port", Type: cty.Number, Required: false},
+		"ssh_proxy_username":                    &hcldec.AttrSpec{Name: "ssh_proxy_username", Type: cty.String, Required: false},
+		"ssh_proxy_password":                    &hcldec.AttrSpec{Name: "ssh_proxy_password", Type: cty.String, Required: false},
+		"ssh_keep_alive_interval":               &hcldec.AttrSpec{Name: "ssh_keep_alive_interval", Type: cty.String, Required: false},
+		"ssh_read_write_timeout":                &hcldec.AttrSpec{Name: "ssh_read_write_timeout", Type: cty.String, Required: false},
+		"ssh_remote_tunnels":                    &hcldec.AttrSpec{Name: "ssh_remote_tunnels", Type: cty.List(cty.String), Required: false},
+		"ssh_local_tunnels":                     &hcldec.AttrSpec{Name: "ssh_local_tunnels", Type: cty.List(cty.String), Required: false},
+		"ssh_public_key":                        &hcldec.AttrSpec{Name: "ssh_public_key", Type: cty.List(cty.Number), Required: false},
+		"ssh_private_key":                       &hcldec.AttrSpec{Name: "ssh_private_key", Type: cty.List(cty.Number), Required: false},
+		"winrm_username":                        &hcldec.AttrSpec{Name: "winrm_username", Type: cty.String, Required: false},
+		"winrm_password":                        &hcldec.AttrSpec{Name: "winrm_password", Type: cty.String, Required: false},
+		"winrm_host":                            &hcldec.AttrSpec{Name: "winrm_host", Type: cty.String, Required: false},
+		"winrm_no_proxy":                        &hcldec.AttrSpec{Name: "winrm_no_proxy", Type: cty.Bool, Required: false},
+		"winrm_port":                            &hcldec.AttrSpec{Name: "winrm_port", Type: cty.Number, Required: false},
+		"winrm_timeout":                         &hcldec.AttrSpec{Name: "winrm_timeout", Type: cty.String, Required: false},
+		"winrm_use_ssl":                         &hcldec.AttrSpec{Name: "winrm_use_ssl", Type: cty.Bool, Required: false},
+		"winrm_insecure":                        &hcldec.AttrSpec{Name: "winrm_insecure", Type: cty.Bool, Required: false},
+		"winrm_use_ntlm":                        &hcldec.AttrSpec{Name: "winrm_use_ntlm", Type: cty.Bool, Required: false},
+		"use_instance_principals":               &hcldec.AttrSpec{Name: "use_instance_principals", Type: cty.Bool, Required: false},
+		"skip_create_image":                     &hcldec.AttrSpec{Name: "skip_create_image", Type: cty.Bool, Required: false},
+		"delete_failed_image":                   &hcldec.AttrSpec{Name: "delete_failed_image", Type: cty.Bool, Required: false},
+		"boot_volume_backup":                    &hcldec.AttrSpec{Name: "boot_volume_backup", Type: cty.Bool, Required: false},
+		"skip_preflight_checks":                 &hcldec.AttrSpec{Name: "skip_preflight_checks", Type: cty.Bool, Required: false},
+		"diagnostics":                           &hcldec.AttrSpec{Name: "diagnostics", Type: cty.Bool, Required: false},
+		"access_cfg_file":                       &hcldec.AttrSpec{Name: "access_cfg_file", Type: cty.String, Required: false},
+		"access_cfg_file_account":               &hcldec.AttrSpec{Name: "access_cfg_file_account", Type: cty.String, Required: false},
+		"user_ocid":                             &hcldec.AttrSpec{Name: "user_ocid", Type: cty.String, Required: false},
+		"tenancy_ocid":                          &hcldec.AttrSpec{Name: "tenancy_ocid", Type: cty.String, Required: false},
+		"region":                                &hcldec.AttrSpec{Name: "region", Type: cty.String, Required: false},
+		"fingerprint":                           &hcldec.AttrSpec{Name: "fingerprint", Type: cty.String, Required: false},
+		"key_file":                              &hcldec.AttrSpec{Name: "key_file", Type: cty.String, Required: false},
+		"pass_phrase":                           &hcldec.AttrSpec{Name: "pass_phrase", Type: cty.String, Required: false},
+		"use_private_ip":                        &hcldec.AttrSpec{Name: "use_private_ip", Type: cty.Bool, Required: false},
+		"use_private_fqdn":                      &hcldec.AttrSpec{Name: "use_private_fqdn", Type: cty.Bool, Required: false},
+		"use_image_connection_hints":            &hcldec.AttrSpec{Name: "use_image_connection_hints", Type: cty.Bool, Required: false},
+		"use_ipv6":                              &hcldec.AttrSpec{Name: "use_ipv6", Type: cty.Bool, Required: false},
+		"security_token_file":                   &hcldec.AttrSpec{Name: "security_token_file", Type: cty.String, Required: false},
+		"availability_domain":                   &hcldec.AttrSpec{Name: "availability_domain", Type: cty.String, Required: false},
+		"compartment_ocid":                      &hcldec.AttrSpec{Name: "compartment_ocid", Type: cty.String, Required: false},
+		"availability_domains":                  &hcldec.AttrSpec{Name: "availability_domains", Type: cty.List(cty.String), Required: false},
+		"capacity_retry_timeout":                &hcldec.AttrSpec{Name: "capacity_retry_timeout", Type: cty.String, Required: false},
+		"capacity_retry_interval":               &hcldec.AttrSpec{Name: "capacity_retry_interval", Type: cty.String, Required: false},
+		"base_image_ocid":                       &hcldec.AttrSpec{Name: "base_image_ocid", Type: cty.String, Required: false},
+		"base_image_filter":                     &hcldec.BlockSpec{TypeName: "base_image_filter", Nested: hcldec.ObjectSpec((*FlatListImagesRequest)(nil).HCL2Spec())},
+		"base_image":                            &hcldec.AttrSpec{Name: "base_image", Type: cty.String, Required: false},
+		"source_boot_volume_ocid":               &hcldec.AttrSpec{Name: "source_boot_volume_ocid", Type: cty.String, Required: false},
+		"boot_volume_ocid":                      &hcldec.AttrSpec{Name: "boot_volume_ocid", Type: cty.String, Required: false},
+		"base_image_import":                     &hcldec.BlockSpec{TypeName: "base_image_import", Nested: hcldec.ObjectSpec((*FlatImageImportConfig)(nil).HCL2Spec())},
+		"image_name":                            &hcldec.AttrSpec{Name: "image_name", Type: cty.String, Required: false},
+		"image_compartment_ocid":                &hcldec.AttrSpec{Name: "image_compartment_ocid", Type: cty.String, Required: false},
+		"image_launch_mode":                     &hcldec.AttrSpec{Name: "image_launch_mode", Type: cty.String, Required: false},
+		"nic_attachment_type":                   &hcldec.AttrSpec{Name: "nic_attachment_type", Type: cty.String, Required: false},
+		"image_capabilities":                    &hcldec.AttrSpec{Name: "image_capabilities", Type: cty.Map(cty.String), Required: false},
+		"iscsi_multipath_device_supported":      &hcldec.AttrSpec{Name: "iscsi_multipath_device_supported", Type: cty.Bool, Required: false},
+		"consistent_volume_naming":              &hcldec.AttrSpec{Name: "consistent_volume_naming", Type: cty.Bool, Required: false},
+		"paravirtualization_attachment_version": &hcldec.AttrSpec{Name: "paravirtualization_attachment_version", Type: cty.Number, Required: false},
+		"image_firmware":                        &hcldec.AttrSpec{Name: "image_firmware", Type: cty.String, Required: false},
+		"image_operating_system":                &hcldec.AttrSpec{Name: "image_operating_system", Type: cty.String, Required: false},
+		"image_operating_system_version":        &hcldec.AttrSpec{Name: "image_operating_system_version", Type: cty.String, Required: false},
+		"image_version_scheme":                  &hcldec.AttrSpec{Name: "image_version_scheme", Type: cty.String, Required: false},
+		"image_version":                         &hcldec.AttrSpec{Name: "image_version", Type: cty.String, Required: false},
+		"image_destination_compartment_ocid":    &hcldec.AttrSpec{Name: "image_destination_compartment_ocid", Type: cty.String, Required: false},
+		"instance_name":                         &hcldec.AttrSpec{Name: "instance_name", Type: cty.String, Required: false},
+		"instance_tags":                         &hcldec.AttrSpec{Name: "instance_tags", Type: cty.Map(cty.String), Required: false},
+		"instance_defined_tags_json":            &hcldec.AttrSpec{Name: "instance_defined_tags_json", Type: cty.String, Required: false},
+		"instance_options":                      &hcldec.BlockSpec{TypeName: "instance_options", Nested: hcldec.ObjectSpec((*FlatInstanceOptionsConfig)(nil).HCL2Spec())},
+		"launch_options":                        &hcldec.BlockSpec{TypeName: "launch_options", Nested: hcldec.ObjectSpec((*FlatLaunchOptionsConfig)(nil).HCL2Spec())},
+		"platform_config":                       &hcldec.BlockSpec{TypeName: "platform_config", Nested: hcldec.ObjectSpec((*FlatPlatformConfig)(nil).HCL2Spec())},
+		"agent_config":                          &hcldec.BlockSpec{TypeName: "agent_config", Nested: hcldec.ObjectSpec((*FlatInstanceAgentConfig)(nil).HCL2Spec())},
+		"shape":                                 &hcldec.AttrSpec{Name: "shape", Type: cty.String, Required: false},
+		"shape_config":                          &hcldec.BlockSpec{TypeName: "shape_config", Nested: hcldec.ObjectSpec((*FlatFlexShapeConfig)(nil).HCL2Spec())},
+		"capture_shape_config":                  &hcldec.BlockSpec{TypeName: "capture_shape_config", Nested: hcldec.ObjectSpec((*FlatFlexShapeConfig)(nil).HCL2Spec())},
+		"disk_size":                             &hcldec.AttrSpec{Name: "disk_size", Type: cty.Number, Required: false},
+		"boot_volume_kms_key_ocid":              &hcldec.AttrSpec{Name: "boot_volume_kms_key_ocid", Type: cty.String, Required: false},
+		"boot_volume_vpus_per_gb":               &hcldec.AttrSpec{Name: "boot_volume_vpus_per_gb", Type: cty.Number, Required: false},
+		"preserve_boot_volume":                  &hcldec.AttrSpec{Name: "preserve_boot_volume", Type: cty.Bool, Required: false},
+		"instance_disposal":                     &hcldec.AttrSpec{Name: "instance_disposal", Type: cty.String, Required: false},
+		"keep_instance_alive":                   &hcldec.AttrSpec{Name: "keep_instance_alive", Type: cty.Bool, Required: false},
+		"free_tier":                             &hcldec.AttrSpec{Name: "free_tier", Type: cty.Bool, Required: false},
+		"block_volumes":                         &hcldec.BlockListSpec{TypeName: "block_volumes", Nested: hcldec.ObjectSpec((*FlatBlockVolumeConfig)(nil).HCL2Spec())},
+		"capacity_reservation_ocid":             &hcldec.AttrSpec{Name: "capacity_reservation_ocid", Type: cty.String, Required: false},
+		"fault_domain":                          &hcldec.AttrSpec{Name: "fault_domain", Type: cty.String, Required: false},
+		"is_preemptible":                        &hcldec.AttrSpec{Name: "is_preemptible", Type: cty.Bool, Required: false},
+		"preemptible_instance_config":           &hcldec.BlockSpec{TypeName: "preemptible_instance_config", Nested: hcldec.ObjectSpec((*FlatPreemptibleInstanceConfig)(nil).HCL2Spec())},
+		"metadata":                              &hcldec.AttrSpec{Name: "metadata", Type: cty.Map(cty.String), Required: false},
+		"user_data":                             &hcldec.AttrSpec{Name: "user_data", Type: cty.String, Required: false},
+		"user_data_file":                        &hcldec.AttrSpec{Name: "user_data_file", Type: cty.String, Required: false},
+		"configure_winrm":                       &hcldec.AttrSpec{Name: "configure_winrm", Type: cty.Bool, Required: false},
+		"rotate_winrm_password":                 &hcldec.AttrSpec{Name: "rotate_winrm_password", Type: cty.Bool, Required: false},
+		"subnet_ocid":                           &hcldec.AttrSpec{Name: "subnet_ocid", Type: cty.String, Required: false},
+		"create_vnic_details":                   &hcldec.BlockSpec{TypeName: "create_vnic_details", Nested: hcldec.ObjectSpec((*FlatCreateVNICDetails)(nil).HCL2Spec())},
+		"vlan_ocid":                             &hcldec.AttrSpec{Name: "vlan_ocid", Type: cty.String, Required: false},
+		"public_ip_lifetime":                    &hcldec.AttrSpec{Name: "public_ip_lifetime", Type: cty.String, Required: false},
+		"reserved_public_ip_ocid":               &hcldec.AttrSpec{Name: "reserved_public_ip_ocid", Type: cty.String, Required: false},
+		"retain_reserved_public_ip":             &hcldec.AttrSpec{Name: "retain_reserved_public_ip", Type: cty.Bool, Required: false},
+		"security_list_ocid":                    &hcldec.AttrSpec{Name: "security_list_ocid", Type: cty.String, Required: false},
+		"security_list_source_cidrs":            &hcldec.AttrSpec{Name: "security_list_source_cidrs", Type: cty.List(cty.String), Required: false},
+		"provisioner_log_ocid":                  &hcldec.AttrSpec{Name: "provisioner_log_ocid", Type: cty.String, Required: false},
+		"image_lock_bucket":                     &hcldec.AttrSpec{Name: "image_lock_bucket", Type: cty.String, Required: false},
+		"image_lock_timeout":                    &hcldec.AttrSpec{Name: "image_lock_timeout", Type: cty.String, Required: false},
+		"image_lock_ttl":                        &hcldec.AttrSpec{Name: "image_lock_ttl", Type: cty.String, Required: false},
+		"image_creation_timeout":                &hcldec.AttrSpec{Name: "image_creation_timeout", Type: cty.String, Required: false},
+		"image_polling_interval":                &hcldec.AttrSpec{Name: "image_polling_interval", Type: cty.String, Required: false},
+		"first_boot_validation":                 &hcldec.BlockSpec{TypeName: "first_boot_validation", Nested: hcldec.ObjectSpec((*FlatFirstBootValidationConfig)(nil).HCL2Spec())},
+		"export_to_object_storage":              &hcldec.BlockSpec{TypeName: "export_to_object_storage", Nested: hcldec.ObjectSpec((*FlatImageExportConfig)(nil).HCL2Spec())},
+		"image_copy_regions":                    &hcldec.AttrSpec{Name: "image_copy_regions", Type: cty.List(cty.String), Required: false},
+		"image_copy_names":                      &hcldec.AttrSpec{Name: "image_copy_names", Type: cty.Map(cty.String), Required: false},
+		"image_copy_compartment_ocids":          &hcldec.AttrSpec{Name: "image_copy_compartment_ocids", Type: cty.List(cty.String), Required: false},
+		"image_retention":                       &hcldec.BlockSpec{TypeName: "image_retention", Nested: hcldec.ObjectSpec((*FlatImageRetentionConfig)(nil).HCL2Spec())},
+		"marketplace_publication":               &hcldec.BlockSpec{TypeName: "marketplace_publication", Nested: hcldec.ObjectSpec((*FlatMarketplacePublicationConfig)(nil).HCL2Spec())},
+		"manifest_path":                         &hcldec.AttrSpec{Name: "manifest_path", Type: cty.String, Required: false},
+		"manifest_export_sha256":                &hcldec.AttrSpec{Name: "manifest_export_sha256", Type: cty.Bool, Required: false},
+		"create_console_connection":             &hcldec.AttrSpec{Name: "create_console_connection", Type: cty.Bool, Required: false},
+		"stream_console_output":                 &hcldec.AttrSpec{Name: "stream_console_output", Type: cty.Bool, Required: false},
+		"wait_for_cloud_init":                   &hcldec.AttrSpec{Name: "wait_for_cloud_init", Type: cty.Bool, Required: false},
+		"cloud_init_timeout":                    &hcldec.AttrSpec{Name: "cloud_init_timeout", Type: cty.String, Required: false},
+		"wait_for_gpu_driver":                   &hcldec.AttrSpec{Name: "wait_for_gpu_driver", Type: cty.Bool, Required: false},
+		"gpu_driver_timeout":                    &hcldec.AttrSpec{Name: "gpu_driver_timeout", Type: cty.String, Required: false},
+		"wait_for_agent_plugins":                &hcldec.AttrSpec{Name: "wait_for_agent_plugins", Type: cty.List(cty.String), Required: false},
+		"agent_plugins_timeout":                 &hcldec.AttrSpec{Name: "agent_plugins_timeout", Type: cty.String, Required: false},
+		"bootstrap_script":                      &hcldec.AttrSpec{Name: "bootstrap_script", Type: cty.String, Required: false},
+		"bootstrap_timeout":                     &hcldec.AttrSpec{Name: "bootstrap_timeout", Type: cty.String, Required: false},
+		"pause_before_capture":                  &hcldec.AttrSpec{Name: "pause_before_capture", Type: cty.String, Required: false},
+		"shutdown_before_image":                 &hcldec.AttrSpec{Name: "shutdown_before_image", Type: cty.Bool, Required: false},
+		"shutdown_command":                      &hcldec.AttrSpec{Name: "shutdown_command", Type: cty.String, Required: false},
+		"restart_after_image":                   &hcldec.AttrSpec{Name: "restart_after_image", Type: cty.Bool, Required: false},
+		"timeouts":                              &hcldec.BlockSpec{TypeName: "timeouts", Nested: hcldec.ObjectSpec((*FlatTimeoutsConfig)(nil).HCL2Spec())},
+		"fips_mode":                             &hcldec.AttrSpec{Name: "fips_mode", Type: cty.Bool, Required: false},
+		"http_client":                           &hcldec.BlockSpec{TypeName: "http_client", Nested: hcldec.ObjectSpec((*FlatHTTPClientConfig)(nil).HCL2Spec())},
+		"max_run_duration":                      &hcldec.AttrSpec{Name: "max_run_duration", Type: cty.String, Required: false},
+		"build_retry_attempts":                  &hcldec.AttrSpec{Name: "build_retry_attempts", Type: cty.Number, Required: false},
+		"build_retry_on":                        &hcldec.AttrSpec{Name: "build_retry_on", Type: cty.List(cty.String), Required: false},
+		"tags":                                  &hcldec.AttrSpec{Name: "tags", Type: cty.Map(cty.String), Required: false},
+		"source_control_tags":                   &hcldec.AttrSpec{Name: "source_control_tags", Type: cty.Bool, Required: false},
+		"provenance_tags":                       &hcldec.AttrSpec{Name: "provenance_tags", Type: cty.Bool, Required: false},
+		"defined_tags_json":                     &hcldec.AttrSpec{Name: "defined_tags_json", Type: cty.String, Required: false},
 	}
 	return s
 }
@@ -219,6 +420,7 @@ type FlatCreateVNICDetails struct {
 	FreeformTags        map[string]string `mapstructure:"tags" required:"false" cty:"tags" hcl:"tags"`
 	HostnameLabel       *string           `mapstructure:"hostname_label" required:"false" cty:"hostname_label" hcl:"hostname_label"`
 	NsgIds              []string          `mapstructure:"nsg_ids" required:"false" cty:"nsg_ids" hcl:"nsg_ids"`
+	NsgNames            []string          `mapstructure:"nsg_names" required:"false" cty:"nsg_names" hcl:"nsg_names"`
 	PrivateIp           *string           `mapstructure:"private_ip" required:"false" cty:"private_ip" hcl:"private_ip"`
 	SkipSourceDestCheck *bool             `mapstructure:"skip_source_dest_check" required:"false" cty:"skip_source_dest_check" hcl:"skip_source_dest_check"`
 	SubnetId            *string           `mapstructure:"subnet_id" required:"false" cty:"subnet_id" hcl:"subnet_id"`
@@ -242,6 +444,7 @@ func (*FlatCreateVNICDetails) HCL2Spec() map[string]hcldec.Spec {
 		"tags":                   &hcldec.AttrSpec{Name: "tags", Type: cty.Map(cty.String), Required: false},
 		"hostname_label":         &hcldec.AttrSpec{Name: "hostname_label", Type: cty.String, Required: false},
 		"nsg_ids":                &hcldec.AttrSpec{Name: "nsg_ids", Type: cty.List(cty.String), Required: false},
+		"nsg_names":              &hcldec.AttrSpec{Name: "nsg_names", Type: cty.List(cty.String), Required: false},
 		"private_ip":             &hcldec.AttrSpec{Name: "private_ip", Type: cty.String, Required: false},
 		"skip_source_dest_check": &hcldec.AttrSpec{Name: "skip_source_dest_check", Type: cty.Bool, Required: false},
 		"subnet_id":              &hcldec.AttrSpec{Name: "subnet_id", Type: cty.String, Required: false},
@@ -249,6 +452,31 @@ func (*FlatCreateVNICDetails) HCL2Spec() map[string]hcldec.Spec {
 	return s
 }
 
+// FlatFirstBootValidationConfig is an auto-generated flat version of FirstBootValidationConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatFirstBootValidationConfig struct {
+	Assertions      []string `mapstructure:"assertions" required:"false" cty:"assertions" hcl:"assertions"`
+	OutputDirectory *string  `mapstructure:"output_directory" required:"false" cty:"output_directory" hcl:"output_directory"`
+}
+
+// FlatMapstructure returns a new FlatFirstBootValidationConfig.
+// FlatFirstBootValidationConfig is an auto-generated flat version of FirstBootValidationConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*FirstBootValidationConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatFirstBootValidationConfig)
+}
+
+// HCL2Spec returns the hcl spec of a FirstBootValidationConfig.
+// This spec is used by HCL to read the fields of FirstBootValidationConfig.
+// The decoded values from this spec will then be applied to a FlatFirstBootValidationConfig.
+func (*FlatFirstBootValidationConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"assertions":       &hcldec.AttrSpec{Name: "assertions", Type: cty.List(cty.String), Required: false},
+		"output_directory": &hcldec.AttrSpec{Name: "output_directory", Type: cty.String, Required: false},
+	}
+	return s
+}
+
 // FlatFlexShapeConfig is an auto-generated flat version of FlexShapeConfig.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatFlexShapeConfig struct {
@@ -276,6 +504,184 @@ func (*FlatFlexShapeConfig) HCL2Spec() map[string]hcldec.Spec {
 	return s
 }
 
+// FlatHTTPClientConfig is an auto-generated flat version of HTTPClientConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatHTTPClientConfig struct {
+	MaxIdleConns           *int    `mapstructure:"max_idle_conns" required:"false" cty:"max_idle_conns" hcl:"max_idle_conns"`
+	MaxIdleConnsPerHost    *int    `mapstructure:"max_idle_conns_per_host" required:"false" cty:"max_idle_conns_per_host" hcl:"max_idle_conns_per_host"`
+	IdleConnTimeout        *string `mapstructure:"idle_conn_timeout" required:"false" cty:"idle_conn_timeout" hcl:"idle_conn_timeout"`
+	TLSHandshakeTimeout    *string `mapstructure:"tls_handshake_timeout" required:"false" cty:"tls_handshake_timeout" hcl:"tls_handshake_timeout"`
+	RequestTimeout         *string `mapstructure:"request_timeout" required:"false" cty:"request_timeout" hcl:"request_timeout"`
+	DisableTLSSessionReuse *bool   `mapstructure:"disable_tls_session_reuse" required:"false" cty:"disable_tls_session_reuse" hcl:"disable_tls_session_reuse"`
+}
+
+// FlatMapstructure returns a new FlatHTTPClientConfig.
+// FlatHTTPClientConfig is an auto-generated flat version of HTTPClientConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*HTTPClientConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatHTTPClientConfig)
+}
+
+// HCL2Spec returns the hcl spec of a HTTPClientConfig.
+// This spec is used by HCL to read the fields of HTTPClientConfig.
+// The decoded values from this spec will then be applied to a FlatHTTPClientConfig.
+func (*FlatHTTPClientConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"max_idle_conns":            &hcldec.AttrSpec{Name: "max_idle_conns", Type: cty.Number, Required: false},
+		"max_idle_conns_per_host":   &hcldec.AttrSpec{Name: "max_idle_conns_per_host", Type: cty.Number, Required: false},
+		"idle_conn_timeout":         &hcldec.AttrSpec{Name: "idle_conn_timeout", Type: cty.String, Required: false},
+		"tls_handshake_timeout":     &hcldec.AttrSpec{Name: "tls_handshake_timeout", Type: cty.String, Required: false},
+		"request_timeout":           &hcldec.AttrSpec{Name: "request_timeout", Type: cty.String, Required: false},
+		"disable_tls_session_reuse": &hcldec.AttrSpec{Name: "disable_tls_session_reuse", Type: cty.Bool, Required: false},
+	}
+	return s
+}
+
+// FlatImageExportConfig is an auto-generated flat version of ImageExportConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatImageExportConfig struct {
+	Bucket           *string `mapstructure:"bucket" required:"true" cty:"bucket" hcl:"bucket"`
+	Namespace        *string `mapstructure:"namespace" required:"false" cty:"namespace" hcl:"namespace"`
+	ObjectName       *string `mapstructure:"object_name" required:"false" cty:"object_name" hcl:"object_name"`
+	ExportFormat     *string `mapstructure:"export_format" required:"false" cty:"export_format" hcl:"export_format"`
+	ShareURLLifetime *string `mapstructure:"share_url_lifetime" required:"false" cty:"share_url_lifetime" hcl:"share_url_lifetime"`
+	KmsKeyID         *string `mapstructure:"kms_key_ocid" required:"false" cty:"kms_key_ocid" hcl:"kms_key_ocid"`
+}
+
+// FlatMapstructure returns a new FlatImageExportConfig.
+// FlatImageExportConfig is an auto-generated flat version of ImageExportConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*ImageExportConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatImageExportConfig)
+}
+
+// HCL2Spec returns the hcl spec of a ImageExportConfig.
+// This spec is used by HCL to read the fields of ImageExportConfig.
+// The decoded values from this spec will then be applied to a FlatImageExportConfig.
+func (*FlatImageExportConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"bucket":             &hcldec.AttrSpec{Name: "bucket", Type: cty.String, Required: false},
+		"namespace":          &hcldec.AttrSpec{Name: "namespace", Type: cty.String, Required: false},
+		"object_name":        &hcldec.AttrSpec{Name: "object_name", Type: cty.String, Required: false},
+		"export_format":      &hcldec.AttrSpec{Name: "export_format", Type: cty.String, Required: false},
+		"share_url_lifetime": &hcldec.AttrSpec{Name: "share_url_lifetime", Type: cty.String, Required: false},
+		"kms_key_ocid":       &hcldec.AttrSpec{Name: "kms_key_ocid", Type: cty.String, Required: false},
+	}
+	return s
+}
+
+// FlatImageImportConfig is an auto-generated flat version of ImageImportConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatImageImportConfig struct {
+	SourceURI              *string `mapstructure:"source_uri" required:"true" cty:"source_uri" hcl:"source_uri"`
+	SourceImageType        *string `mapstructure:"source_image_type" required:"false" cty:"source_image_type" hcl:"source_image_type"`
+	LaunchMode             *string `mapstructure:"launch_mode" required:"false" cty:"launch_mode" hcl:"launch_mode"`
+	OperatingSystem        *string `mapstructure:"operating_system" required:"false" cty:"operating_system" hcl:"operating_system"`
+	OperatingSystemVersion *string `mapstructure:"operating_system_version" required:"false" cty:"operating_system_version" hcl:"operating_system_version"`
+}
+
+// FlatMapstructure returns a new FlatImageImportConfig.
+// FlatImageImportConfig is an auto-generated flat version of ImageImportConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*ImageImportConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatImageImportConfig)
+}
+
+// HCL2Spec returns the hcl spec of a ImageImportConfig.
+// This spec is used by HCL to read the fields of ImageImportConfig.
+// The decoded values from this spec will then be applied to a FlatImageImportConfig.
+func (*FlatImageImportConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"source_uri":               &hcldec.AttrSpec{Name: "source_uri", Type: cty.String, Required: false},
+		"source_image_type":        &hcldec.AttrSpec{Name: "source_image_type", Type: cty.String, Required: false},
+		"launch_mode":              &hcldec.AttrSpec{Name: "launch_mode", Type: cty.String, Required: false},
+		"operating_system":         &hcldec.AttrSpec{Name: "operating_system", Type: cty.String, Required: false},
+		"operating_system_version": &hcldec.AttrSpec{Name: "operating_system_version", Type: cty.String, Required: false},
+	}
+	return s
+}
+
+// FlatImageRetentionConfig is an auto-generated flat version of ImageRetentionConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatImageRetentionConfig struct {
+	KeepReleases  *int    `mapstructure:"keep_releases" required:"true" cty:"keep_releases" hcl:"keep_releases"`
+	NameRegex     *string `mapstructure:"name_regex" required:"true" cty:"name_regex" hcl:"name_regex"`
+	CompartmentID *string `mapstructure:"compartment_ocid" required:"false" cty:"compartment_ocid" hcl:"compartment_ocid"`
+}
+
+// FlatMapstructure returns a new FlatImageRetentionConfig.
+// FlatImageRetentionConfig is an auto-generated flat version of ImageRetentionConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*ImageRetentionConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatImageRetentionConfig)
+}
+
+// HCL2Spec returns the hcl spec of a ImageRetentionConfig.
+// This spec is used by HCL to read the fields of ImageRetentionConfig.
+// The decoded values from this spec will then be applied to a FlatImageRetentionConfig.
+func (*FlatImageRetentionConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"keep_releases":    &hcldec.AttrSpec{Name: "keep_releases", Type: cty.Number, Required: false},
+		"name_regex":       &hcldec.AttrSpec{Name: "name_regex", Type: cty.String, Required: false},
+		"compartment_ocid": &hcldec.AttrSpec{Name: "compartment_ocid", Type: cty.String, Required: false},
+	}
+	return s
+}
+
+// FlatInstanceAgentConfig is an auto-generated flat version of InstanceAgentConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatInstanceAgentConfig struct {
+	IsMonitoringDisabled  *bool                           `mapstructure:"is_monitoring_disabled" required:"false" cty:"is_monitoring_disabled" hcl:"is_monitoring_disabled"`
+	IsManagementDisabled  *bool                           `mapstructure:"is_management_disabled" required:"false" cty:"is_management_disabled" hcl:"is_management_disabled"`
+	AreAllPluginsDisabled *bool                           `mapstructure:"are_all_plugins_disabled" required:"false" cty:"are_all_plugins_disabled" hcl:"are_all_plugins_disabled"`
+	PluginsConfig         []FlatInstanceAgentPluginConfig `mapstructure:"plugins_config" required:"false" cty:"plugins_config" hcl:"plugins_config"`
+}
+
+// FlatMapstructure returns a new FlatInstanceAgentConfig.
+// FlatInstanceAgentConfig is an auto-generated flat version of InstanceAgentConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*InstanceAgentConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatInstanceAgentConfig)
+}
+
+// HCL2Spec returns the hcl spec of a InstanceAgentConfig.
+// This spec is used by HCL to read the fields of InstanceAgentConfig.
+// The decoded values from this spec will then be applied to a FlatInstanceAgentConfig.
+func (*FlatInstanceAgentConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"is_monitoring_disabled":   &hcldec.AttrSpec{Name: "is_monitoring_disabled", Type: cty.Bool, Required: false},
+		"is_management_disabled":   &hcldec.AttrSpec{Name: "is_management_disabled", Type: cty.Bool, Required: false},
+		"are_all_plugins_disabled": &hcldec.AttrSpec{Name: "are_all_plugins_disabled", Type: cty.Bool, Required: false},
+		"plugins_config":           &hcldec.BlockListSpec{TypeName: "plugins_config", Nested: hcldec.ObjectSpec((*FlatInstanceAgentPluginConfig)(nil).HCL2Spec())},
+	}
+	return s
+}
+
+// FlatInstanceAgentPluginConfig is an auto-generated flat version of InstanceAgentPluginConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatInstanceAgentPluginConfig struct {
+	Name         *string `mapstructure:"name" required:"true" cty:"name" hcl:"name"`
+	DesiredState *string `mapstructure:"desired_state" required:"true" cty:"desired_state" hcl:"desired_state"`
+}
+
+// FlatMapstructure returns a new FlatInstanceAgentPluginConfig.
+// FlatInstanceAgentPluginConfig is an auto-generated flat version of InstanceAgentPluginConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*InstanceAgentPluginConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatInstanceAgentPluginConfig)
+}
+
+// HCL2Spec returns the hcl spec of a InstanceAgentPluginConfig.
+// This spec is used by HCL to read the fields of InstanceAgentPluginConfig.
+// The decoded values from this spec will then be applied to a FlatInstanceAgentPluginConfig.
+func (*FlatInstanceAgentPluginConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"name":          &hcldec.AttrSpec{Name: "name", Type: cty.String, Required: false},
+		"desired_state": &hcldec.AttrSpec{Name: "desired_state", Type: cty.String, Required: false},
+	}
+	return s
+}
+
 // FlatInstanceOptionsConfig is an auto-generated flat version of InstanceOptionsConfig.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatInstanceOptionsConfig struct {
@@ -299,15 +705,55 @@ func (*FlatInstanceOptionsConfig) HCL2Spec() map[string]hcldec.Spec {
 	return s
 }
 
+// FlatLaunchOptionsConfig is an auto-generated flat version of LaunchOptionsConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatLaunchOptionsConfig struct {
+	BootVolumeType                  *string `mapstructure:"boot_volume_type" required:"false" cty:"boot_volume_type" hcl:"boot_volume_type"`
+	Firmware                        *string `mapstructure:"firmware" required:"false" cty:"firmware" hcl:"firmware"`
+	NetworkType                     *string `mapstructure:"network_type" required:"false" cty:"network_type" hcl:"network_type"`
+	RemoteDataVolumeType            *string `mapstructure:"remote_data_volume_type" required:"false" cty:"remote_data_volume_type" hcl:"remote_data_volume_type"`
+	IsPvEncryptionInTransitEnabled  *bool   `mapstructure:"is_pv_encryption_in_transit_enabled" required:"false" cty:"is_pv_encryption_in_transit_enabled" hcl:"is_pv_encryption_in_transit_enabled"`
+	IsConsistentVolumeNamingEnabled *bool   `mapstructure:"is_consistent_volume_naming_enabled" required:"false" cty:"is_consistent_volume_naming_enabled" hcl:"is_consistent_volume_naming_enabled"`
+}
+
+// FlatMapstructure returns a new FlatLaunchOptionsConfig.
+// FlatLaunchOptionsConfig is an auto-generated flat version of LaunchOptionsConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*LaunchOptionsConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatLaunchOptionsConfig)
+}
+
+// HCL2Spec returns the hcl spec of a LaunchOptionsConfig.
+// This spec is used by HCL to read the fields of LaunchOptionsConfig.
+// The decoded values from this spec will then be applied to a FlatLaunchOptionsConfig.
+func (*FlatLaunchOptionsConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"boot_volume_type":                    &hcldec.AttrSpec{Name: "boot_volume_type", Type: cty.String, Required: false},
+		"firmware":                            &hcldec.AttrSpec{Name: "firmware", Type: cty.String, Required: false},
+		"network_type":                        &hcldec.AttrSpec{Name: "network_type", Type: cty.String, Required: false},
+		"remote_data_volume_type":             &hcldec.AttrSpec{Name: "remote_data_volume_type", Type: cty.String, Required: false},
+		"is_pv_encryption_in_transit_enabled": &hcldec.AttrSpec{Name: "is_pv_encryption_in_transit_enabled", Type: cty.Bool, Required: false},
+		"is_consistent_volume_naming_enabled": &hcldec.AttrSpec{Name: "is_consistent_volume_naming_enabled", Type: cty.Bool, Required: false},
+	}
+	return s
+}
+
 // FlatListImagesRequest is an auto-generated flat version of ListImagesRequest.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatListImagesRequest struct {
-	CompartmentId          *string `mapstructure:"compartment_id" cty:"compartment_id" hcl:"compartment_id"`
-	DisplayName            *string `mapstructure:"display_name" cty:"display_name" hcl:"display_name"`
-	DisplayNameSearch      *string `mapstructure:"display_name_search" cty:"display_name_search" hcl:"display_name_search"`
-	OperatingSystem        *string `mapstructure:"operating_system" cty:"operating_system" hcl:"operating_system"`
-	OperatingSystemVersion *string `mapstructure:"operating_system_version" cty:"operating_system_version" hcl:"operating_system_version"`
-	Shape                  *string `mapstructure:"shape" cty:"shape" hcl:"shape"`
+	CompartmentId          *string           `mapstructure:"compartment_id" cty:"compartment_id" hcl:"compartment_id"`
+	DisplayName            *string           `mapstructure:"display_name" cty:"display_name" hcl:"display_name"`
+	DisplayNameSearch      *string           `mapstructure:"display_name_search" cty:"display_name_search" hcl:"display_name_search"`
+	OperatingSystem        *string           `mapstructure:"operating_system" cty:"operating_system" hcl:"operating_system"`
+	OperatingSystemVersion *string           `mapstructure:"operating_system_version" cty:"operating_system_version" hcl:"operating_system_version"`
+	Shape                  *string           `mapstructure:"shape" cty:"shape" hcl:"shape"`
+	MaxResults             *int              `mapstructure:"max_results" cty:"max_results" hcl:"max_results"`
+	PageSize               *int              `mapstructure:"page_size" cty:"page_size" hcl:"page_size"`
+	FreeformTags           map[string]string `mapstructure:"tags" cty:"tags" hcl:"tags"`
+	DefinedTags            map[string]string `mapstructure:"defined_tags" cty:"defined_tags" hcl:"defined_tags"`
+	CreatedAfter           *string           `mapstructure:"created_after" cty:"created_after" hcl:"created_after"`
+	DisplayNameExclude     *string           `mapstructure:"display_name_exclude" cty:"display_name_exclude" hcl:"display_name_exclude"`
+	VersionRegex           *string           `mapstructure:"version_regex" cty:"version_regex" hcl:"version_regex"`
 }
 
 // FlatMapstructure returns a new FlatListImagesRequest.
@@ -328,6 +774,145 @@ func (*FlatListImagesRequest) HCL2Spec() map[string]hcldec.Spec {
 		"operating_system":         &hcldec.AttrSpec{Name: "operating_system", Type: cty.String, Required: false},
 		"operating_system_version": &hcldec.AttrSpec{Name: "operating_system_version", Type: cty.String, Required: false},
 		"shape":                    &hcldec.AttrSpec{Name: "shape", Type: cty.String, Required: false},
+		"max_results":              &hcldec.AttrSpec{Name: "max_results", Type: cty.Number, Required: false},
+		"page_size":                &hcldec.AttrSpec{Name: "page_size", Type: cty.Number, Required: false},
+		"tags":                     &hcldec.AttrSpec{Name: "tags", Type: cty.Map(cty.String), Required: false},
+		"defined_tags":             &hcldec.AttrSpec{Name: "defined_tags", Type: cty.Map(cty.String), Required: false},
+		"created_after":            &hcldec.AttrSpec{Name: "created_after", Type: cty.String, Required: false},
+		"display_name_exclude":     &hcldec.AttrSpec{Name: "display_name_exclude", Type: cty.String, Required: false},
+		"version_regex":            &hcldec.AttrSpec{Name: "version_regex", Type: cty.String, Required: false},
+	}
+	return s
+}
+
+// FlatMarketplacePublicationConfig is an auto-generated flat version of MarketplacePublicationConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatMarketplacePublicationConfig struct {
+	Name                  *string `mapstructure:"name" required:"false" cty:"name" hcl:"name"`
+	ShortDescription      *string `mapstructure:"short_description" required:"true" cty:"short_description" hcl:"short_description"`
+	LongDescription       *string `mapstructure:"long_description" required:"false" cty:"long_description" hcl:"long_description"`
+	PackageVersion        *string `mapstructure:"package_version" required:"false" cty:"package_version" hcl:"package_version"`
+	OperatingSystem       *string `mapstructure:"operating_system" required:"false" cty:"operating_system" hcl:"operating_system"`
+	EulaText              *string `mapstructure:"eula_text" required:"false" cty:"eula_text" hcl:"eula_text"`
+	SupportName           *string `mapstructure:"support_name" required:"false" cty:"support_name" hcl:"support_name"`
+	SupportEmail          *string `mapstructure:"support_email" required:"true" cty:"support_email" hcl:"support_email"`
+	CompartmentID         *string `mapstructure:"compartment_ocid" required:"false" cty:"compartment_ocid" hcl:"compartment_ocid"`
+	AgreementAcknowledged *bool   `mapstructure:"agreement_acknowledged" required:"true" cty:"agreement_acknowledged" hcl:"agreement_acknowledged"`
+}
+
+// FlatMapstructure returns a new FlatMarketplacePublicationConfig.
+// FlatMarketplacePublicationConfig is an auto-generated flat version of MarketplacePublicationConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*MarketplacePublicationConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatMarketplacePublicationConfig)
+}
+
+// HCL2Spec returns the hcl spec of a MarketplacePublicationConfig.
+// This spec is used by HCL to read the fields of MarketplacePublicationConfig.
+// The decoded values from this spec will then be applied to a FlatMarketplacePublicationConfig.
+func (*FlatMarketplacePublicationConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"name":                   &hcldec.AttrSpec{Name: "name", Type: cty.String, Required: false},
+		"short_description":      &hcldec.AttrSpec{Name: "short_description", Type: cty.String, Required: false},
+		"long_description":       &hcldec.AttrSpec{Name: "long_description", Type: cty.String, Required: false},
+		"package_version":        &hcldec.AttrSpec{Name: "package_version", Type: cty.String, Required: false},
+		"operating_system":       &hcldec.AttrSpec{Name: "operating_system", Type: cty.String, Required: false},
+		"eula_text":              &hcldec.AttrSpec{Name: "eula_text", Type: cty.String, Required: false},
+		"support_name":           &hcldec.AttrSpec{Name: "support_name", Type: cty.String, Required: false},
+		"support_email":          &hcldec.AttrSpec{Name: "support_email", Type: cty.String, Required: false},
+		"compartment_ocid":       &hcldec.AttrSpec{Name: "compartment_ocid", Type: cty.String, Required: false},
+		"agreement_acknowledged": &hcldec.AttrSpec{Name: "agreement_acknowledged", Type: cty.Bool, Required: false},
+	}
+	return s
+}
+
+// FlatPlatformConfig is an auto-generated flat version of PlatformConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatPlatformConfig struct {
+	Type                             *string `mapstructure:"type" required:"false" cty:"type" hcl:"type"`
+	IsSymmetricMultiThreadingEnabled *bool   `mapstructure:"is_symmetric_multi_threading_enabled" required:"false" cty:"is_symmetric_multi_threading_enabled" hcl:"is_symmetric_multi_threading_enabled"`
+	NumaNodesPerSocket               *string `mapstructure:"numa_nodes_per_socket" required:"false" cty:"numa_nodes_per_socket" hcl:"numa_nodes_per_socket"`
+	PercentageOfCoresEnabled         *int    `mapstructure:"percentage_of_cores_enabled" required:"false" cty:"percentage_of_cores_enabled" hcl:"percentage_of_cores_enabled"`
+	IsSecureBootEnabled              *bool   `mapstructure:"is_secure_boot_enabled" required:"false" cty:"is_secure_boot_enabled" hcl:"is_secure_boot_enabled"`
+	IsTrustedPlatformModuleEnabled   *bool   `mapstructure:"is_trusted_platform_module_enabled" required:"false" cty:"is_trusted_platform_module_enabled" hcl:"is_trusted_platform_module_enabled"`
+	IsMeasuredBootEnabled            *bool   `mapstructure:"is_measured_boot_enabled" required:"false" cty:"is_measured_boot_enabled" hcl:"is_measured_boot_enabled"`
+}
+
+// FlatMapstructure returns a new FlatPlatformConfig.
+// FlatPlatformConfig is an auto-generated flat version of PlatformConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*PlatformConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatPlatformConfig)
+}
+
+// HCL2Spec returns the hcl spec of a PlatformConfig.
+// This spec is used by HCL to read the fields of PlatformConfig.
+// The decoded values from this spec will then be applied to a FlatPlatformConfig.
+func (*FlatPlatformConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"type":                                 &hcldec.AttrSpec{Name: "type", Type: cty.String, Required: false},
+		"is_symmetric_multi_threading_enabled": &hcldec.AttrSpec{Name: "is_symmetric_multi_threading_enabled", Type: cty.Bool, Required: false},
+		"numa_nodes_per_socket":                &hcldec.AttrSpec{Name: "numa_nodes_per_socket", Type: cty.String, Required: false},
+		"percentage_of_cores_enabled":          &hcldec.AttrSpec{Name: "percentage_of_cores_enabled", Type: cty.Number, Required: false},
+		"is_secure_boot_enabled":               &hcldec.AttrSpec{Name: "is_secure_boot_enabled", Type: cty.Bool, Required: false},
+		"is_trusted_platform_module_enabled":   &hcldec.AttrSpec{Name: "is_trusted_platform_module_enabled", Type: cty.Bool, Required: false},
+		"is_measured_boot_enabled":             &hcldec.AttrSpec{Name: "is_measured_boot_enabled", Type: cty.Bool, Required: false},
+	}
+	return s
+}
+
+// FlatPreemptibleInstanceConfig is an auto-generated flat version of PreemptibleInstanceConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatPreemptibleInstanceConfig struct {
+	PreserveBootVolume *bool `mapstructure:"preserve_boot_volume" required:"false" cty:"preserve_boot_volume" hcl:"preserve_boot_volume"`
+}
+
+// FlatMapstructure returns a new FlatPreemptibleInstanceConfig.
+// FlatPreemptibleInstanceConfig is an auto-generated flat version of PreemptibleInstanceConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*PreemptibleInstanceConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatPreemptibleInstanceConfig)
+}
+
+// HCL2Spec returns the hcl spec of a PreemptibleInstanceConfig.
+// This spec is used by HCL to read the fields of PreemptibleInstanceConfig.
+// The decoded values from this spec will then be applied to a FlatPreemptibleInstanceConfig.
+func (*FlatPreemptibleInstanceConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"preserve_boot_volume": &hcldec.AttrSpec{Name: "preserve_boot_volume", Type: cty.Bool, Required: false},
+	}
+	return s
+}
+
+// FlatTimeoutsConfig is an auto-generated flat version of TimeoutsConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatTimeoutsConfig struct {
+	Compute              *string `mapstructure:"compute" required:"false" cty:"compute" hcl:"compute"`
+	Network              *string `mapstructure:"network" required:"false" cty:"network" hcl:"network"`
+	ObjectStorage        *string `mapstructure:"object_storage" required:"false" cty:"object_storage" hcl:"object_storage"`
+	PollingInterval      *string `mapstructure:"polling_interval" required:"false" cty:"polling_interval" hcl:"polling_interval"`
+	AdaptivePolling      *bool   `mapstructure:"adaptive_polling" required:"false" cty:"adaptive_polling" hcl:"adaptive_polling"`
+	AdaptivePollingCache *string `mapstructure:"adaptive_polling_cache" required:"false" cty:"adaptive_polling_cache" hcl:"adaptive_polling_cache"`
+}
+
+// FlatMapstructure returns a new FlatTimeoutsConfig.
+// FlatTimeoutsConfig is an auto-generated flat version of TimeoutsConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*TimeoutsConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatTimeoutsConfig)
+}
+
+// HCL2Spec returns the hcl spec of a TimeoutsConfig.
+// This spec is used by HCL to read the fields of TimeoutsConfig.
+// The decoded values from this spec will then be applied to a FlatTimeoutsConfig.
+func (*FlatTimeoutsConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"compute":                &hcldec.AttrSpec{Name: "compute", Type: cty.String, Required: false},
+		"network":                &hcldec.AttrSpec{Name: "network", Type: cty.String, Required: false},
+		"object_storage":         &hcldec.AttrSpec{Name: "object_storage", Type: cty.String, Required: false},
+		"polling_interval":       &hcldec.AttrSpec{Name: "polling_interval", Type: cty.String, Required: false},
+		"adaptive_polling":       &hcldec.AttrSpec{Name: "adaptive_polling", Type: cty.Bool, Required: false},
+		"adaptive_polling_cache": &hcldec.AttrSpec{Name: "adaptive_polling_cache", Type: cty.String, Required: false},
 	}
 	return s
 }
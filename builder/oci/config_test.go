@@ -4,14 +4,19 @@
 package oci
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"io/ioutil"
 	"os"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-ini/ini"
 )
@@ -121,26 +126,1351 @@ func TestConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("BaseImageAlias", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["base_image_ocid"] = ""
+		raw["base_image"] = "Canonical-Ubuntu-22.04-latest"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		search := regexp.MustCompile(*c.BaseImageFilter.DisplayNameSearch)
+		for name, expected := range map[string]bool{
+			"Canonical-Ubuntu-22.04-2024.01.12-0":         true,
+			"Canonical-Ubuntu-22.04-aarch64-2024.01.12-0": true,
+			"Canonical-Ubuntu-22.04.1-2023.01.12-0":       true,
+			"Canonical-Ubuntu-22.04-Minimal-2024.01.12-0": false,
+			"Canonical-Ubuntu-22.040-2024.01.12-0":        false,
+			"Canonical-Ubuntu-20.04-2024.01.12-0":         false,
+			"My-Canonical-Ubuntu-22.04-2024.01.12-0":      false,
+		} {
+			if search.MatchString(name) != expected {
+				t.Errorf("Expected base_image to match %q: %t", name, expected)
+			}
+		}
+	})
+
+	t.Run("BaseImageAliasInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["base_image_ocid"] = ""
+		raw["base_image"] = "Oracle-Linux-9"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'base_image' \"Oracle-Linux-9\" must be a platform image alias") {
+			t.Fatalf("Expected an error for an invalid base_image, got %v", errs)
+		}
+	})
+
+	t.Run("BaseImageAliasWithOCID", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["base_image"] = "Oracle-Linux-9-latest"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'base_image' cannot be combined with 'base_image_ocid' or 'base_image_filter'") {
+			t.Fatalf("Expected an error combining base_image with base_image_ocid, got %v", errs)
+		}
+	})
+
 	t.Run("LaunchMode", func(t *testing.T) {
 		raw := testConfig(cfgFile)
 		raw["image_launch_mode"] = "NATIVE"
 
 		var c Config
 		errs := c.Prepare(raw)
-		if errs != nil {
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+	})
+
+	t.Run("NicAttachmentType", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["nic_attachment_type"] = "VFIO"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+	})
+
+	t.Run("VlanWithoutSubnet", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "subnet_ocid")
+		raw["vlan_ocid"] = "ocd1..."
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.CreateVnicDetails.SubnetId != nil {
+			t.Errorf("Expected no subnet to be set, got %q", *c.CreateVnicDetails.SubnetId)
+		}
+	})
+
+	t.Run("PrivateIPAndPrivateFQDN", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["use_private_ip"] = true
+		raw["use_private_fqdn"] = true
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "use_private_fqdn") {
+			t.Fatalf("Expected error about use_private_fqdn, got %+v", errs)
+		}
+	})
+
+	t.Run("NoWarningsForBaseConfig", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if len(c.warnings) != 0 {
+			t.Errorf("Expected no warnings, got %q", c.warnings)
+		}
+	})
+
+	t.Run("WarnIgnoredOptions", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["base_image_filter"] = map[string]interface{}{
+			"display_name": "hello_world",
+		}
+		raw["shape_config"] = map[string]interface{}{
+			"ocpus": 2,
+		}
+		raw["skip_create_image"] = true
+		raw["nic_attachment_type"] = "VFIO"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		warnings := strings.Join(c.warnings, "\n")
+		for _, expected := range []string{"'base_image_filter'", "'shape_config'", "'image_name'", "'nic_attachment_type'"} {
+			if !strings.Contains(warnings, expected) {
+				t.Errorf("Expected warnings %q to contain %s", warnings, expected)
+			}
+		}
+	})
+
+	t.Run("TimeoutsDefaultPollingInterval", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["timeouts"] = map[string]interface{}{
+			"compute": "30m",
+			"network": "1m",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.Timeouts.Compute != 30*time.Minute || c.Timeouts.Network != time.Minute {
+			t.Errorf("Unexpected timeouts %+v", c.Timeouts)
+		}
+		if c.Timeouts.PollingInterval != 5*time.Second {
+			t.Errorf("Expected default polling interval of 5s, got %s", c.Timeouts.PollingInterval)
+		}
+	})
+
+	t.Run("TimeoutsAdaptivePollingDefaultCache", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["timeouts"] = map[string]interface{}{
+			"adaptive_polling": true,
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.Timeouts.AdaptivePollingCache != defaultDurationCachePath() {
+			t.Errorf("Expected default cache path %s, got %q", defaultDurationCachePath(), c.Timeouts.AdaptivePollingCache)
+		}
+	})
+
+	t.Run("TimeoutsNegative", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["timeouts"] = map[string]interface{}{
+			"compute": "-1m",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'timeouts'") {
+			t.Fatalf("Expected error about 'timeouts', got %+v", errs)
+		}
+	})
+
+	t.Run("InstanceTagsBuildUUID", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["instance_tags"] = map[string]interface{}{"team": "images"}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.InstanceTags["team"] != "images" || c.InstanceTags[buildUUIDTag] == "" {
+			t.Errorf("Expected the instance tags along with a build UUID, got %v", c.InstanceTags)
+		}
+	})
+
+	t.Run("ImageCreationTimeout", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_creation_timeout"] = "1h"
+		raw["image_polling_interval"] = "30s"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.ImageCreationTimeout != time.Hour || c.ImagePollingInterval != 30*time.Second {
+			t.Errorf("Unexpected image creation timeout %s and polling interval %s", c.ImageCreationTimeout, c.ImagePollingInterval)
+		}
+	})
+
+	t.Run("ImageCreationTimeoutLongerThanCompute", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_creation_timeout"] = "3h"
+		raw["timeouts"] = map[string]interface{}{"compute": "30m"}
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if got := c.imageCreationTimeout(); got != 3*time.Hour {
+			t.Errorf("Expected image_creation_timeout to replace timeouts.compute, got %s", got)
+		}
+
+		delete(raw, "image_creation_timeout")
+		c = Config{}
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if got := c.imageCreationTimeout(); got != 30*time.Minute {
+			t.Errorf("Expected timeouts.compute by default, got %s", got)
+		}
+	})
+
+	t.Run("ImageCreationTimeoutNegative", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_creation_timeout"] = "-1h"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'image_creation_timeout'") {
+			t.Fatalf("Expected error about 'image_creation_timeout', got %+v", errs)
+		}
+	})
+
+	t.Run("SecurityListSourceCidrsDefault", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["security_list_ocid"] = "ocd1..."
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if len(c.SecurityListSourceCidrs) != 1 || c.SecurityListSourceCidrs[0] != "0.0.0.0/0" {
+			t.Errorf("Expected default source CIDRs [0.0.0.0/0], got %q", c.SecurityListSourceCidrs)
+		}
+	})
+
+	t.Run("ReservedPublicIPOCID", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["reserved_public_ip_ocid"] = "ocd1..."
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.PublicIPLifetime != "RESERVED" {
+			t.Errorf("Expected public_ip_lifetime RESERVED, got %q", c.PublicIPLifetime)
+		}
+		if c.CreateVnicDetails.AssignPublicIp == nil || *c.CreateVnicDetails.AssignPublicIp {
+			t.Errorf("Expected assign_public_ip to be false")
+		}
+	})
+
+	t.Run("ReservedPublicIPWithEphemeralAssignment", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["public_ip_lifetime"] = "RESERVED"
+		raw["create_vnic_details"] = map[string]interface{}{
+			"assign_public_ip": true,
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "assign_public_ip") {
+			t.Fatalf("Expected error about assign_public_ip, got %+v", errs)
+		}
+	})
+
+	t.Run("InvalidPublicIPLifetime", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["public_ip_lifetime"] = "FOREVER"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "public_ip_lifetime") {
+			t.Fatalf("Expected error about public_ip_lifetime, got %+v", errs)
+		}
+	})
+
+	t.Run("BuildRetryOnDefault", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["build_retry_attempts"] = 2
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if !reflect.DeepEqual(c.BuildRetryOn, []string{"capacity", "preemption", "maintenance"}) {
+			t.Errorf("Unexpected build_retry_on default: %v", c.BuildRetryOn)
+		}
+	})
+
+	t.Run("InvalidBuildRetryOn", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["build_retry_attempts"] = 2
+		raw["build_retry_on"] = []string{"capacity", "quota"}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "build_retry_on") {
+			t.Fatalf("Expected error about build_retry_on, got %+v", errs)
+		}
+	})
+
+	t.Run("NegativeBuildRetryAttempts", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["build_retry_attempts"] = -1
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "build_retry_attempts") {
+			t.Fatalf("Expected error about build_retry_attempts, got %+v", errs)
+		}
+	})
+
+	t.Run("UseIPv6WithPrivateIP", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["use_ipv6"] = true
+		raw["use_private_ip"] = true
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "use_ipv6") {
+			t.Fatalf("Expected error about use_ipv6, got %+v", errs)
+		}
+	})
+
+	t.Run("UseIPv6SecurityListSourceCidrsDefault", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["use_ipv6"] = true
+		raw["security_list_ocid"] = "ocid1.securitylist.oc1..aaa"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if !reflect.DeepEqual(c.SecurityListSourceCidrs, []string{"::/0"}) {
+			t.Errorf("Unexpected security_list_source_cidrs default: %v", c.SecurityListSourceCidrs)
+		}
+	})
+
+	t.Run("PreemptibleWithoutRetry", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["is_preemptible"] = true
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if !strings.Contains(strings.Join(c.warnings, "\n"), "build_retry_attempts") {
+			t.Errorf("Expected a warning about build_retry_attempts, got %q", c.warnings)
+		}
+	})
+
+	t.Run("PreemptibleWithRetry", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["is_preemptible"] = true
+		raw["build_retry_attempts"] = 1
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if len(c.warnings) != 0 {
+			t.Errorf("Expected no warnings, got %q", c.warnings)
+		}
+	})
+
+	t.Run("InvalidBlockVolume", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["block_volumes"] = []map[string]interface{}{
+			{"size_in_gbs": 10, "vpus_per_gb": 15, "attachment_type": "emulated"},
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil {
+			t.Fatalf("Expected error in configuration")
+		}
+		for _, expected := range []string{"size_in_gbs", "vpus_per_gb", "attachment_type"} {
+			if !strings.Contains(errs.Error(), expected) {
+				t.Errorf("Expected error about %s, got %+v", expected, errs)
+			}
+		}
+	})
+
+	t.Run("ExistingBlockVolume", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["block_volumes"] = []map[string]interface{}{
+			{"volume_ocid": "ocid1.volume.oc1..aaa"},
+			{"volume_ocid": "ocid1.volume.oc1..bbb", "size_in_gbs": 100},
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'block_volumes[1].volume_ocid'") {
+			t.Fatalf("Expected a block_volumes[1] error, got %v", errs)
+		}
+		if strings.Contains(errs.Error(), "block_volumes[0]") {
+			t.Errorf("Unexpected block_volumes[0] error: %+v", errs)
+		}
+	})
+
+	t.Run("AvailabilityDomains", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "availability_domain")
+		raw["availability_domains"] = []string{"aaaa:PHX-AD-2", "aaaa:PHX-AD-3"}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.AvailabilityDomain != "aaaa:PHX-AD-2" {
+			t.Errorf("Expected availability_domain to default to the first of availability_domains, got %s", c.AvailabilityDomain)
+		}
+	})
+
+	t.Run("AvailabilityDomainAndDomains", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["availability_domains"] = []string{"aaaa:PHX-AD-2"}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "availability_domains") {
+			t.Fatalf("Expected error about availability_domains, got %+v", errs)
+		}
+	})
+
+	t.Run("BaseImageFilterTags", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["base_image_ocid"] = ""
+		raw["base_image_filter"] = map[string]interface{}{
+			"tags":         map[string]string{"approved": "true"},
+			"defined_tags": map[string]string{"Security.approved": "true", "approved": "true"},
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'base_image_filter.defined_tags' key \"approved\" must be of the form namespace.key") {
+			t.Fatalf("Expected an error for a defined tag without namespace, got %v", errs)
+		}
+		if strings.Contains(errs.Error(), "Security.approved") {
+			t.Fatalf("Unexpected error for a valid defined tag: %v", errs)
+		}
+	})
+
+	t.Run("BaseImageFilterCreatedAfter", func(t *testing.T) {
+		for value, expected := range map[string]time.Time{
+			"2024-01-01T00:00:00Z": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			"720h":                 time.Now().Add(-720 * time.Hour),
+		} {
+			raw := testConfig(cfgFile)
+			raw["base_image_ocid"] = ""
+			raw["base_image_filter"] = map[string]interface{}{
+				"created_after": value,
+			}
+
+			var c Config
+			if errs := c.Prepare(raw); errs != nil {
+				t.Fatalf("Unexpected error in configuration %+v", errs)
+			}
+			if c.baseImageCreatedAfter.Sub(expected).Abs() > time.Minute {
+				t.Errorf("Expected created_after %q to be %s, got %s", value, expected, c.baseImageCreatedAfter)
+			}
+		}
+
+		for _, value := range []string{"2024-01-01", "-24h"} {
+			raw := testConfig(cfgFile)
+			raw["base_image_ocid"] = ""
+			raw["base_image_filter"] = map[string]interface{}{
+				"created_after": value,
+			}
+
+			var c Config
+			errs := c.Prepare(raw)
+			if errs == nil || !strings.Contains(errs.Error(), "'base_image_filter.created_after' must be an RFC 3339 timestamp or a positive duration") {
+				t.Fatalf("Expected an error for created_after %q, got %v", value, errs)
+			}
+		}
+	})
+
+	t.Run("BaseImageFilterInvalidDisplayNameExclude", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["base_image_ocid"] = ""
+		raw["base_image_filter"] = map[string]interface{}{
+			"display_name_search":  "^Oracle-Linux-8",
+			"display_name_exclude": "(GPU|aarch64",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'base_image_filter.display_name_exclude' is invalid") {
+			t.Fatalf("Expected an error for an invalid display_name_exclude, got %v", errs)
+		}
+	})
+
+	t.Run("BaseImageFilterVersionRegexWithoutGroup", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["base_image_ocid"] = ""
+		raw["base_image_filter"] = map[string]interface{}{
+			"version_regex": "^Oracle-Linux-8\\.[0-9]+",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'base_image_filter.version_regex' must have a capture group for the version") {
+			t.Fatalf("Expected an error for a version_regex without capture group, got %v", errs)
+		}
+	})
+
+	t.Run("MarketplacePublication", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["marketplace_publication"] = map[string]interface{}{
+			"short_description": "Hardened appliance",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		for _, expected := range []string{"'marketplace_publication.support_email' must be specified", "'marketplace_publication.agreement_acknowledged' must be true"} {
+			if errs == nil || !strings.Contains(errs.Error(), expected) {
+				t.Errorf("Expected %q error, got %v", expected, errs)
+			}
+		}
+
+		raw["marketplace_publication"] = map[string]interface{}{
+			"short_description":      "Hardened appliance",
+			"support_email":          "support@example.com",
+			"agreement_acknowledged": true,
+		}
+		c = Config{}
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if c.MarketplacePublication.CompartmentID != c.ImageCompartmentID {
+			t.Errorf("Expected the publication in the compartment of the image, got %q", c.MarketplacePublication.CompartmentID)
+		}
+	})
+
+	t.Run("BaseImageFilterInvalidMaxResults", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "base_image_ocid")
+		raw["base_image_filter"] = map[string]interface{}{
+			"display_name_search": "^Oracle-Linux",
+			"max_results":         0,
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "base_image_filter.max_results") {
+			t.Fatalf("Expected error about base_image_filter.max_results, got %+v", errs)
+		}
+	})
+
+	t.Run("InvalidPauseBeforeCapture", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["pause_before_capture"] = "soon"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "pause_before_capture") {
+			t.Fatalf("Expected error about pause_before_capture, got %+v", errs)
+		}
+	})
+
+	t.Run("ImageNameBuildCounter", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_name"] = "base-{{isotime \"2006\"}}-{{ .BuildCounter }}"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if !c.usesBuildCounter() {
+			t.Errorf("Expected the build counter to be kept for later, got %s", c.ImageName)
+		}
+		if strings.Contains(c.ImageName, "isotime") {
+			t.Errorf("Expected isotime to be rendered, got %s", c.ImageName)
+		}
+	})
+
+	t.Run("ImageConnectionHints", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "ssh_username")
+		raw["use_image_connection_hints"] = true
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if !c.sshUsernameFromImage || c.Comm.SSHUsername != "" {
+			t.Errorf("Expected the SSH username to be read from the base image, got %q", c.Comm.SSHUsername)
+		}
+	})
+
+	t.Run("CaptureShapeConfigNonFlex", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["capture_shape_config"] = map[string]interface{}{"ocpus": 1}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "capture_shape_config") {
+			t.Fatalf("Expected error about capture_shape_config, got %+v", errs)
+		}
+	})
+
+	t.Run("UserDataCompressed", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["user_data"] = strings.Repeat("#cloud-config\n", 4000)
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(c.UserData)
+		if err != nil {
+			t.Fatalf("Expected base64 encoded user data: %s", err)
+		}
+		if !bytes.HasPrefix(decoded, []byte{0x1f, 0x8b}) {
+			t.Errorf("Expected gzip compressed user data")
+		}
+	})
+
+	t.Run("UserDataTooLarge", func(t *testing.T) {
+		random := make([]byte, 40000)
+		if _, err := rand.Read(random); err != nil {
+			t.Fatal(err)
+		}
+		raw := testConfig(cfgFile)
+		raw["user_data"] = base64.StdEncoding.EncodeToString(random)
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "user data is too large") {
+			t.Fatalf("Expected error about user data size, got %+v", errs)
+		}
+	})
+
+	t.Run("FreeTierDefaultShape", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "shape")
+		raw["free_tier"] = true
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.Shape != "VM.Standard.A1.Flex" {
+			t.Errorf("Expected Always Free shape VM.Standard.A1.Flex, got %q", c.Shape)
+		}
+		if c.ShapeConfig.Ocpus == nil || *c.ShapeConfig.Ocpus != 1 || c.ShapeConfig.MemoryInGBs == nil || *c.ShapeConfig.MemoryInGBs != 6 {
+			t.Errorf("Expected 1 OCPU and 6 GBs, got %+v", c.ShapeConfig)
+		}
+	})
+
+	t.Run("FreeTierShape", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["free_tier"] = true
+		raw["shape"] = "VM.Standard.E4.Flex"
+		raw["shape_config"] = map[string]interface{}{"ocpus": 1}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'shape' must be one of") {
+			t.Fatalf("Expected a free tier shape error, got %v", errs)
+		}
+	})
+
+	t.Run("FreeTierLimits", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["free_tier"] = true
+		raw["shape"] = "VM.Standard.A1.Flex"
+		raw["shape_config"] = map[string]interface{}{"ocpus": 8}
+		raw["disk_size"] = 100
+		raw["block_volumes"] = []map[string]interface{}{{"size_in_gbs": 150}}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil {
+			t.Fatal("Expected errors for configuration exceeding the free tier")
+		}
+		for _, expected := range []string{"'shape_config.ocpus' must be at most 4", "add up to 250 GBs"} {
+			if !strings.Contains(errs.Error(), expected) {
+				t.Errorf("Expected %q in errors, got %s", expected, errs)
+			}
+		}
+	})
+
+	t.Run("BootVolumeVpusPerGB", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["boot_volume_vpus_per_gb"] = 25
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'boot_volume_vpus_per_gb'") {
+			t.Fatalf("Expected a boot_volume_vpus_per_gb error, got %v", errs)
+		}
+	})
+
+	t.Run("InstanceNameTemplate", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["packer_build_name"] = "base"
+		raw["instance_name"] = "packer-{{ build_name }}"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if *c.InstanceName != "packer-base" || c.usesSourceImageName() {
+			t.Errorf("Expected instance name rendered at prepare time, got %q", *c.InstanceName)
+		}
+	})
+
+	t.Run("InstanceNameSourceImageName", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["packer_build_name"] = "base"
+		raw["instance_name"] = "packer-{{ build_name }}-{{ .SourceImageName }}"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if !c.usesSourceImageName() {
+			t.Fatal("Expected instance name to be rendered at launch")
+		}
+		name, err := c.renderInstanceName("Oracle-Linux-8.8")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != "packer-base-Oracle-Linux-8.8" {
+			t.Errorf("Unexpected instance name %q", name)
+		}
+	})
+
+	t.Run("HostnameLabelInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["create_vnic_details"] = map[string]interface{}{
+			"hostname_label": "packer_{{ build_name }}",
+		}
+		raw["packer_build_name"] = "base"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'create_vnic_details.hostname_label'") {
+			t.Fatalf("Expected a hostname_label error, got %v", errs)
+		}
+	})
+
+	t.Run("WaitForGPUDriver", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["wait_for_gpu_driver"] = true
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.GPUDriverTimeout != 15*time.Minute {
+			t.Errorf("Unexpected gpu_driver_timeout %s", c.GPUDriverTimeout)
+		}
+		if !strings.Contains(strings.Join(c.warnings, "\n"), "'wait_for_gpu_driver'") {
+			t.Errorf("Expected a wait_for_gpu_driver warning, got %q", c.warnings)
+		}
+	})
+
+	t.Run("RotateWinRMPassword", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["communicator"] = "winrm"
+		raw["winrm_username"] = "opc"
+		raw["winrm_use_ssl"] = true
+		raw["configure_winrm"] = true
+		raw["rotate_winrm_password"] = true
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if len(c.Comm.WinRMPassword) != generatedPasswordLength {
+			t.Errorf("Expected a generated WinRM password, got %q", c.Comm.WinRMPassword)
+		}
+		if c.UserData != winrmUserData(true, "opc", c.Comm.WinRMPassword) {
+			t.Errorf("Expected the WinRM user_data, got %q", c.UserData)
+		}
+	})
+
+	t.Run("RotateWinRMPasswordShutdownCommand", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["communicator"] = "winrm"
+		raw["winrm_username"] = "opc"
+		raw["rotate_winrm_password"] = true
+		raw["shutdown_before_image"] = true
+		raw["shutdown_command"] = "shutdown /s /t 5"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'shutdown_command' cannot be set") {
+			t.Fatalf("Expected a shutdown_command error, got %v", errs)
+		}
+
+		// A soft stop does not use the communicator
+		delete(raw, "shutdown_command")
+		c = Config{}
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+	})
+
+	t.Run("RotateWinRMPasswordUserData", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["communicator"] = "winrm"
+		raw["winrm_username"] = "opc"
+		raw["rotate_winrm_password"] = true
+		raw["user_data"] = "#ps1_sysnative"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'user_data'") {
+			t.Fatalf("Expected a user_data error, got %v", errs)
+		}
+	})
+
+	t.Run("InvalidInstanceDisposal", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["instance_disposal"] = "keep"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'instance_disposal'") {
+			t.Fatalf("Expected an instance_disposal error, got %v", errs)
+		}
+	})
+
+	t.Run("BootstrapScript", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["packer_build_name"] = "base"
+		raw["bootstrap_script"] = "echo {{ build_name }} '{{ .SSHPublicKey }}'"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if !stringSliceContains(c.WaitForAgentPlugins, runCommandPlugin) {
+			t.Errorf("Expected to wait for the Run Command plugin, got %q", c.WaitForAgentPlugins)
+		}
+		if c.BootstrapTimeout != 10*time.Minute {
+			t.Errorf("Unexpected bootstrap_timeout %s", c.BootstrapTimeout)
+		}
+		script, err := c.renderBootstrapScript("ssh-ed25519 AAAA")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if script != "echo base 'ssh-ed25519 AAAA'" {
+			t.Errorf("Unexpected bootstrap script %q", script)
+		}
+	})
+
+	t.Run("SourceBootVolume", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "base_image_ocid")
+		raw["source_boot_volume_ocid"] = "ocid1.bootvolume.oc1..aaa"
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+	})
+
+	t.Run("SourceBootVolumeWithBaseImage", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["source_boot_volume_ocid"] = "ocid1.bootvolume.oc1..aaa"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if !strings.Contains(errs.Error(), "'source_boot_volume_ocid' cannot be combined") {
+			t.Fatalf("Expected source_boot_volume_ocid error, got %v", errs)
+		}
+	})
+
+	t.Run("SourceBootVolumeWithImageConnectionHints", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "base_image_ocid")
+		raw["source_boot_volume_ocid"] = "ocid1.bootvolume.oc1..aaa"
+		raw["use_image_connection_hints"] = true
+
+		var c Config
+		errs := c.Prepare(raw)
+		if !strings.Contains(errs.Error(), "'use_image_connection_hints' requires an image source") {
+			t.Fatalf("Expected use_image_connection_hints error, got %v", errs)
+		}
+	})
+
+	t.Run("BootVolume", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "base_image_ocid")
+		delete(raw, "disk_size")
+		raw["boot_volume_ocid"] = "ocid1.bootvolume.oc1..aaa"
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if !c.PreserveBootVolume {
+			t.Fatalf("boot_volume_ocid should be preserved")
+		}
+	})
+
+	t.Run("BootVolumeWithDiskSize", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "base_image_ocid")
+		raw["boot_volume_ocid"] = "ocid1.bootvolume.oc1..aaa"
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if !strings.Contains(strings.Join(c.warnings, "\n"), "'disk_size' and 'boot_volume_kms_key_ocid' are ignored") {
+			t.Fatalf("Expected disk_size warning, got %q", c.warnings)
+		}
+	})
+
+	t.Run("BootVolumeWithSourceBootVolume", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "base_image_ocid")
+		raw["boot_volume_ocid"] = "ocid1.bootvolume.oc1..aaa"
+		raw["source_boot_volume_ocid"] = "ocid1.bootvolume.oc1..bbb"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if !strings.Contains(errs.Error(), "'boot_volume_ocid' cannot be combined") {
+			t.Fatalf("Expected boot_volume_ocid error, got %v", errs)
+		}
+	})
+
+	t.Run("PlatformConfig", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["platform_config"] = map[string]interface{}{
+			"type":                                 "intel_icelake_bm",
+			"is_symmetric_multi_threading_enabled": false,
+			"numa_nodes_per_socket":                "NPS2",
+		}
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if c.PlatformConfig.Type != "INTEL_ICELAKE_BM" {
+			t.Fatalf("Unexpected platform type %q", c.PlatformConfig.Type)
+		}
+	})
+
+	t.Run("PlatformConfigWithoutType", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["platform_config"] = map[string]interface{}{
+			"numa_nodes_per_socket": "NPS2",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if !strings.Contains(errs.Error(), "'platform_config.type' must be one of") {
+			t.Fatalf("Expected platform_config.type error, got %v", errs)
+		}
+	})
+
+	t.Run("PlatformConfigUnsupportedOption", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["platform_config"] = map[string]interface{}{
+			"type":                        "AMD_VM",
+			"percentage_of_cores_enabled": 50,
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if !strings.Contains(errs.Error(), "is not supported by platform type AMD_VM") {
+			t.Fatalf("Expected unsupported option error, got %v", errs)
+		}
+	})
+
+	t.Run("ExportToObjectStorage", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["export_to_object_storage"] = map[string]interface{}{
+			"bucket": "images",
+		}
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if c.ExportToObjectStorage.ExportFormat != "OCI" {
+			t.Fatalf("Unexpected default export format %q", c.ExportToObjectStorage.ExportFormat)
+		}
+	})
+
+	t.Run("ExportToObjectStorageInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["export_to_object_storage"] = map[string]interface{}{
+			"export_format": "RAW",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		for _, expected := range []string{"'export_to_object_storage.bucket' must be specified", "'export_to_object_storage.export_format' must be one of"} {
+			if !strings.Contains(errs.Error(), expected) {
+				t.Errorf("Expected %q error, got %v", expected, errs)
+			}
+		}
+	})
+
+	t.Run("ImageDestinationCompartmentSameAsImageCompartment", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_compartment_ocid"] = "ocid1.compartment.sandbox"
+		raw["image_destination_compartment_ocid"] = "ocid1.compartment.sandbox"
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if c.ImageDestinationCompartmentID != "" {
+			t.Fatalf("Expected the destination compartment to be ignored, got %q", c.ImageDestinationCompartmentID)
+		}
+		if !strings.Contains(strings.Join(c.warnings, "\n"), "'image_destination_compartment_ocid' is ignored") {
+			t.Fatalf("Expected a warning, got %q", c.warnings)
+		}
+	})
+
+	t.Run("ImageCapabilitiesWithDedicatedOption", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_launch_mode"] = "NATIVE"
+		raw["image_capabilities"] = map[string]string{
+			"Compute.LaunchMode": "PARAVIRTUALIZED",
+			"Compute.SecureBoot": "true",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'image_capabilities' cannot set Compute.LaunchMode along with 'image_launch_mode'") {
+			t.Fatalf("Expected image_capabilities error, got %v", errs)
+		}
+	})
+
+	t.Run("IscsiMultipathWithImageCapabilities", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["iscsi_multipath_device_supported"] = true
+		raw["image_capabilities"] = map[string]string{
+			"Storage.Iscsi.MultipathDeviceSupported": "false",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "cannot set Storage.Iscsi.MultipathDeviceSupported along with 'iscsi_multipath_device_supported'") {
+			t.Fatalf("Expected image_capabilities error, got %v", errs)
+		}
+	})
+
+	t.Run("ParavirtualizationAttachmentVersionInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["paravirtualization_attachment_version"] = 3
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'paravirtualization_attachment_version' must be 1 or 2") {
+			t.Fatalf("Expected paravirtualization_attachment_version error, got %v", errs)
+		}
+	})
+
+	t.Run("ImageFirmware", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_firmware"] = "uefi_64"
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
 			t.Fatalf("Unexpected error in configuration %+v", errs)
 		}
+		if c.ImageFirmware != "UEFI_64" {
+			t.Fatalf("Expected image_firmware to be normalized, got %q", c.ImageFirmware)
+		}
+
+		raw["image_firmware"] = "EFI"
+		c = Config{}
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'image_firmware' must be one of BIOS or UEFI_64") {
+			t.Fatalf("Expected image_firmware error, got %v", errs)
+		}
 	})
 
-	t.Run("NicAttachmentType", func(t *testing.T) {
+	t.Run("RestartAfterImage", func(t *testing.T) {
 		raw := testConfig(cfgFile)
-		raw["nic_attachment_type"] = "VFIO"
+		raw["restart_after_image"] = true
+		raw["shutdown_before_image"] = true
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if c.RestartAfterImage {
+			t.Fatalf("Expected restart_after_image to be ignored without the stop instance_disposal")
+		}
+		if !strings.Contains(strings.Join(c.warnings, "\n"), "'restart_after_image' is ignored") {
+			t.Fatalf("Expected a warning, got %q", c.warnings)
+		}
+
+		raw["instance_disposal"] = "stop"
+		c = Config{}
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if !c.RestartAfterImage {
+			t.Fatalf("Expected restart_after_image to be set")
+		}
+
+		delete(raw, "instance_disposal")
+		raw["keep_instance_alive"] = true
+		c = Config{}
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if !c.RestartAfterImage {
+			t.Fatalf("Expected restart_after_image to be set with keep_instance_alive")
+		}
+	})
+
+	t.Run("ImageVersionSchemeInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_name"] = "golden-{{ .BuildCounter }}"
+		raw["image_version_scheme"] = "semver"
+		raw["image_version"] = "latest"
 
 		var c Config
 		errs := c.Prepare(raw)
-		if errs != nil {
+		for _, expected := range []string{"'image_version' must be a semantic version", "cannot be combined with {{ .BuildCounter }}"} {
+			if !strings.Contains(errs.Error(), expected) {
+				t.Errorf("Expected %q error, got %v", expected, errs)
+			}
+		}
+
+		raw = testConfig(cfgFile)
+		raw["image_version_scheme"] = "date"
+
+		c = Config{}
+		errs = c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'image_version_scheme' must be one of timestamp, counter or semver") {
+			t.Fatalf("Expected image_version_scheme error, got %v", errs)
+		}
+	})
+
+	t.Run("ImageRetentionInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_retention"] = map[string]interface{}{
+			"name_regex": "golden-(",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		for _, expected := range []string{"'image_retention.keep_releases' must be at least 1", "'image_retention.name_regex' is invalid"} {
+			if !strings.Contains(errs.Error(), expected) {
+				t.Errorf("Expected %q error, got %v", expected, errs)
+			}
+		}
+	})
+
+	t.Run("ManifestExportSHA256Invalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["manifest_export_sha256"] = true
+
+		var c Config
+		errs := c.Prepare(raw)
+		for _, expected := range []string{
+			"'manifest_export_sha256' requires 'manifest_path'",
+			"'manifest_export_sha256' requires 'export_to_object_storage'",
+		} {
+			if errs == nil || !strings.Contains(errs.Error(), expected) {
+				t.Errorf("Expected %q error, got %v", expected, errs)
+			}
+		}
+	})
+
+	t.Run("ImageCopyRegions", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["export_to_object_storage"] = map[string]interface{}{
+			"bucket": "images",
+		}
+		raw["image_copy_regions"] = []string{"us-ashburn-1", "eu-frankfurt-1"}
+		raw["image_copy_names"] = map[string]string{"eu-frankfurt-1": "golden-eu"}
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+	})
+
+	t.Run("ImageCopyRegionsInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_copy_regions"] = []string{"us-ashburn-1", "us-ashburn-1"}
+		raw["image_copy_names"] = map[string]string{"eu-frankfurt-1": "golden-eu"}
+
+		var c Config
+		errs := c.Prepare(raw)
+		for _, expected := range []string{
+			"'image_copy_regions' requires 'export_to_object_storage'",
+			"contains region us-ashburn-1 more than once",
+			"contains region eu-frankfurt-1, which is not in 'image_copy_regions'",
+		} {
+			if !strings.Contains(errs.Error(), expected) {
+				t.Errorf("Expected %q error, got %v", expected, errs)
+			}
+		}
+
+		raw = testConfig(cfgFile)
+		raw["export_to_object_storage"] = map[string]interface{}{
+			"bucket":        "images",
+			"export_format": "VHD",
+		}
+		raw["image_copy_regions"] = []string{"us-ashburn-1"}
+
+		c = Config{}
+		errs = c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "requires an 'export_to_object_storage.export_format' of OCI, QCOW2 or VMDK") {
+			t.Fatalf("Expected export format error, got %v", errs)
+		}
+	})
+
+	t.Run("ImageCopyCompartments", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["export_to_object_storage"] = map[string]interface{}{
+			"bucket": "images",
+		}
+		raw["image_copy_compartment_ocids"] = []string{"ocid1.compartment.test", "ocid1.compartment.prod"}
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+	})
+
+	t.Run("ImageCopyCompartmentsInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_compartment_ocid"] = "ocid1.compartment.dev"
+		raw["image_copy_compartment_ocids"] = []string{"ocid1.compartment.test", "ocid1.compartment.test", "ocid1.compartment.dev"}
+
+		var c Config
+		errs := c.Prepare(raw)
+		for _, expected := range []string{
+			"'image_copy_compartment_ocids' requires 'export_to_object_storage'",
+			"contains compartment ocid1.compartment.test more than once",
+			"contains compartment ocid1.compartment.dev of the image",
+		} {
+			if errs == nil || !strings.Contains(errs.Error(), expected) {
+				t.Errorf("Expected %q error, got %v", expected, errs)
+			}
+		}
+	})
+
+	t.Run("BaseImageImport", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "base_image_ocid")
+		raw["base_image_import"] = map[string]interface{}{
+			"source_uri":  "https://objectstorage.us-phoenix-1.oraclecloud.com/n/ns/b/disks/o/disk.VMDK",
+			"launch_mode": "paravirtualized",
+		}
+
+		var c Config
+		if errs := c.Prepare(raw); errs != nil {
 			t.Fatalf("Unexpected error in configuration %+v", errs)
 		}
+		if c.BaseImageImport.SourceImageType != "VMDK" || c.BaseImageImport.LaunchMode != "PARAVIRTUALIZED" {
+			t.Fatalf("Unexpected base image import %#v", c.BaseImageImport)
+		}
+	})
+
+	t.Run("BaseImageImportWithBaseImage", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["base_image_import"] = map[string]interface{}{
+			"source_image_type": "RAW",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		for _, expected := range []string{
+			"'base_image_import' cannot be combined",
+			"'base_image_import.source_uri' must be specified",
+			"'base_image_import.source_image_type' must be one of",
+		} {
+			if !strings.Contains(errs.Error(), expected) {
+				t.Errorf("Expected %q error, got %v", expected, errs)
+			}
+		}
 	})
 
 	t.Run("NoAccessConfig", func(t *testing.T) {
@@ -399,6 +1729,86 @@ func TestConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("launch_options", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["launch_options"] = map[string]interface{}{
+			"firmware":         "bios",
+			"boot_volume_type": "IDE",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration: %+v", errs)
+		}
+
+		if c.LaunchOptions.Firmware != "BIOS" {
+			t.Errorf("Expected firmware to be normalized to BIOS, got %s", c.LaunchOptions.Firmware)
+		}
+	})
+
+	t.Run("launch_options.invalid_firmware", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["launch_options"] = map[string]interface{}{
+			"firmware": "UEFI_32",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "launch_options.firmware") {
+			t.Fatalf("Expected error about launch_options.firmware, got %+v", errs)
+		}
+	})
+
+	t.Run("launch_options.pv_encryption_requires_paravirtualized", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["launch_options"] = map[string]interface{}{
+			"boot_volume_type":                    "ISCSI",
+			"is_pv_encryption_in_transit_enabled": true,
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "is_pv_encryption_in_transit_enabled") {
+			t.Fatalf("Expected error about is_pv_encryption_in_transit_enabled, got %+v", errs)
+		}
+	})
+
+	t.Run("agent_config", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["agent_config"] = map[string]interface{}{
+			"is_monitoring_disabled": true,
+			"plugins_config": []map[string]interface{}{
+				{"name": "Bastion", "desired_state": "enabled"},
+			},
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration: %+v", errs)
+		}
+
+		if c.AgentConfig.PluginsConfig[0].DesiredState != "ENABLED" {
+			t.Errorf("Expected desired_state to be normalized to ENABLED, got %s", c.AgentConfig.PluginsConfig[0].DesiredState)
+		}
+	})
+
+	t.Run("agent_config.invalid_desired_state", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["agent_config"] = map[string]interface{}{
+			"plugins_config": []map[string]interface{}{
+				{"name": "Bastion", "desired_state": "ON"},
+			},
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "desired_state") {
+			t.Fatalf("Expected error about desired_state, got %+v", errs)
+		}
+	})
+
 	t.Run("create_vnic_details.defined_tags_json", func(t *testing.T) {
 		createVNICDetails := map[string]interface{}{
 			"defined_tags_json": `{ "fo": { "o" : "bar" } }`,
@@ -5,18 +5,109 @@ package oci
 
 import (
 	"context"
+	"time"
 
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/loggingingestion"
 )
 
 // Driver interfaces between the builder steps and the OCI SDK.
 type Driver interface {
-	CreateInstance(ctx context.Context, publicKey string) (string, error)
-	CreateImage(ctx context.Context, id string) (core.Image, error)
+	AddSecurityListIngressRule(ctx context.Context, securityListId string, port int, description string) error
+	AssignPublicIP(ctx context.Context, publicIpId string, instanceId string) error
+	AttachVlanVnic(ctx context.Context, instanceId string) (string, error)
+	AttachVolume(ctx context.Context, instanceId string, volumeId string, attachmentType string) (string, error)
+	CreateInstance(ctx context.Context, publicKey string) (string, string, error)
+	CaptureConsoleHistory(ctx context.Context, instanceId string) (string, error)
+	AddImageShapeCompatibility(ctx context.Context, imageId string, shape string) error
+	ChangeImageCompartment(ctx context.Context, id string, compartmentId string) error
+	CloneBootVolume(ctx context.Context, source core.BootVolume) (string, error)
+	CopyImageToCompartment(ctx context.Context, sourceURI string, image core.Image, sourceImageType string) (string, error)
+	CopyImageToRegion(ctx context.Context, region string, sourceURI string, image core.Image, sourceImageType string) (string, error)
+	CreateBootVolumeBackup(ctx context.Context, bootVolumeId string) (string, error)
+	CreateConsoleConnection(ctx context.Context, instanceId string, publicKey string) (core.InstanceConsoleConnection, error)
+	CreateImage(ctx context.Context, id string) (core.Image, string, error)
+	CreateMarketplacePublication(ctx context.Context, image core.Image) (string, error)
+	CreateObjectReadURL(ctx context.Context, export ImageExportConfig, purpose string, expires time.Time) (string, string, error)
+	CreateLockObject(ctx context.Context, bucket string, name string, content string) (string, error)
+	CreateReservedPublicIP(ctx context.Context, instanceId string) (string, error)
+	CreateVolume(ctx context.Context, volume BlockVolumeConfig) (string, error)
+	DeleteBootVolume(ctx context.Context, id string) error
+	DeleteBootVolumeBackup(ctx context.Context, id string) error
+	DeleteConsoleConnection(ctx context.Context, id string) error
 	DeleteImage(ctx context.Context, id string) error
+	DeleteImageCapabilitySchemas(ctx context.Context, imageId string) error
+	DeleteImageInRegion(ctx context.Context, region string, id string) error
+	DeleteLockObject(ctx context.Context, bucket string, name string, etag string) error
+	DeleteMarketplacePublication(ctx context.Context, id string) error
+	DeleteObjectReadURL(ctx context.Context, export ImageExportConfig, id string) error
+	DeletePublicIP(ctx context.Context, id string) error
+	DeleteVolume(ctx context.Context, id string) error
+	DetachVolume(ctx context.Context, attachmentId string) error
+	Endpoints() []string
+	ExportImage(ctx context.Context, imageId string, export ImageExportConfig) (string, string, error)
+	GetAgentPluginStates(ctx context.Context, instanceId string) (map[string]string, error)
+	GetBootVolume(ctx context.Context, id string) (core.BootVolume, error)
+	GetBootVolumeID(ctx context.Context, instanceId string) (string, error)
+	GetBucketKmsKeyID(ctx context.Context, export ImageExportConfig) (string, error)
+	GetCompartmentState(ctx context.Context, id string) (string, error)
+	GetComputeAvailability(ctx context.Context, limitName string, availabilityDomain string) (limits.ResourceAvailability, error)
+	GetEndpointTime(ctx context.Context, endpoint string) (time.Time, error)
+	GetImage(ctx context.Context, id string) (core.Image, error)
+	GetImageState(ctx context.Context, id string) (string, error)
+	GetInstanceImage(ctx context.Context, id string) (core.Image, error)
+	GetInstanceInitialCredentials(ctx context.Context, id string) (string, string, error)
+	GetInstanceAddresses(ctx context.Context, id string) (string, string, error)
 	GetInstanceIP(ctx context.Context, id string) (string, error)
+	GetInstanceState(ctx context.Context, id string) (string, error)
+	GetLatestBuildCounter(ctx context.Context, series string) (int, error)
+	GetLockObject(ctx context.Context, bucket string, name string) (string, time.Time, error)
+	GetObjectSHA256(ctx context.Context, export ImageExportConfig) (string, error)
+	GetShape(ctx context.Context, name string, availabilityDomain string) (*core.Shape, error)
+	GetSubnetState(ctx context.Context, id string) (string, error)
+	GetVolumeAttachment(ctx context.Context, id string) (core.VolumeAttachment, error)
+	ImportImage(ctx context.Context, source ImageImportConfig) (string, string, error)
+	InstanceAction(ctx context.Context, id string, action string) error
+	ListCustomImages(ctx context.Context, compartmentId string) ([]core.Image, error)
+	PutLogs(ctx context.Context, logId string, subject string, entries []loggingingestion.LogEntry) error
+	RemoveSecurityListIngressRules(ctx context.Context, securityListId string, description string) error
+	ReplaceLockObject(ctx context.Context, bucket string, name string, content string, etag string) (string, error)
+	RunCommand(ctx context.Context, instanceId string, script string, timeout time.Duration) (string, error)
 	TerminateInstance(ctx context.Context, id string) error
-	WaitForImageCreation(ctx context.Context, id string) error
+	UnassignPublicIP(ctx context.Context, id string) error
+	UpdateBootVolumePerformance(ctx context.Context, id string, vpusPerGB int64) error
+	UpdateBootVolumeTags(ctx context.Context, id string) error
+	UpdateInstanceShapeConfig(ctx context.Context, id string, shapeConfig FlexShapeConfig) error
+	WaitForBootVolumeBackup(ctx context.Context, id string) error
+	WaitForBootVolumeState(ctx context.Context, id string, waitStates []string, terminalState string) error
+	WaitForImageCreation(ctx context.Context, id string, workRequestId string, progress func(percentComplete float32)) error
+	WaitForCompartmentImageCopy(ctx context.Context, id string) error
+	WaitForImageCopy(ctx context.Context, region string, id string) error
+	WaitForInstanceShapeConfig(ctx context.Context, id string, ocpus float32) error
 	WaitForInstanceState(ctx context.Context, id string, waitStates []string, terminalState string) error
+	WaitForMarketplacePublication(ctx context.Context, id string) error
+	WaitForPublicIPState(ctx context.Context, id string, waitStates []string, terminalState string) error
+	WaitForVolumeState(ctx context.Context, id string, waitStates []string, terminalState string) error
+	WaitForVolumeAttachmentState(ctx context.Context, id string, waitStates []string, terminalState string) error
+	WaitForWorkRequest(ctx context.Context, id string, progress func(percentComplete float32)) error
+	WaitForVnicAttachmentState(ctx context.Context, id string, waitStates []string, terminalState string) error
 	UpdateImageCapabilitySchema(ctx context.Context, imageId string) (core.UpdateComputeImageCapabilitySchemaResponse, error)
+	UpdateImageOperatingSystem(ctx context.Context, id string, operatingSystem string, operatingSystemVersion string) (core.Image, error)
+}
+
+// InstanceWaitStates returns the lifecycle states an instance may report on
+// its way to terminalState, to be passed to Driver.WaitForInstanceState along
+// with it.
+func InstanceWaitStates(terminalState string) []string {
+	switch terminalState {
+	case "RUNNING":
+		// A stopped instance may not have started starting yet
+		return []string{"PROVISIONING", "STARTING", "STOPPED", "MOVING"}
+	case "STOPPED":
+		return []string{"RUNNING", "STOPPING", "MOVING"}
+	case "TERMINATED":
+		return []string{"RUNNING", "STOPPING", "STOPPED", "TERMINATING"}
+	}
+	return nil
 }
@@ -5,55 +5,399 @@ package oci
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/loggingingestion"
 )
 
 // driverMock implements the Driver interface and communicates with Oracle
 // OCI.
 type driverMock struct {
+	AddSecurityListIngressRuleID  string
+	AddSecurityListIngressRuleErr error
+
+	AssignPublicIPID  string
+	AssignPublicIPErr error
+
+	AttachVlanVnicID  string
+	AttachVlanVnicErr error
+
+	AttachVolumeIDs   []string
+	AttachVolumeTypes []string
+	AttachVolumeErr   error
+
 	CreateInstanceID  string
 	CreateInstanceErr error
+	// CreateInstanceBaseImageID is the base image resolved when launching,
+	// defaulting to base_image_ocid.
+	CreateInstanceBaseImageID string
+	// CreateInstanceADErrs fails CreateInstance in the given availability
+	// domains.
+	CreateInstanceADErrs map[string]error
+	// CreateInstanceErrs fails the first calls to CreateInstance with the
+	// given errors, in order.
+	CreateInstanceErrs []error
+
+	CaptureConsoleHistoryInstanceID string
+	CaptureConsoleHistoryContent    string
+	CaptureConsoleHistoryErr        error
+
+	CreateConsoleConnectionID  string
+	CreateConsoleConnectionErr error
+
+	CreateImageID   string
+	CreateImageTags map[string]string
+	CreateImageErr  error
+
+	UpdateImageOperatingSystemID      string
+	UpdateImageOperatingSystemOS      string
+	UpdateImageOperatingSystemVersion string
+	UpdateImageOperatingSystemErr     error
+
+	AddImageShapeCompatibilityShapes []string
+	AddImageShapeCompatibilityErr    error
+
+	ChangeImageCompartmentID            string
+	ChangeImageCompartmentCompartmentID string
+	ChangeImageCompartmentErr           error
+
+	ImportImageSource ImageImportConfig
+	ImportImageErr    error
+
+	CreateObjectReadURLPurposes []string
+	CreateObjectReadURLExpires  time.Time
+	CreateObjectReadURLErr      error
+
+	DeleteObjectReadURLID  string
+	DeleteObjectReadURLErr error
+
+	CopyImageToRegionRegions []string
+	CopyImageToRegionURI     string
+	CopyImageToRegionErr     error
+
+	CopyImageToCompartmentCompartments []string
+	CopyImageToCompartmentURI          string
+	CopyImageToCompartmentErr          error
+
+	WaitForImageCopyErr error
+
+	ExportImageID     string
+	ExportImageExport ImageExportConfig
+	ExportImageErr    error
+
+	CreateLockObjectName string
+	CreateLockObjectErr  error
+
+	CreateReservedPublicIPID  string
+	CreateReservedPublicIPErr error
+
+	CreateMarketplacePublicationImageID string
+	CreateMarketplacePublicationErr     error
+
+	CreateVolumeIDs []string
+	CreateVolumeErr error
+
+	CloneBootVolumeSourceID string
+	CloneBootVolumeErr      error
+
+	CreateBootVolumeBackupVolumeID string
+	CreateBootVolumeBackupErr      error
+
+	DeleteLockObjectName string
+	DeleteLockObjectErr  error
 
-	CreateImageID  string
-	CreateImageErr error
+	GetLockObjectETag     string
+	GetLockObjectModified time.Time
+	GetLockObjectErr      error
+
+	ReplaceLockObjectETag string
+	ReplaceLockObjectErr  error
+
+	DeleteMarketplacePublicationID  string
+	DeleteMarketplacePublicationErr error
+
+	DeletePublicIPID  string
+	DeletePublicIPErr error
 
 	UpdateSchemaID  string
 	UpdateSchemaErr error
 
+	DeleteConsoleConnectionID  string
+	DeleteConsoleConnectionErr error
+
 	DeleteImageID  string
+	DeleteImageIDs []string
 	DeleteImageErr error
 
+	DeleteImageCapabilitySchemasImageID string
+	DeleteImageCapabilitySchemasErr     error
+
+	ListCustomImagesCompartmentID string
+	ListCustomImagesImages        []core.Image
+	ListCustomImagesErr           error
+
+	DeleteImageInRegionIDs []string
+	DeleteImageInRegionErr error
+
+	DeleteVolumeIDs []string
+	DeleteVolumeErr error
+
+	DeleteBootVolumeID  string
+	DeleteBootVolumeErr error
+
+	DeleteBootVolumeBackupID  string
+	DeleteBootVolumeBackupErr error
+
+	DetachVolumeIDs []string
+	DetachVolumeErr error
+
 	GetInstanceIPErr error
 
+	GetInstanceAddressesErr error
+
+	// GetAgentPluginStatesStates are returned by the calls to
+	// GetAgentPluginStates in order, the last one repeating.
+	GetAgentPluginStatesStates []map[string]string
+	GetAgentPluginStatesErr    error
+
+	GetBootVolumeIDErr error
+
+	GetBootVolumeState string
+	GetBootVolumeErr   error
+
+	GetBucketKmsKeyIDKey string
+	GetBucketKmsKeyIDErr error
+
+	GetCompartmentStateState string
+	GetCompartmentStateErr   error
+
+	GetEndpointTimeTime time.Time
+	GetEndpointTimeErr  error
+
+	GetImageErr error
+
+	GetImageStateState string
+	GetImageStateErr   error
+
+	GetObjectSHA256Object string
+	GetObjectSHA256Err    error
+
+	// GetShapeADs lists the availability domains offering the shape, all
+	// of them when nil.
+	GetShapeADs []string
+	GetShapeErr error
+
+	GetSubnetStateState string
+	GetSubnetStateErr   error
+
+	GetVolumeAttachmentErr error
+
+	GetComputeAvailabilityAvailability *limits.ResourceAvailability
+	GetComputeAvailabilityErr          error
+
+	GetInstanceImageTags map[string]string
+	GetInstanceImageErr  error
+
+	// GetInstanceInitialCredentialsErrs fails the first calls to
+	// GetInstanceInitialCredentials with the given errors, in order.
+	GetInstanceInitialCredentialsErrs  []error
+	GetInstanceInitialCredentialsCalls int
+
+	InstanceActionActions []string
+	InstanceActionErr     error
+
+	GetInstanceStateState string
+	GetInstanceStateErr   error
+
+	GetLatestBuildCounterCounter int
+	GetLatestBuildCounterErr     error
+
+	PutLogsEntries []loggingingestion.LogEntry
+	PutLogsErr     error
+
+	RemoveSecurityListIngressRulesID  string
+	RemoveSecurityListIngressRulesErr error
+
+	RunCommandScript string
+	RunCommandOutput string
+	RunCommandErr    error
+
 	TerminateInstanceID  string
 	TerminateInstanceErr error
 
-	WaitForImageCreationErr error
+	UnassignPublicIPID  string
+	UnassignPublicIPErr error
+
+	UpdateBootVolumePerformanceVpusPerGB int64
+	UpdateBootVolumePerformanceErr       error
+
+	UpdateBootVolumeTagsID  string
+	UpdateBootVolumeTagsErr error
+
+	UpdateInstanceShapeConfigOcpus float32
+	UpdateInstanceShapeConfigErr   error
+
+	WaitForImageCreationWorkRequestID string
+	WaitForImageCreationProgress      []float32
+	WaitForImageCreationErr           error
+
+	WaitForWorkRequestID       string
+	WaitForWorkRequestProgress []float32
+	WaitForWorkRequestErr      error
+
+	WaitForInstanceShapeConfigErr error
 
 	WaitForInstanceStateErr error
 
+	WaitForPublicIPStateErr error
+
+	WaitForVnicAttachmentStateErr error
+
+	WaitForVolumeStateErr error
+
+	WaitForMarketplacePublicationErr error
+
+	WaitForBootVolumeBackupErr error
+
+	WaitForBootVolumeStateErr error
+
+	WaitForVolumeAttachmentStateErr error
+
 	cfg *Config
 }
 
 // CreateInstance creates a new compute instance.
-func (d *driverMock) CreateInstance(ctx context.Context, publicKey string) (string, error) {
+func (d *driverMock) CreateInstance(ctx context.Context, publicKey string) (string, string, error) {
 	if d.CreateInstanceErr != nil {
-		return "", d.CreateInstanceErr
+		return "", "", d.CreateInstanceErr
+	}
+	if err := d.CreateInstanceADErrs[d.cfg.AvailabilityDomain]; err != nil {
+		return "", "", err
+	}
+	if len(d.CreateInstanceErrs) > 0 {
+		err := d.CreateInstanceErrs[0]
+		d.CreateInstanceErrs = d.CreateInstanceErrs[1:]
+		return "", "", err
 	}
 
 	d.CreateInstanceID = "ocid1..."
 
-	return d.CreateInstanceID, nil
+	// The base image is resolved when launching
+	baseImageID := d.cfg.BaseImageID
+	if d.CreateInstanceBaseImageID != "" {
+		baseImageID = d.CreateInstanceBaseImageID
+	}
+	if d.cfg.launchBootVolumeID != "" {
+		baseImageID = ""
+	}
+
+	return d.CreateInstanceID, baseImageID, nil
+}
+
+// AddSecurityListIngressRule mocks adding an ingress rule to a security list.
+func (d *driverMock) AddSecurityListIngressRule(ctx context.Context, securityListId string, port int, description string) error {
+	if d.AddSecurityListIngressRuleErr != nil {
+		return d.AddSecurityListIngressRuleErr
+	}
+
+	d.AddSecurityListIngressRuleID = securityListId
+
+	return nil
+}
+
+// AssignPublicIP mocks assigning a reserved public IP to an instance.
+func (d *driverMock) AssignPublicIP(ctx context.Context, publicIpId string, instanceId string) error {
+	if d.AssignPublicIPErr != nil {
+		return d.AssignPublicIPErr
+	}
+
+	d.AssignPublicIPID = publicIpId
+
+	return nil
+}
+
+// AttachVlanVnic mocks attaching a VLAN VNIC to an instance.
+func (d *driverMock) AttachVlanVnic(ctx context.Context, instanceId string) (string, error) {
+	if d.AttachVlanVnicErr != nil {
+		return "", d.AttachVlanVnicErr
+	}
+
+	d.AttachVlanVnicID = "ocid1.vnicattachment..."
+
+	return d.AttachVlanVnicID, nil
 }
 
 // CreateImage creates a new custom image.
-func (d *driverMock) CreateImage(ctx context.Context, id string) (core.Image, error) {
+func (d *driverMock) CreateImage(ctx context.Context, id string) (core.Image, string, error) {
 	if d.CreateImageErr != nil {
-		return core.Image{}, d.CreateImageErr
+		return core.Image{}, "", d.CreateImageErr
 	}
 	d.CreateImageID = id
-	return core.Image{Id: &id}, nil
+	d.CreateImageTags = d.cfg.imageTags()
+	return core.Image{Id: &id}, "ocid1.workrequest...", nil
+}
+
+// ChangeImageCompartment mocks moving a custom image to another compartment.
+func (d *driverMock) ChangeImageCompartment(ctx context.Context, id string, compartmentId string) error {
+	if d.ChangeImageCompartmentErr != nil {
+		return d.ChangeImageCompartmentErr
+	}
+	d.ChangeImageCompartmentID = id
+	d.ChangeImageCompartmentCompartmentID = compartmentId
+	return nil
+}
+
+// ImportImage mocks importing a custom image from Object Storage.
+func (d *driverMock) ImportImage(ctx context.Context, source ImageImportConfig) (string, string, error) {
+	if d.ImportImageErr != nil {
+		return "", "", d.ImportImageErr
+	}
+	d.ImportImageSource = source
+	return "ocid1.image.imported", "ocid1.workrequest.import", nil
+}
+
+// ExportImage mocks exporting a custom image to Object Storage.
+func (d *driverMock) ExportImage(ctx context.Context, imageId string, export ImageExportConfig) (string, string, error) {
+	if d.ExportImageErr != nil {
+		return "", "", d.ExportImageErr
+	}
+	d.ExportImageID = imageId
+	d.ExportImageExport = export
+
+	namespace := export.Namespace
+	if namespace == "" {
+		namespace = "tenancy-namespace"
+	}
+	uri := fmt.Sprintf("https://objectstorage.us-phoenix-1.oraclecloud.com/n/%s/b/%s/o/%s", namespace, export.Bucket, export.ObjectName)
+	return "ocid1.workrequest.export", uri, nil
+}
+
+// UpdateImageOperatingSystem mocks setting the operating system of a custom
+// image.
+func (d *driverMock) UpdateImageOperatingSystem(ctx context.Context, id string, operatingSystem string, operatingSystemVersion string) (core.Image, error) {
+	if d.UpdateImageOperatingSystemErr != nil {
+		return core.Image{}, d.UpdateImageOperatingSystemErr
+	}
+	d.UpdateImageOperatingSystemID = id
+	d.UpdateImageOperatingSystemOS = operatingSystem
+	d.UpdateImageOperatingSystemVersion = operatingSystemVersion
+	return core.Image{Id: &id, OperatingSystem: &operatingSystem, OperatingSystemVersion: &operatingSystemVersion}, nil
+}
+
+// AddImageShapeCompatibility mocks making a custom image compatible with a
+// shape.
+func (d *driverMock) AddImageShapeCompatibility(ctx context.Context, imageId string, shape string) error {
+	if d.AddImageShapeCompatibilityErr != nil {
+		return d.AddImageShapeCompatibilityErr
+	}
+
+	d.AddImageShapeCompatibilityShapes = append(d.AddImageShapeCompatibilityShapes, shape)
+
+	return nil
 }
 
 // CreateImage creates a new custom image.
@@ -65,6 +409,78 @@ func (d *driverMock) UpdateImageCapabilitySchema(ctx context.Context, imageId st
 	return core.UpdateComputeImageCapabilitySchemaResponse{}, nil
 }
 
+// CreateReservedPublicIP mocks creating a reserved public IP.
+func (d *driverMock) CreateReservedPublicIP(ctx context.Context, instanceId string) (string, error) {
+	if d.CreateReservedPublicIPErr != nil {
+		return "", d.CreateReservedPublicIPErr
+	}
+
+	d.CreateReservedPublicIPID = "ocid1.publicip..."
+
+	return d.CreateReservedPublicIPID, nil
+}
+
+// DeletePublicIP mocks deleting a reserved public IP.
+func (d *driverMock) DeletePublicIP(ctx context.Context, id string) error {
+	if d.DeletePublicIPErr != nil {
+		return d.DeletePublicIPErr
+	}
+
+	d.DeletePublicIPID = id
+
+	return nil
+}
+
+// CaptureConsoleHistory mocks capturing the console history of an instance.
+func (d *driverMock) CaptureConsoleHistory(ctx context.Context, instanceId string) (string, error) {
+	if d.CaptureConsoleHistoryErr != nil {
+		return "", d.CaptureConsoleHistoryErr
+	}
+
+	d.CaptureConsoleHistoryInstanceID = instanceId
+
+	return d.CaptureConsoleHistoryContent, nil
+}
+
+// CreateConsoleConnection mocks creating an instance console connection.
+func (d *driverMock) CreateConsoleConnection(ctx context.Context, instanceId string, publicKey string) (core.InstanceConsoleConnection, error) {
+	if d.CreateConsoleConnectionErr != nil {
+		return core.InstanceConsoleConnection{}, d.CreateConsoleConnectionErr
+	}
+
+	d.CreateConsoleConnectionID = "ocid1.instanceconsoleconnection..."
+	connection := "ssh -o ProxyCommand='ssh -W %h:%p -p 443 " + d.CreateConsoleConnectionID + "@instance-console.us-phoenix-1.oci.oraclecloud.com' " + instanceId
+	vnc := "ssh -o ProxyCommand='ssh -W %h:%p -p 443 " + d.CreateConsoleConnectionID + "@instance-console.us-phoenix-1.oci.oraclecloud.com' -N -L localhost:5900:" + instanceId + ":5900 " + instanceId
+
+	return core.InstanceConsoleConnection{
+		Id:                  &d.CreateConsoleConnectionID,
+		InstanceId:          &instanceId,
+		ConnectionString:    &connection,
+		VncConnectionString: &vnc,
+		LifecycleState:      core.InstanceConsoleConnectionLifecycleStateActive,
+	}, nil
+}
+
+// DeleteConsoleConnection mocks deleting an instance console connection.
+func (d *driverMock) DeleteConsoleConnection(ctx context.Context, id string) error {
+	if d.DeleteConsoleConnectionErr != nil {
+		return d.DeleteConsoleConnectionErr
+	}
+
+	d.DeleteConsoleConnectionID = id
+
+	return nil
+}
+
+// DeleteImageInRegion mocks deleting a custom image in another region.
+func (d *driverMock) DeleteImageInRegion(ctx context.Context, region string, id string) error {
+	if d.DeleteImageInRegionErr != nil {
+		return d.DeleteImageInRegionErr
+	}
+	d.DeleteImageInRegionIDs = append(d.DeleteImageInRegionIDs, id)
+	return nil
+}
+
 // DeleteImage mocks deleting a custom image.
 func (d *driverMock) DeleteImage(ctx context.Context, id string) error {
 	if d.DeleteImageErr != nil {
@@ -72,11 +488,34 @@ func (d *driverMock) DeleteImage(ctx context.Context, id string) error {
 	}
 
 	d.DeleteImageID = id
+	d.DeleteImageIDs = append(d.DeleteImageIDs, id)
+
+	return nil
+}
+
+// DeleteImageCapabilitySchemas mocks deleting the capability schemas of an
+// image.
+func (d *driverMock) DeleteImageCapabilitySchemas(ctx context.Context, imageId string) error {
+	if d.DeleteImageCapabilitySchemasErr != nil {
+		return d.DeleteImageCapabilitySchemasErr
+	}
+
+	d.DeleteImageCapabilitySchemasImageID = imageId
 
 	return nil
 }
 
-// GetInstanceIP returns the public or private IP corresponding to the given instance id.
+// ListCustomImages mocks listing the custom images of a compartment.
+func (d *driverMock) ListCustomImages(ctx context.Context, compartmentId string) ([]core.Image, error) {
+	if d.ListCustomImagesErr != nil {
+		return nil, d.ListCustomImagesErr
+	}
+	d.ListCustomImagesCompartmentID = compartmentId
+	return d.ListCustomImagesImages, nil
+}
+
+// GetInstanceIP returns the public, private or IPv6 address corresponding to
+// the given instance id.
 func (d *driverMock) GetInstanceIP(ctx context.Context, id string) (string, error) {
 	if d.GetInstanceIPErr != nil {
 		return "", d.GetInstanceIPErr
@@ -84,9 +523,287 @@ func (d *driverMock) GetInstanceIP(ctx context.Context, id string) (string, erro
 	if d.cfg.UsePrivateIP {
 		return "private_ip", nil
 	}
+	if d.cfg.UsePrivateFQDN {
+		return "host.subnet.vcn.oraclevcn.com", nil
+	}
+	if d.cfg.UseIPv6 {
+		return "2603:c020:4000:7e00::10", nil
+	}
 	return "ip", nil
 }
 
+// GetInstanceAddresses returns the private and public addresses of the given
+// instance.
+func (d *driverMock) GetInstanceAddresses(ctx context.Context, id string) (string, string, error) {
+	if d.GetInstanceAddressesErr != nil {
+		return "", "", d.GetInstanceAddressesErr
+	}
+	return "private_ip", "ip", nil
+}
+
+// Endpoints mocks listing the OCI endpoints in use.
+func (d *driverMock) Endpoints() []string {
+	return []string{
+		"https://iaas.us-phoenix-1.oraclecloud.com",
+		"https://marketplace.us-phoenix-1.oci.oraclecloud.com",
+	}
+}
+
+// GetEndpointTime mocks reading the time of an OCI endpoint.
+func (d *driverMock) GetEndpointTime(ctx context.Context, endpoint string) (time.Time, error) {
+	if d.GetEndpointTimeErr != nil {
+		return time.Time{}, d.GetEndpointTimeErr
+	}
+	if d.GetEndpointTimeTime.IsZero() {
+		return time.Now(), nil
+	}
+	return d.GetEndpointTimeTime, nil
+}
+
+// GetImage mocks getting a custom image.
+func (d *driverMock) GetImage(ctx context.Context, id string) (core.Image, error) {
+	if d.GetImageErr != nil {
+		return core.Image{}, d.GetImageErr
+	}
+	sizeInMBs := int64(47694)
+	return core.Image{
+		Id:          &id,
+		SizeInMBs:   &sizeInMBs,
+		TimeCreated: &common.SDKTime{Time: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+	}, nil
+}
+
+// GetImageState mocks getting the lifecycle state of an image.
+func (d *driverMock) GetImageState(ctx context.Context, id string) (string, error) {
+	if d.GetImageStateErr != nil {
+		return "", d.GetImageStateErr
+	}
+	if d.GetImageStateState == "" {
+		return "AVAILABLE", nil
+	}
+	return d.GetImageStateState, nil
+}
+
+// GetObjectSHA256 mocks computing the SHA256 digest of an exported image.
+func (d *driverMock) GetObjectSHA256(ctx context.Context, export ImageExportConfig) (string, error) {
+	if d.GetObjectSHA256Err != nil {
+		return "", d.GetObjectSHA256Err
+	}
+	d.GetObjectSHA256Object = export.ObjectName
+	return "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", nil
+}
+
+// GetShape mocks getting a shape offered in an availability domain.
+func (d *driverMock) GetShape(ctx context.Context, name string, availabilityDomain string) (*core.Shape, error) {
+	if d.GetShapeErr != nil {
+		return nil, d.GetShapeErr
+	}
+	if d.GetShapeADs != nil && !stringSliceContains(d.GetShapeADs, availabilityDomain) {
+		return nil, nil
+	}
+
+	gpus, description := 1, "NVIDIA® A10"
+	return &core.Shape{Shape: &name, Gpus: &gpus, GpuDescription: &description}, nil
+}
+
+// GetSubnetState mocks getting the lifecycle state of a subnet.
+func (d *driverMock) GetSubnetState(ctx context.Context, id string) (string, error) {
+	if d.GetSubnetStateErr != nil {
+		return "", d.GetSubnetStateErr
+	}
+	if d.GetSubnetStateState == "" {
+		return "AVAILABLE", nil
+	}
+	return d.GetSubnetStateState, nil
+}
+
+// GetVolumeAttachment mocks getting an iSCSI volume attachment.
+func (d *driverMock) GetVolumeAttachment(ctx context.Context, id string) (core.VolumeAttachment, error) {
+	if d.GetVolumeAttachmentErr != nil {
+		return nil, d.GetVolumeAttachmentErr
+	}
+
+	iqn := "iqn.2015-12.com.oracleiaas:" + id
+	ipv4 := "169.254.2.2"
+	port := 3260
+	return core.IScsiVolumeAttachment{Id: &id, Iqn: &iqn, Ipv4: &ipv4, Port: &port}, nil
+}
+
+// GetAgentPluginStates mocks getting the status of the agent plugins of an
+// instance.
+func (d *driverMock) GetAgentPluginStates(ctx context.Context, instanceId string) (map[string]string, error) {
+	if d.GetAgentPluginStatesErr != nil {
+		return nil, d.GetAgentPluginStatesErr
+	}
+	if len(d.GetAgentPluginStatesStates) == 0 {
+		return map[string]string{}, nil
+	}
+	states := d.GetAgentPluginStatesStates[0]
+	if len(d.GetAgentPluginStatesStates) > 1 {
+		d.GetAgentPluginStatesStates = d.GetAgentPluginStatesStates[1:]
+	}
+	return states, nil
+}
+
+// GetBootVolumeID mocks getting the boot volume of an instance.
+func (d *driverMock) GetBootVolumeID(ctx context.Context, instanceId string) (string, error) {
+	if d.GetBootVolumeIDErr != nil {
+		return "", d.GetBootVolumeIDErr
+	}
+	return "ocid1.bootvolume...", nil
+}
+
+// GetBucketKmsKeyID mocks getting the Vault key encrypting a bucket.
+func (d *driverMock) GetBucketKmsKeyID(ctx context.Context, export ImageExportConfig) (string, error) {
+	if d.GetBucketKmsKeyIDErr != nil {
+		return "", d.GetBucketKmsKeyIDErr
+	}
+	return d.GetBucketKmsKeyIDKey, nil
+}
+
+// GetCompartmentState mocks getting the lifecycle state of a compartment.
+func (d *driverMock) GetCompartmentState(ctx context.Context, id string) (string, error) {
+	if d.GetCompartmentStateErr != nil {
+		return "", d.GetCompartmentStateErr
+	}
+	if d.GetCompartmentStateState == "" {
+		return "ACTIVE", nil
+	}
+	return d.GetCompartmentStateState, nil
+}
+
+// GetComputeAvailability mocks getting the availability of a compute limit.
+func (d *driverMock) GetComputeAvailability(ctx context.Context, limitName string, availabilityDomain string) (limits.ResourceAvailability, error) {
+	if d.GetComputeAvailabilityErr != nil {
+		return limits.ResourceAvailability{}, d.GetComputeAvailabilityErr
+	}
+	if d.GetComputeAvailabilityAvailability == nil {
+		available := int64(100)
+		return limits.ResourceAvailability{Available: &available}, nil
+	}
+	return *d.GetComputeAvailabilityAvailability, nil
+}
+
+// GetInstanceImage mocks getting the image an instance was launched from.
+func (d *driverMock) GetInstanceImage(ctx context.Context, id string) (core.Image, error) {
+	if d.GetInstanceImageErr != nil {
+		return core.Image{}, d.GetInstanceImageErr
+	}
+	imageId := "ocid1.image.oc1..base"
+	return core.Image{Id: &imageId, FreeformTags: d.GetInstanceImageTags}, nil
+}
+
+// GetInstanceInitialCredentials mocks getting the initial credentials of a
+// Windows instance.
+func (d *driverMock) GetInstanceInitialCredentials(ctx context.Context, id string) (string, string, error) {
+	d.GetInstanceInitialCredentialsCalls++
+	if len(d.GetInstanceInitialCredentialsErrs) > 0 {
+		err := d.GetInstanceInitialCredentialsErrs[0]
+		d.GetInstanceInitialCredentialsErrs = d.GetInstanceInitialCredentialsErrs[1:]
+		return "", "", err
+	}
+	return "opc", "initial-password", nil
+}
+
+// InstanceAction mocks performing an action on an instance.
+func (d *driverMock) InstanceAction(ctx context.Context, id string, action string) error {
+	if d.InstanceActionErr != nil {
+		return d.InstanceActionErr
+	}
+	d.InstanceActionActions = append(d.InstanceActionActions, action)
+	return nil
+}
+
+// GetInstanceState mocks getting the lifecycle state of an instance.
+func (d *driverMock) GetInstanceState(ctx context.Context, id string) (string, error) {
+	if d.GetInstanceStateErr != nil {
+		return "", d.GetInstanceStateErr
+	}
+	if d.GetInstanceStateState == "" {
+		return "RUNNING", nil
+	}
+	return d.GetInstanceStateState, nil
+}
+
+// GetLatestBuildCounter mocks looking up the latest build counter.
+func (d *driverMock) GetLatestBuildCounter(ctx context.Context, series string) (int, error) {
+	if d.GetLatestBuildCounterErr != nil {
+		return 0, d.GetLatestBuildCounterErr
+	}
+	return d.GetLatestBuildCounterCounter, nil
+}
+
+// CreateLockObject mocks creating a lock object.
+func (d *driverMock) CreateLockObject(ctx context.Context, bucket string, name string, content string) (string, error) {
+	if d.CreateLockObjectErr != nil {
+		return "", d.CreateLockObjectErr
+	}
+
+	d.CreateLockObjectName = name
+
+	return "etag", nil
+}
+
+// DeleteLockObject mocks deleting a lock object.
+func (d *driverMock) DeleteLockObject(ctx context.Context, bucket string, name string, etag string) error {
+	if d.DeleteLockObjectErr != nil {
+		return d.DeleteLockObjectErr
+	}
+
+	d.DeleteLockObjectName = name
+
+	return nil
+}
+
+// GetLockObject mocks looking up a lock object.
+func (d *driverMock) GetLockObject(ctx context.Context, bucket string, name string) (string, time.Time, error) {
+	if d.GetLockObjectErr != nil {
+		return "", time.Time{}, d.GetLockObjectErr
+	}
+
+	return d.GetLockObjectETag, d.GetLockObjectModified, nil
+}
+
+// ReplaceLockObject mocks overwriting a lock object with the given ETag.
+func (d *driverMock) ReplaceLockObject(ctx context.Context, bucket string, name string, content string, etag string) (string, error) {
+	if d.ReplaceLockObjectErr != nil {
+		return "", d.ReplaceLockObjectErr
+	}
+
+	d.ReplaceLockObjectETag = etag
+
+	return "new-etag", nil
+}
+
+// PutLogs mocks sending log entries to a custom log.
+func (d *driverMock) PutLogs(ctx context.Context, logId string, subject string, entries []loggingingestion.LogEntry) error {
+	if d.PutLogsErr != nil {
+		return d.PutLogsErr
+	}
+
+	d.PutLogsEntries = append(d.PutLogsEntries, entries...)
+
+	return nil
+}
+
+// RemoveSecurityListIngressRules mocks removing ingress rules from a security
+// list.
+func (d *driverMock) RemoveSecurityListIngressRules(ctx context.Context, securityListId string, description string) error {
+	if d.RemoveSecurityListIngressRulesErr != nil {
+		return d.RemoveSecurityListIngressRulesErr
+	}
+
+	d.RemoveSecurityListIngressRulesID = securityListId
+
+	return nil
+}
+
+// RunCommand mocks running a script through the Run Command plugin.
+func (d *driverMock) RunCommand(ctx context.Context, instanceId string, script string, timeout time.Duration) (string, error) {
+	d.RunCommandScript = script
+	return d.RunCommandOutput, d.RunCommandErr
+}
+
 // TerminateInstance terminates a compute instance.
 func (d *driverMock) TerminateInstance(ctx context.Context, id string) error {
 	if d.TerminateInstanceErr != nil {
@@ -98,14 +815,301 @@ func (d *driverMock) TerminateInstance(ctx context.Context, id string) error {
 	return nil
 }
 
+// UnassignPublicIP mocks unassigning a reserved public IP.
+func (d *driverMock) UnassignPublicIP(ctx context.Context, id string) error {
+	if d.UnassignPublicIPErr != nil {
+		return d.UnassignPublicIPErr
+	}
+
+	d.UnassignPublicIPID = id
+
+	return nil
+}
+
+// UpdateBootVolumeTags mocks tagging a boot volume.
+func (d *driverMock) UpdateBootVolumeTags(ctx context.Context, id string) error {
+	if d.UpdateBootVolumeTagsErr != nil {
+		return d.UpdateBootVolumeTagsErr
+	}
+
+	d.UpdateBootVolumeTagsID = id
+
+	return nil
+}
+
+// UpdateBootVolumePerformance mocks changing the performance of a boot
+// volume.
+func (d *driverMock) UpdateBootVolumePerformance(ctx context.Context, id string, vpusPerGB int64) error {
+	if d.UpdateBootVolumePerformanceErr != nil {
+		return d.UpdateBootVolumePerformanceErr
+	}
+
+	d.UpdateBootVolumePerformanceVpusPerGB = vpusPerGB
+
+	return nil
+}
+
+// UpdateInstanceShapeConfig mocks resizing an instance.
+func (d *driverMock) UpdateInstanceShapeConfig(ctx context.Context, id string, shapeConfig FlexShapeConfig) error {
+	if d.UpdateInstanceShapeConfigErr != nil {
+		return d.UpdateInstanceShapeConfigErr
+	}
+	d.UpdateInstanceShapeConfigOcpus = *shapeConfig.Ocpus
+	return nil
+}
+
 // WaitForImageCreation waits for a provisioning custom image to reach the
 // "AVAILABLE" state.
-func (d *driverMock) WaitForImageCreation(ctx context.Context, id string) error {
+func (d *driverMock) WaitForImageCreation(ctx context.Context, id string, workRequestId string, progress func(percentComplete float32)) error {
+	d.WaitForImageCreationWorkRequestID = workRequestId
+	for _, percentComplete := range d.WaitForImageCreationProgress {
+		progress(percentComplete)
+	}
 	return d.WaitForImageCreationErr
 }
 
+// CreateObjectReadURL mocks creating a pre-authenticated request to read an
+// object.
+func (d *driverMock) CreateObjectReadURL(ctx context.Context, export ImageExportConfig, purpose string, expires time.Time) (string, string, error) {
+	if d.CreateObjectReadURLErr != nil {
+		return "", "", d.CreateObjectReadURLErr
+	}
+	d.CreateObjectReadURLPurposes = append(d.CreateObjectReadURLPurposes, purpose)
+	d.CreateObjectReadURLExpires = expires
+	return "ocid1.par", "https://objectstorage.us-phoenix-1.oraclecloud.com/p/token/n/ns/b/" + export.Bucket + "/o/" + export.ObjectName, nil
+}
+
+// DeleteObjectReadURL mocks deleting a pre-authenticated request.
+func (d *driverMock) DeleteObjectReadURL(ctx context.Context, export ImageExportConfig, id string) error {
+	if d.DeleteObjectReadURLErr != nil {
+		return d.DeleteObjectReadURLErr
+	}
+	d.DeleteObjectReadURLID = id
+	return nil
+}
+
+// CopyImageToRegion mocks importing a custom image in another region.
+func (d *driverMock) CopyImageToRegion(ctx context.Context, region string, sourceURI string, image core.Image, sourceImageType string) (string, error) {
+	if d.CopyImageToRegionErr != nil {
+		return "", d.CopyImageToRegionErr
+	}
+	d.CopyImageToRegionURI = sourceURI
+	d.CopyImageToRegionRegions = append(d.CopyImageToRegionRegions, region)
+	return "ocid1.image.oc1." + region, nil
+}
+
+// CopyImageToCompartment mocks importing a custom image in another
+// compartment.
+func (d *driverMock) CopyImageToCompartment(ctx context.Context, sourceURI string, image core.Image, sourceImageType string) (string, error) {
+	if d.CopyImageToCompartmentErr != nil {
+		return "", d.CopyImageToCompartmentErr
+	}
+	d.CopyImageToCompartmentURI = sourceURI
+	d.CopyImageToCompartmentCompartments = append(d.CopyImageToCompartmentCompartments, *image.CompartmentId)
+	return "ocid1.image.oc1.copy." + *image.CompartmentId, nil
+}
+
+// WaitForImageCopy mocks waiting for a custom image imported in another
+// region to become available.
+func (d *driverMock) WaitForImageCopy(ctx context.Context, region string, id string) error {
+	return d.WaitForImageCopyErr
+}
+
+// WaitForCompartmentImageCopy mocks waiting for a custom image imported in
+// another compartment to become available.
+func (d *driverMock) WaitForCompartmentImageCopy(ctx context.Context, id string) error {
+	return d.WaitForImageCopyErr
+}
+
+// WaitForWorkRequest mocks waiting for a work request to succeed.
+func (d *driverMock) WaitForWorkRequest(ctx context.Context, id string, progress func(percentComplete float32)) error {
+	d.WaitForWorkRequestID = id
+	for _, percentComplete := range d.WaitForWorkRequestProgress {
+		progress(percentComplete)
+	}
+	return d.WaitForWorkRequestErr
+}
+
+// WaitForInstanceShapeConfig waits for a resized instance to be running.
+func (d *driverMock) WaitForInstanceShapeConfig(ctx context.Context, id string, ocpus float32) error {
+	return d.WaitForInstanceShapeConfigErr
+}
+
 // WaitForInstanceState waits for an instance to reach the a given terminal
 // state.
 func (d *driverMock) WaitForInstanceState(ctx context.Context, id string, waitStates []string, terminalState string) error {
 	return d.WaitForInstanceStateErr
 }
+
+// WaitForPublicIPState waits for a public IP to reach a given terminal state.
+func (d *driverMock) WaitForPublicIPState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+	return d.WaitForPublicIPStateErr
+}
+
+// WaitForVnicAttachmentState waits for a VNIC attachment to reach a given
+// terminal state.
+func (d *driverMock) WaitForVnicAttachmentState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+	return d.WaitForVnicAttachmentStateErr
+}
+
+// CreateVolume mocks creating a block volume.
+func (d *driverMock) CreateVolume(ctx context.Context, volume BlockVolumeConfig) (string, error) {
+	if d.CreateVolumeErr != nil {
+		return "", d.CreateVolumeErr
+	}
+
+	id := fmt.Sprintf("ocid1.volume.%d", len(d.CreateVolumeIDs))
+	d.CreateVolumeIDs = append(d.CreateVolumeIDs, id)
+
+	return id, nil
+}
+
+// AttachVolume mocks attaching a block volume to an instance.
+func (d *driverMock) AttachVolume(ctx context.Context, instanceId string, volumeId string, attachmentType string) (string, error) {
+	if d.AttachVolumeErr != nil {
+		return "", d.AttachVolumeErr
+	}
+
+	id := fmt.Sprintf("ocid1.volumeattachment.%d", len(d.AttachVolumeIDs))
+	d.AttachVolumeIDs = append(d.AttachVolumeIDs, id)
+	d.AttachVolumeTypes = append(d.AttachVolumeTypes, attachmentType)
+
+	return id, nil
+}
+
+// DetachVolume mocks detaching a block volume.
+func (d *driverMock) DetachVolume(ctx context.Context, attachmentId string) error {
+	if d.DetachVolumeErr != nil {
+		return d.DetachVolumeErr
+	}
+
+	d.DetachVolumeIDs = append(d.DetachVolumeIDs, attachmentId)
+
+	return nil
+}
+
+// DeleteVolume mocks deleting a block volume.
+func (d *driverMock) DeleteVolume(ctx context.Context, id string) error {
+	if d.DeleteVolumeErr != nil {
+		return d.DeleteVolumeErr
+	}
+
+	d.DeleteVolumeIDs = append(d.DeleteVolumeIDs, id)
+
+	return nil
+}
+
+// CreateMarketplacePublication mocks publishing an image to the Marketplace.
+func (d *driverMock) CreateMarketplacePublication(ctx context.Context, image core.Image) (string, error) {
+	if d.CreateMarketplacePublicationErr != nil {
+		return "", d.CreateMarketplacePublicationErr
+	}
+
+	d.CreateMarketplacePublicationImageID = *image.Id
+
+	return "ocid1.marketplacepublication", nil
+}
+
+// DeleteMarketplacePublication mocks deleting a Marketplace publication.
+func (d *driverMock) DeleteMarketplacePublication(ctx context.Context, id string) error {
+	if d.DeleteMarketplacePublicationErr != nil {
+		return d.DeleteMarketplacePublicationErr
+	}
+
+	d.DeleteMarketplacePublicationID = id
+
+	return nil
+}
+
+// WaitForMarketplacePublication mocks waiting for a Marketplace publication
+// to become active.
+func (d *driverMock) WaitForMarketplacePublication(ctx context.Context, id string) error {
+	return d.WaitForMarketplacePublicationErr
+}
+
+// CloneBootVolume mocks cloning a boot volume.
+func (d *driverMock) CloneBootVolume(ctx context.Context, source core.BootVolume) (string, error) {
+	if d.CloneBootVolumeErr != nil {
+		return "", d.CloneBootVolumeErr
+	}
+
+	d.CloneBootVolumeSourceID = *source.Id
+
+	return "ocid1.bootvolume.clone", nil
+}
+
+// DeleteBootVolume mocks deleting a boot volume.
+func (d *driverMock) DeleteBootVolume(ctx context.Context, id string) error {
+	if d.DeleteBootVolumeErr != nil {
+		return d.DeleteBootVolumeErr
+	}
+
+	d.DeleteBootVolumeID = id
+
+	return nil
+}
+
+// CreateBootVolumeBackup mocks backing up a boot volume.
+func (d *driverMock) CreateBootVolumeBackup(ctx context.Context, bootVolumeId string) (string, error) {
+	if d.CreateBootVolumeBackupErr != nil {
+		return "", d.CreateBootVolumeBackupErr
+	}
+
+	d.CreateBootVolumeBackupVolumeID = bootVolumeId
+
+	return "ocid1.bootvolumebackup", nil
+}
+
+// DeleteBootVolumeBackup mocks deleting a boot volume backup.
+func (d *driverMock) DeleteBootVolumeBackup(ctx context.Context, id string) error {
+	if d.DeleteBootVolumeBackupErr != nil {
+		return d.DeleteBootVolumeBackupErr
+	}
+
+	d.DeleteBootVolumeBackupID = id
+
+	return nil
+}
+
+// GetBootVolume mocks getting a boot volume.
+func (d *driverMock) GetBootVolume(ctx context.Context, id string) (core.BootVolume, error) {
+	if d.GetBootVolumeErr != nil {
+		return core.BootVolume{}, d.GetBootVolumeErr
+	}
+
+	state := d.GetBootVolumeState
+	if state == "" {
+		state = "AVAILABLE"
+	}
+	availabilityDomain := "aaaa:PHX-AD-2"
+	name := "last-known-good"
+	return core.BootVolume{
+		Id:                 &id,
+		AvailabilityDomain: &availabilityDomain,
+		DisplayName:        &name,
+		LifecycleState:     core.BootVolumeLifecycleStateEnum(state),
+	}, nil
+}
+
+// WaitForBootVolumeBackup mocks waiting for a boot volume backup to become
+// available.
+func (d *driverMock) WaitForBootVolumeBackup(ctx context.Context, id string) error {
+	return d.WaitForBootVolumeBackupErr
+}
+
+// WaitForBootVolumeState mocks waiting for a boot volume to reach a given
+// state.
+func (d *driverMock) WaitForBootVolumeState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+	return d.WaitForBootVolumeStateErr
+}
+
+// WaitForVolumeState mocks waiting for a block volume to reach a given state.
+func (d *driverMock) WaitForVolumeState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+	return d.WaitForVolumeStateErr
+}
+
+// WaitForVolumeAttachmentState mocks waiting for a volume attachment to reach
+// a given state.
+func (d *driverMock) WaitForVolumeAttachmentState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+	return d.WaitForVolumeAttachmentStateErr
+}
@@ -5,26 +5,51 @@ package oci
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"log"
 	"math"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/packer-plugin-sdk/uuid"
 	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/computeinstanceagent"
 	core "github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/loggingingestion"
+	"github.com/oracle/oci-go-sdk/v65/marketplace"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/workrequests"
 )
 
 // driverOCI implements the Driver interface and communicates with Oracle
 // OCI.
 type driverOCI struct {
-	computeClient core.ComputeClient
-	vcnClient     core.VirtualNetworkClient
-	cfg           *Config
+	computeClient  core.ComputeClient
+	vcnClient      core.VirtualNetworkClient
+	blockClient    core.BlockstorageClient
+	loggingClient  loggingingestion.LoggingClient
+	objectClient   objectstorage.ObjectStorageClient
+	downloadClient objectstorage.ObjectStorageClient
+	identityClient identity.IdentityClient
+	limitsClient   limits.LimitsClient
+	agentClient    computeinstanceagent.ComputeInstanceAgentClient
+	pluginClient   computeinstanceagent.PluginClient
+	requestClient  workrequests.WorkRequestClient
+	marketClient   marketplace.MarketplaceClient
+	cfg            *Config
 }
 
 var retryPolicy = &common.RetryPolicy{
@@ -64,15 +89,107 @@ func NewDriverOCI(cfg *Config) (Driver, error) {
 		return nil, err
 	}
 
-	return &driverOCI{
-		computeClient: coreClient,
-		vcnClient:     vcnClient,
-		cfg:           cfg,
-	}, nil
+	blockClient, err := core.NewBlockstorageClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	loggingClient, err := loggingingestion.NewLoggingClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	objectClient, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	identityClient, err := identity.NewIdentityClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	limitsClient, err := limits.NewLimitsClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	agentClient, err := computeinstanceagent.NewComputeInstanceAgentClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	pluginClient, err := computeinstanceagent.NewPluginClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	requestClient, err := workrequests.NewWorkRequestClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	marketClient, err := marketplace.NewMarketplaceClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	// All clients share a pooled HTTP client so connections are reused
+	// across services and across builds running in the same process.
+	httpClient := sharedHTTPClient(cfg.HTTPClient, cfg.FIPSMode)
+	coreClient.HTTPClient = httpClient
+	vcnClient.HTTPClient = httpClient
+	blockClient.HTTPClient = httpClient
+	loggingClient.HTTPClient = httpClient
+	objectClient.HTTPClient = httpClient
+	identityClient.HTTPClient = httpClient
+	limitsClient.HTTPClient = httpClient
+	agentClient.HTTPClient = httpClient
+	pluginClient.HTTPClient = httpClient
+	requestClient.HTTPClient = httpClient
+	marketClient.HTTPClient = httpClient
+
+	// Whole objects take longer than request_timeout to be read
+	downloadClient := objectClient
+	downloadClient.HTTPClient = downloadHTTPClient(cfg.HTTPClient, cfg.FIPSMode)
+
+	d := &driverOCI{
+		computeClient:  coreClient,
+		vcnClient:      vcnClient,
+		blockClient:    blockClient,
+		loggingClient:  loggingClient,
+		objectClient:   objectClient,
+		downloadClient: downloadClient,
+		identityClient: identityClient,
+		limitsClient:   limitsClient,
+		agentClient:    agentClient,
+		pluginClient:   pluginClient,
+		requestClient:  requestClient,
+		marketClient:   marketClient,
+		cfg:            cfg,
+	}
+
+	if cfg.FIPSMode {
+		region, err := cfg.configProvider.Region()
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyFIPSEndpoints(region, d.Endpoints()...); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
 }
 
-// CreateInstance creates a new compute instance.
-func (d *driverOCI) CreateInstance(ctx context.Context, publicKey string) (string, error) {
+// CreateInstance creates a new compute instance. It returns the OCID of the
+// instance and the OCID of the base image it was launched from, as resolved
+// from base_image_ocid, base_image or base_image_filter, which is empty when
+// it was launched from a boot volume.
+func (d *driverOCI) CreateInstance(ctx context.Context, publicKey string) (string, string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
 	metadata := map[string]string{
 		"ssh_authorized_keys": publicKey,
 	}
@@ -94,13 +211,29 @@ func (d *driverOCI) CreateInstance(ctx context.Context, publicKey string) (strin
 		PrivateIp:           d.cfg.CreateVnicDetails.PrivateIp,
 		SkipSourceDestCheck: d.cfg.CreateVnicDetails.SkipSourceDestCheck,
 		SubnetId:            d.cfg.CreateVnicDetails.SubnetId,
-		DefinedTags:         d.cfg.CreateVnicDetails.DefinedTags,
-		FreeformTags:        d.cfg.CreateVnicDetails.FreeformTags,
+		DefinedTags:         mergeDefinedTags(d.cfg.InstanceDefinedTags, d.cfg.CreateVnicDetails.DefinedTags),
+		FreeformTags:        mergeFreeformTags(d.cfg.InstanceTags, d.cfg.CreateVnicDetails.FreeformTags),
+	}
+
+	// Without a subnet the primary VNIC is created in the VLAN
+	if CreateVnicDetails.SubnetId == nil && d.cfg.VlanID != "" {
+		CreateVnicDetails.VlanId = &d.cfg.VlanID
+	}
+
+	if len(d.cfg.CreateVnicDetails.NsgNames) > 0 {
+		nsgIds, err := d.resolveNsgNames(ctx, CreateVnicDetails.SubnetId, CreateVnicDetails.VlanId, d.cfg.CreateVnicDetails.NsgNames)
+		if err != nil {
+			return "", "", err
+		}
+		CreateVnicDetails.NsgIds = append(CreateVnicDetails.NsgIds, nsgIds...)
 	}
 
 	// Determine base image ID
-	var imageId *string
-	if d.cfg.BaseImageID != "" {
+	var imageId, imageName *string
+	if d.cfg.launchBootVolumeID != "" {
+		// The instance is launched from a boot volume rather than an image
+		imageName = &d.cfg.sourceBootVolumeName
+	} else if d.cfg.BaseImageID != "" {
 		imageId = &d.cfg.BaseImageID
 	} else {
 		request := core.ListImagesRequest{
@@ -114,51 +247,178 @@ func (d *driverOCI) CreateInstance(ctx context.Context, publicKey string) (strin
 			SortOrder:              "DESC",
 			RequestMetadata:        requestMetadata,
 			Page:                   common.String(""),
+			Limit:                  d.cfg.BaseImageFilter.PageSize,
+		}
+
+		var imageNameRegex *regexp.Regexp
+		if d.cfg.BaseImageFilter.DisplayNameSearch != nil {
+			var err error
+			imageNameRegex, err = regexp.Compile(*d.cfg.BaseImageFilter.DisplayNameSearch)
+			if err != nil {
+				return "", "", err
+			}
+		}
+
+		var excludeRegex *regexp.Regexp
+		if d.cfg.BaseImageFilter.DisplayNameExclude != nil {
+			var err error
+			excludeRegex, err = regexp.Compile(*d.cfg.BaseImageFilter.DisplayNameExclude)
+			if err != nil {
+				return "", "", err
+			}
+		}
+
+		var versionRegex *regexp.Regexp
+		if d.cfg.BaseImageFilter.VersionRegex != nil {
+			var err error
+			versionRegex, err = regexp.Compile(*d.cfg.BaseImageFilter.VersionRegex)
+			if err != nil {
+				return "", "", err
+			}
 		}
 
-		for request.Page != nil && imageId == nil {
+		maxResults := 0
+		if d.cfg.BaseImageFilter.MaxResults != nil {
+			maxResults = *d.cfg.BaseImageFilter.MaxResults
+		}
+
+		var matched core.Image
+		var matchedVersion *version.Version
+		scanned := 0
+	pages:
+		for request.Page != nil {
 			// Pull images and determine which image ID to use, if BaseImageId not specified
 			response, err := d.computeClient.ListImages(ctx, request)
 			if err != nil {
-				return "", err
+				err = newRequestError("ListImages", request.CompartmentId, err)
+				if *request.CompartmentId != d.cfg.CompartmentID {
+					return "", "", fmt.Errorf("%w%s", err, crossCompartmentHint(err))
+				}
+				return "", "", err
 			}
 
-			if len(response.Items) == 0 && response.OpcNextPage == nil {
-				return "", errors.New("base_image_filter returned no images")
+			if len(response.Items) == 0 && response.OpcNextPage == nil && scanned == 0 {
+				return "", "", errors.New("base_image_filter returned no images")
 			}
 
-			if d.cfg.BaseImageFilter.DisplayNameSearch != nil {
-				// Return most recent image that matches regex
-				imageNameRegex, err := regexp.Compile(*d.cfg.BaseImageFilter.DisplayNameSearch)
-				if err != nil {
-					return "", err
+			for _, image := range response.Items {
+				if maxResults > 0 && scanned >= maxResults {
+					break pages
 				}
-				for _, image := range response.Items {
-					if imageNameRegex.MatchString(*image.DisplayName) {
-						imageId = image.Id
-						break
-					}
+				scanned++
+
+				// A platform image alias does not resolve to custom images
+				// of the same name
+				if d.cfg.BaseImage != "" && image.CompartmentId != nil {
+					continue
 				}
 
-				if imageId == nil && response.OpcNextPage == nil {
-					return "", errors.New("no image matched display_name_search criteria")
+				if excludeRegex != nil && excludeRegex.MatchString(*image.DisplayName) {
+					continue
 				}
-			} else {
-				// If no regex provided, simply return most recent image pulled
-				if len(response.Items) > 0 {
-					imageId = response.Items[0].Id
+
+				// If no regex provided, simply return most recent image pulled,
+				// otherwise return the most recent image that matches the regex
+				if (imageNameRegex == nil || imageNameRegex.MatchString(*image.DisplayName)) && imageHasTags(image, d.cfg.BaseImageFilter) {
+					if versionRegex == nil {
+						imageId = image.Id
+						imageName = image.DisplayName
+						matched = image
+						break pages
+					}
+
+					// Every image is scanned for the highest version, the
+					// most recent one winning ties
+					v, ok := displayNameVersion(versionRegex, *image.DisplayName)
+					if ok && (matchedVersion == nil || v.GreaterThan(matchedVersion)) {
+						imageId = image.Id
+						imageName = image.DisplayName
+						matched = image
+						matchedVersion = v
+					}
 				}
 			}
 
 			request.Page = response.OpcNextPage
 		}
+
+		log.Printf("[INFO] base_image_filter scanned %d image(s)", scanned)
+
+		if imageId == nil {
+			if d.cfg.BaseImage != "" {
+				return "", "", fmt.Errorf("no platform image found for base_image %q and shape %s", d.cfg.BaseImage, *request.Shape)
+			}
+			criteria := "display_name_search"
+			if versionRegex != nil {
+				criteria = "version_regex"
+			} else if imageNameRegex == nil {
+				criteria = "tags"
+			} else if len(d.cfg.BaseImageFilter.FreeformTags) > 0 || len(d.cfg.BaseImageFilter.DefinedTags) > 0 {
+				criteria = "display_name_search and tags"
+			}
+			if maxResults > 0 && scanned >= maxResults {
+				return "", "", fmt.Errorf("no image matched %s criteria within the first %d images", criteria, maxResults)
+			}
+			return "", "", fmt.Errorf("no image matched %s criteria", criteria)
+		}
+
+		if matchedVersion != nil {
+			log.Printf("[INFO] base_image_filter selected version %s", matchedVersion)
+		}
+
+		// Images are listed newest first, so no other matching image is
+		// recent enough either, unless selected by version
+		if !d.cfg.baseImageCreatedAfter.IsZero() && matched.TimeCreated != nil && matched.TimeCreated.Before(d.cfg.baseImageCreatedAfter) {
+			return "", "", fmt.Errorf("the newest image matching base_image_filter, %s (%s), was created at %s, before created_after %s",
+				*matched.DisplayName, *matched.Id, matched.TimeCreated.Format(time.RFC3339), d.cfg.baseImageCreatedAfter.Format(time.RFC3339))
+		}
+	}
+
+	// A launch with an image not supporting the shape fails with a less
+	// clear error
+	if imageId != nil {
+		if err := d.checkImageShapeCompatibility(ctx, *imageId); err != nil {
+			return "", "", err
+		}
+	}
+
+	displayName := d.cfg.InstanceName
+	if d.cfg.usesSourceImageName() {
+		if imageName == nil {
+			image, err := d.computeClient.GetImage(ctx, core.GetImageRequest{
+				ImageId:         imageId,
+				RequestMetadata: requestMetadata,
+			})
+			if err != nil {
+				return "", "", newRequestError("GetImage", imageId, err)
+			}
+			imageName = image.DisplayName
+		}
+		name, err := d.cfg.renderInstanceName(*imageName)
+		if err != nil {
+			return "", "", fmt.Errorf("unable to render instance name: %s", err)
+		}
+		// Resources created later on are named after the instance
+		displayName = &name
+		d.cfg.InstanceName = displayName
 	}
 
 	// Create Source details which will be used to Launch Instance
-	InstanceSourceDetails := core.InstanceSourceViaImageDetails{ImageId: imageId}
+	var InstanceSourceDetails core.InstanceSourceDetails
+	if d.cfg.launchBootVolumeID != "" {
+		// A clone was already resized and encrypted when it was created
+		InstanceSourceDetails = core.InstanceSourceViaBootVolumeDetails{BootVolumeId: &d.cfg.launchBootVolumeID}
+	} else {
+		imageSourceDetails := core.InstanceSourceViaImageDetails{ImageId: imageId}
 
-	if d.cfg.BootVolumeSizeInGBs != 0 {
-		InstanceSourceDetails.BootVolumeSizeInGBs = &d.cfg.BootVolumeSizeInGBs
+		if d.cfg.BootVolumeSizeInGBs != 0 {
+			imageSourceDetails.BootVolumeSizeInGBs = &d.cfg.BootVolumeSizeInGBs
+		}
+
+		if d.cfg.BootVolumeKmsKeyID != "" {
+			imageSourceDetails.KmsKeyId = &d.cfg.BootVolumeKmsKeyID
+		}
+		InstanceSourceDetails = imageSourceDetails
 	}
 
 	// Build instance details
@@ -167,17 +427,73 @@ func (d *driverOCI) CreateInstance(ctx context.Context, publicKey string) (strin
 		CompartmentId:      &d.cfg.CompartmentID,
 		CreateVnicDetails:  &CreateVnicDetails,
 		DefinedTags:        d.cfg.InstanceDefinedTags,
-		DisplayName:        d.cfg.InstanceName,
+		DisplayName:        displayName,
 		FreeformTags:       d.cfg.InstanceTags,
 		Shape:              &d.cfg.Shape,
 		SourceDetails:      InstanceSourceDetails,
 		Metadata:           metadata,
 	}
 
+	if d.cfg.CapacityReservationID != "" {
+		instanceDetails.CapacityReservationId = &d.cfg.CapacityReservationID
+	}
+
+	if d.cfg.FaultDomain != "" {
+		instanceDetails.FaultDomain = &d.cfg.FaultDomain
+	}
+
+	if d.cfg.IsPreemptible {
+		instanceDetails.PreemptibleInstanceConfig = &core.PreemptibleInstanceConfigDetails{
+			PreemptionAction: core.TerminatePreemptionAction{
+				PreserveBootVolume: d.cfg.PreemptibleInstanceConfig.PreserveBootVolume,
+			},
+		}
+	}
+
 	if d.cfg.InstanceOptions.AreLegacyImdsEndpointsDisabled != nil {
 		instanceDetails.InstanceOptions = &core.InstanceOptions{AreLegacyImdsEndpointsDisabled: d.cfg.InstanceOptions.AreLegacyImdsEndpointsDisabled}
 	}
 
+	agentConfig := d.cfg.AgentConfig
+	if agentConfig.IsMonitoringDisabled != nil || agentConfig.IsManagementDisabled != nil ||
+		agentConfig.AreAllPluginsDisabled != nil || len(agentConfig.PluginsConfig) > 0 {
+		instanceDetails.AgentConfig = &core.LaunchInstanceAgentConfigDetails{
+			IsMonitoringDisabled:  agentConfig.IsMonitoringDisabled,
+			IsManagementDisabled:  agentConfig.IsManagementDisabled,
+			AreAllPluginsDisabled: agentConfig.AreAllPluginsDisabled,
+		}
+		for _, plugin := range agentConfig.PluginsConfig {
+			instanceDetails.AgentConfig.PluginsConfig = append(instanceDetails.AgentConfig.PluginsConfig, core.InstanceAgentPluginConfigDetails{
+				Name:         common.String(plugin.Name),
+				DesiredState: core.InstanceAgentPluginConfigDetailsDesiredStateEnum(plugin.DesiredState),
+			})
+		}
+	}
+
+	// In-transit encryption is deprecated in the launch options, it is set
+	// on the launch details instead
+	instanceDetails.IsPvEncryptionInTransitEnabled = d.cfg.LaunchOptions.IsPvEncryptionInTransitEnabled
+
+	launchOptions := d.cfg.LaunchOptions
+	launchOptions.IsPvEncryptionInTransitEnabled = nil
+	if launchOptions != (LaunchOptionsConfig{}) {
+		instanceDetails.LaunchOptions = &core.LaunchOptions{
+			BootVolumeType:                  core.LaunchOptionsBootVolumeTypeEnum(launchOptions.BootVolumeType),
+			Firmware:                        core.LaunchOptionsFirmwareEnum(launchOptions.Firmware),
+			NetworkType:                     core.LaunchOptionsNetworkTypeEnum(launchOptions.NetworkType),
+			RemoteDataVolumeType:            core.LaunchOptionsRemoteDataVolumeTypeEnum(launchOptions.RemoteDataVolumeType),
+			IsConsistentVolumeNamingEnabled: launchOptions.IsConsistentVolumeNamingEnabled,
+		}
+	}
+
+	if d.cfg.PlatformConfig != (PlatformConfig{}) {
+		platformConfig, err := launchPlatformConfig(d.cfg.PlatformConfig)
+		if err != nil {
+			return "", "", err
+		}
+		instanceDetails.PlatformConfig = platformConfig
+	}
+
 	if d.cfg.ShapeConfig.Ocpus != nil {
 		LaunchInstanceShapeConfigDetails := core.LaunchInstanceShapeConfigDetails{
 			Ocpus:       d.cfg.ShapeConfig.Ocpus,
@@ -191,251 +507,2431 @@ func (d *driverOCI) CreateInstance(ctx context.Context, publicKey string) (strin
 		instanceDetails.ShapeConfig = &LaunchInstanceShapeConfigDetails
 	}
 
-	instance, err := d.computeClient.LaunchInstance(context.TODO(), core.LaunchInstanceRequest{
+	instance, err := d.computeClient.LaunchInstance(ctx, core.LaunchInstanceRequest{
 		LaunchInstanceDetails: instanceDetails,
 		RequestMetadata:       requestMetadata,
 	})
 
 	if err != nil {
-		return "", err
+		return "", "", newRequestError("LaunchInstance", &d.cfg.CompartmentID, err)
 	}
 
-	return *instance.Id, nil
+	var baseImageID string
+	if imageId != nil {
+		baseImageID = *imageId
+	}
+	return *instance.Id, baseImageID, nil
 }
 
-// CreateImage creates a new custom image.
-func (d *driverOCI) CreateImage(ctx context.Context, id string) (core.Image, error) {
-	res, err := d.computeClient.CreateImage(ctx, core.CreateImageRequest{CreateImageDetails: core.CreateImageDetails{
-		CompartmentId: &d.cfg.ImageCompartmentID,
-		InstanceId:    &id,
-		DisplayName:   &d.cfg.ImageName,
-		FreeformTags:  d.cfg.Tags,
-		DefinedTags:   d.cfg.DefinedTags,
-		LaunchMode:    core.CreateImageDetailsLaunchModeEnum(d.cfg.LaunchMode),
-	},
-		RequestMetadata: requestMetadata,
-	})
+// resolveNsgNames returns the OCIDs of the network security groups with the
+// given display names in the VCN of the given subnet or VLAN.
+func (d *driverOCI) resolveNsgNames(ctx context.Context, subnetId *string, vlanId *string, names []string) ([]string, error) {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
 
-	if err != nil {
-		return core.Image{}, err
+	var vcnId *string
+	if subnetId != nil {
+		subnet, err := d.vcnClient.GetSubnet(ctx, core.GetSubnetRequest{
+			SubnetId:        subnetId,
+			RequestMetadata: requestMetadata,
+		})
+		if err != nil {
+			return nil, newRequestError("GetSubnet", subnetId, err)
+		}
+		vcnId = subnet.VcnId
+	} else {
+		vlan, err := d.vcnClient.GetVlan(ctx, core.GetVlanRequest{
+			VlanId:          vlanId,
+			RequestMetadata: requestMetadata,
+		})
+		if err != nil {
+			return nil, newRequestError("GetVlan", vlanId, err)
+		}
+		vcnId = vlan.VcnId
 	}
 
-	return res.Image, nil
-}
-
-// UpdateImageCapabilitySchema creates a new custom image.
-func (d *driverOCI) UpdateImageCapabilitySchema(ctx context.Context, imageId string) (core.UpdateComputeImageCapabilitySchemaResponse, error) {
-
-	// get the schema associated with the newly created image
-	schema, err := d.computeClient.ListComputeImageCapabilitySchemas(context.Background(), core.ListComputeImageCapabilitySchemasRequest{
-		ImageId: &imageId,
+	// Network security groups usually live in the compartment of their VCN
+	vcn, err := d.vcnClient.GetVcn(ctx, core.GetVcnRequest{
+		VcnId:           vcnId,
+		RequestMetadata: requestMetadata,
 	})
 	if err != nil {
-		return core.UpdateComputeImageCapabilitySchemaResponse{}, err
+		return nil, newRequestError("GetVcn", vcnId, err)
 	}
-	// no schema found, need to download the global image schema, use it as a base to create an image schema for this image
-	// and create the schema
-	if len(schema.Items) < 1 {
-		// get the global schema list
-		globalSchemaList, err := d.computeClient.ListComputeGlobalImageCapabilitySchemas(context.Background(), core.ListComputeGlobalImageCapabilitySchemasRequest{})
-		if err != nil {
-			return core.UpdateComputeImageCapabilitySchemaResponse{}, err
-		}
-		if len(globalSchemaList.Items) < 1 {
-			return core.UpdateComputeImageCapabilitySchemaResponse{}, errors.New("unable to find any global schemas")
-		}
 
-		// get the global schema based on ocid and latest version guid
-		var globalSchemaId = globalSchemaList.Items[0].Id
-		var globalSchemaCurrentVersion = globalSchemaList.Items[0].CurrentVersionName
-		globalSchema, err := d.computeClient.GetComputeGlobalImageCapabilitySchemaVersion(context.Background(),
-			core.GetComputeGlobalImageCapabilitySchemaVersionRequest{ComputeGlobalImageCapabilitySchemaId: globalSchemaId,
-				ComputeGlobalImageCapabilitySchemaVersionName: globalSchemaCurrentVersion})
-		if err != nil {
-			return core.UpdateComputeImageCapabilitySchemaResponse{}, err
+	nsgIds := make([]string, 0, len(names))
+	for _, name := range names {
+		request := core.ListNetworkSecurityGroupsRequest{
+			CompartmentId:   vcn.CompartmentId,
+			VcnId:           vcnId,
+			DisplayName:     common.String(name),
+			LifecycleState:  core.NetworkSecurityGroupLifecycleStateAvailable,
+			RequestMetadata: requestMetadata,
 		}
-
-		// update schema data to replace all instances of "source": "GLOBAL" with "source": "IMAGE"
-		newSchemaData := make(map[string]core.ImageCapabilitySchemaDescriptor)
-		for key := range globalSchema.SchemaData {
-			val := globalSchema.SchemaData[key]
-			if boolVal, ok := val.(core.BooleanImageCapabilitySchemaDescriptor); ok {
-				newSchemaData[key] = core.BooleanImageCapabilitySchemaDescriptor{Source: "IMAGE", DefaultValue: boolVal.DefaultValue}
+		var matches []string
+		for {
+			res, err := d.vcnClient.ListNetworkSecurityGroups(ctx, request)
+			if err != nil {
+				return nil, newRequestError("ListNetworkSecurityGroups", vcnId, err)
 			}
-			if enumIntVal, ok := val.(core.EnumIntegerImageCapabilityDescriptor); ok {
-				destArr := make([]int, len(enumIntVal.Values))
-				copy(destArr, enumIntVal.Values)
-				newVal := core.EnumIntegerImageCapabilityDescriptor{Source: "IMAGE", DefaultValue: enumIntVal.DefaultValue, Values: destArr}
-				newSchemaData[key] = newVal
+			for _, nsg := range res.Items {
+				matches = append(matches, *nsg.Id)
 			}
-			if enumStringVal, ok := val.(core.EnumStringImageCapabilitySchemaDescriptor); ok {
-				destArr := make([]string, len(enumStringVal.Values))
-				copy(destArr, enumStringVal.Values)
-				newVal := core.EnumStringImageCapabilitySchemaDescriptor{Source: "IMAGE", DefaultValue: enumStringVal.DefaultValue, Values: destArr}
-				newSchemaData[key] = newVal
+			if res.OpcNextPage == nil {
+				break
 			}
+			request.Page = res.OpcNextPage
 		}
 
-		// create a new schema for the image by duplication the global schema
-		req := core.CreateComputeImageCapabilitySchemaRequest{CreateComputeImageCapabilitySchemaDetails: core.CreateComputeImageCapabilitySchemaDetails{
-			DisplayName:   common.String(fmt.Sprintf("Default Image Capability Schema for %s", d.cfg.ImageName)),
-			ImageId:       &imageId,
-			SchemaData:    newSchemaData,
-			CompartmentId: &d.cfg.ImageCompartmentID,
-			ComputeGlobalImageCapabilitySchemaVersionName: globalSchema.ComputeGlobalImageCapabilitySchemaVersion.Name,
-		},
-			OpcRetryToken: common.String(uuid.TimeOrderedUUID()),
-		}
-		_, err = d.computeClient.CreateComputeImageCapabilitySchema(context.Background(), req)
-		if err != nil {
-			return core.UpdateComputeImageCapabilitySchemaResponse{}, err
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("no network security group named %q found in VCN %s", name, *vcnId)
+		case 1:
+			nsgIds = append(nsgIds, matches[0])
+		default:
+			return nil, fmt.Errorf("network security group name %q is ambiguous in VCN %s, matching %v", name, *vcnId, matches)
 		}
+	}
 
-		// try to get the schema again, now it should be good
-		schema, err = d.computeClient.ListComputeImageCapabilitySchemas(context.Background(),
-			core.ListComputeImageCapabilitySchemasRequest{
-				ImageId: &imageId,
-			})
+	return nsgIds, nil
+}
+
+// checkImageShapeCompatibility returns an error when an image does not
+// support the shape of the instance, such as an x86 image and an Ampere A1
+// shape. Listing the compatible shapes needs permissions the build does not
+// otherwise need, so the check is skipped when it fails.
+func (d *driverOCI) checkImageShapeCompatibility(ctx context.Context, imageId string) error {
+	var shapes []string
+	request := core.ListImageShapeCompatibilityEntriesRequest{
+		ImageId:         &imageId,
+		RequestMetadata: requestMetadata,
+	}
+	for {
+		res, err := d.computeClient.ListImageShapeCompatibilityEntries(ctx, request)
 		if err != nil {
-			return core.UpdateComputeImageCapabilitySchemaResponse{}, err
+			log.Printf("[WARN] Skipping image shape compatibility check: %s", newRequestError("ListImageShapeCompatibilityEntries", &imageId, err))
+			return nil
 		}
+		for _, entry := range res.Items {
+			if *entry.Shape == d.cfg.Shape {
+				return nil
+			}
+			shapes = append(shapes, *entry.Shape)
+		}
+		if res.OpcNextPage == nil {
+			break
+		}
+		request.Page = res.OpcNextPage
 	}
-
-	// update the schema to add the new custom fields
-	if d.cfg.LaunchMode != "" {
-		schema.Items[0].SchemaData["Compute.LaunchMode"] = core.EnumStringImageCapabilitySchemaDescriptor{Values: []string{"NATIVE", "EMULATED", "PARAVIRTUALIZED", "CUSTOM"}, DefaultValue: &d.cfg.LaunchMode, Source: "IMAGE"}
-	}
-	if d.cfg.NicAttachmentType != "" {
-		schema.Items[0].SchemaData["Network.AttachmentType"] = core.EnumStringImageCapabilitySchemaDescriptor{Values: []string{"E1000", "VFIO", "PARAVIRTUALIZED"}, DefaultValue: &d.cfg.NicAttachmentType, Source: "IMAGE"}
+	if len(shapes) == 0 {
+		return nil
 	}
 
-	// update the new fields to the schema definition
-	resp, err := d.computeClient.UpdateComputeImageCapabilitySchema(ctx,
-		core.UpdateComputeImageCapabilitySchemaRequest{ComputeImageCapabilitySchemaId: schema.Items[0].Id,
-			UpdateComputeImageCapabilitySchemaDetails: core.UpdateComputeImageCapabilitySchemaDetails{SchemaData: schema.Items[0].SchemaData,
-				FreeformTags: d.cfg.Tags,
-				DefinedTags:  d.cfg.DefinedTags,
-			}})
+	return fmt.Errorf("base image %s is not compatible with shape %s, it supports: %s", imageId, d.cfg.Shape, strings.Join(shapes, ", "))
+}
+
+// AttachVlanVnic attaches a secondary VNIC in the configured VLAN to the
+// instance and returns the OCID of the VNIC attachment.
+func (d *driverOCI) AttachVlanVnic(ctx context.Context, instanceId string) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
 
+	res, err := d.computeClient.AttachVnic(ctx, core.AttachVnicRequest{
+		AttachVnicDetails: core.AttachVnicDetails{
+			InstanceId: &instanceId,
+			CreateVnicDetails: &core.CreateVnicDetails{
+				VlanId:       &d.cfg.VlanID,
+				FreeformTags: d.cfg.InstanceTags,
+				DefinedTags:  d.cfg.InstanceDefinedTags,
+			},
+		},
+		RequestMetadata: requestMetadata,
+	})
 	if err != nil {
-		return resp, err
+		return "", newRequestError("AttachVnic", &instanceId, err)
 	}
 
-	return resp, nil
+	return *res.Id, nil
 }
 
-// DeleteImage deletes a custom image.
-func (d *driverOCI) DeleteImage(ctx context.Context, id string) error {
-	_, err := d.computeClient.DeleteImage(ctx, core.DeleteImageRequest{
-		ImageId:         &id,
-		RequestMetadata: requestMetadata,
-	})
-	return err
-}
+// CreateVolume creates a new block volume in the availability domain of the
+// instance and returns its OCID.
+func (d *driverOCI) CreateVolume(ctx context.Context, volume BlockVolumeConfig) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
 
-// GetInstanceIP returns the public or private IP corresponding to the given instance id.
-func (d *driverOCI) GetInstanceIP(ctx context.Context, id string) (string, error) {
-	vnics, err := d.computeClient.ListVnicAttachments(ctx, core.ListVnicAttachmentsRequest{
-		InstanceId:      &id,
-		CompartmentId:   &d.cfg.CompartmentID,
-		RequestMetadata: requestMetadata,
+	details := core.CreateVolumeDetails{
+		AvailabilityDomain: &d.cfg.AvailabilityDomain,
+		CompartmentId:      &d.cfg.CompartmentID,
+		DisplayName:        volume.DisplayName,
+		SizeInGBs:          &volume.SizeInGBs,
+		VpusPerGB:          volume.VpusPerGB,
+		FreeformTags:       d.cfg.InstanceTags,
+		DefinedTags:        d.cfg.InstanceDefinedTags,
+	}
+	if volume.KmsKeyID != "" {
+		details.KmsKeyId = &volume.KmsKeyID
+	}
+
+	res, err := d.blockClient.CreateVolume(ctx, core.CreateVolumeRequest{
+		CreateVolumeDetails: details,
+		RequestMetadata:     requestMetadata,
 	})
 	if err != nil {
-		return "", err
+		return "", newRequestError("CreateVolume", &d.cfg.CompartmentID, err)
 	}
 
-	if len(vnics.Items) == 0 {
-		return "", errors.New("instance has zero VNICs")
+	return *res.Id, nil
+}
+
+// AttachVolume attaches a block volume to an instance as a paravirtualized
+// or iSCSI volume and returns the OCID of the volume attachment.
+func (d *driverOCI) AttachVolume(ctx context.Context, instanceId string, volumeId string, attachmentType string) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	var details core.AttachVolumeDetails = core.AttachParavirtualizedVolumeDetails{
+		InstanceId:                     &instanceId,
+		VolumeId:                       &volumeId,
+		IsPvEncryptionInTransitEnabled: d.cfg.LaunchOptions.IsPvEncryptionInTransitEnabled,
+	}
+	if attachmentType == volumeAttachmentTypeISCSI {
+		details = core.AttachIScsiVolumeDetails{
+			InstanceId: &instanceId,
+			VolumeId:   &volumeId,
+		}
 	}
 
-	vnic, err := d.vcnClient.GetVnic(ctx, core.GetVnicRequest{
-		VnicId:          vnics.Items[0].VnicId,
-		RequestMetadata: requestMetadata,
+	res, err := d.computeClient.AttachVolume(ctx, core.AttachVolumeRequest{
+		AttachVolumeDetails: details,
+		RequestMetadata:     requestMetadata,
 	})
 	if err != nil {
-		return "", fmt.Errorf("error getting VNIC details: %s", err)
+		return "", newRequestError("AttachVolume", &volumeId, err)
 	}
 
-	if d.cfg.UsePrivateIP {
-		return *vnic.PrivateIp, nil
-	}
+	return *res.VolumeAttachment.GetId(), nil
+}
+
+// DetachVolume detaches a block volume from its instance.
+func (d *driverOCI) DetachVolume(ctx context.Context, attachmentId string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.computeClient.DetachVolume(ctx, core.DetachVolumeRequest{
+		VolumeAttachmentId: &attachmentId,
+		RequestMetadata:    requestMetadata,
+	})
+	return newRequestError("DetachVolume", &attachmentId, err)
+}
+
+// DeleteVolume deletes a block volume.
+func (d *driverOCI) DeleteVolume(ctx context.Context, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.blockClient.DeleteVolume(ctx, core.DeleteVolumeRequest{
+		VolumeId:        &id,
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("DeleteVolume", &id, err)
+}
+
+// ImportImage imports a custom image from an Object Storage URL. It returns
+// the OCID of the image and of the import work request.
+func (d *driverOCI) ImportImage(ctx context.Context, source ImageImportConfig) (string, string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	displayName := fmt.Sprintf("packer-%s-import", d.cfg.PackerBuildName)
+	details := core.ImageSourceViaObjectStorageUriDetails{
+		SourceUri:       &source.SourceURI,
+		SourceImageType: core.ImageSourceDetailsSourceImageTypeEnum(source.SourceImageType),
+	}
+	if source.OperatingSystem != "" {
+		details.OperatingSystem = &source.OperatingSystem
+	}
+	if source.OperatingSystemVersion != "" {
+		details.OperatingSystemVersion = &source.OperatingSystemVersion
+	}
+
+	res, err := d.computeClient.CreateImage(ctx, core.CreateImageRequest{
+		CreateImageDetails: core.CreateImageDetails{
+			CompartmentId:      &d.cfg.CompartmentID,
+			DisplayName:        &displayName,
+			ImageSourceDetails: details,
+			LaunchMode:         core.CreateImageDetailsLaunchModeEnum(source.LaunchMode),
+			FreeformTags:       d.cfg.InstanceTags,
+			DefinedTags:        d.cfg.InstanceDefinedTags,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", "", newRequestError("CreateImage", &d.cfg.CompartmentID, err)
+	}
+
+	return *res.Id, *res.OpcWorkRequestId, nil
+}
+
+// ExportImage exports a custom image to Object Storage. It returns the OCID of
+// the export work request and the URI of the exported object.
+func (d *driverOCI) ExportImage(ctx context.Context, imageId string, export ImageExportConfig) (string, string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	namespace, err := d.namespace(ctx, export.Namespace)
+	if err != nil {
+		return "", "", err
+	}
+
+	res, err := d.computeClient.ExportImage(ctx, core.ExportImageRequest{
+		ImageId: &imageId,
+		ExportImageDetails: core.ExportImageViaObjectStorageTupleDetails{
+			NamespaceName: &namespace,
+			BucketName:    &export.Bucket,
+			ObjectName:    &export.ObjectName,
+			ExportFormat:  core.ExportImageDetailsExportFormatEnum(export.ExportFormat),
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", "", newRequestError("ExportImage", &imageId, err)
+	}
+
+	uri := fmt.Sprintf("%s/n/%s/b/%s/o/%s", d.objectClient.Endpoint(),
+		url.PathEscape(namespace), url.PathEscape(export.Bucket), url.PathEscape(export.ObjectName))
+	return *res.OpcWorkRequestId, uri, nil
+}
+
+// CreateObjectReadURL creates a pre-authenticated request to read an exported
+// image until expires, named after the build and its purpose. It returns the
+// OCID of the request and the URL of the object.
+func (d *driverOCI) CreateObjectReadURL(ctx context.Context, export ImageExportConfig, purpose string, expires time.Time) (string, string, error) {
+	ctx, cancel := d.objectStorageContext(ctx)
+	defer cancel()
+
+	namespace, err := d.namespace(ctx, export.Namespace)
+	if err != nil {
+		return "", "", err
+	}
+
+	name := fmt.Sprintf("packer-%s-%s", d.cfg.PackerBuildName, purpose)
+	res, err := d.objectClient.CreatePreauthenticatedRequest(ctx, objectstorage.CreatePreauthenticatedRequestRequest{
+		NamespaceName: &namespace,
+		BucketName:    &export.Bucket,
+		CreatePreauthenticatedRequestDetails: objectstorage.CreatePreauthenticatedRequestDetails{
+			Name:        &name,
+			ObjectName:  &export.ObjectName,
+			AccessType:  objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeObjectread,
+			TimeExpires: &common.SDKTime{Time: expires},
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", "", newRequestError("CreatePreauthenticatedRequest", &export.Bucket, err)
+	}
+
+	return *res.Id, d.objectClient.Endpoint() + *res.AccessUri, nil
+}
+
+// DeleteObjectReadURL deletes a pre-authenticated request created by
+// CreateObjectReadURL.
+func (d *driverOCI) DeleteObjectReadURL(ctx context.Context, export ImageExportConfig, id string) error {
+	ctx, cancel := d.objectStorageContext(ctx)
+	defer cancel()
+
+	namespace, err := d.namespace(ctx, export.Namespace)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.objectClient.DeletePreauthenticatedRequest(ctx, objectstorage.DeletePreauthenticatedRequestRequest{
+		NamespaceName:   &namespace,
+		BucketName:      &export.Bucket,
+		ParId:           &id,
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("DeletePreauthenticatedRequest", &export.Bucket, err)
+}
+
+// CopyImageToRegion imports a custom image in another region from the URL of
+// its export, keeping its name, compartment, launch mode and tags. It returns
+// the OCID of the copy.
+func (d *driverOCI) CopyImageToRegion(ctx context.Context, region string, sourceURI string, image core.Image, sourceImageType string) (string, error) {
+	client, err := d.regionComputeClient(region)
+	if err != nil {
+		return "", err
+	}
+	return d.importImageCopy(ctx, client, sourceURI, image, sourceImageType)
+}
+
+// CopyImageToCompartment imports a custom image in the compartment of image,
+// in the region of the build, from the URL of its export. It returns the OCID
+// of the copy.
+func (d *driverOCI) CopyImageToCompartment(ctx context.Context, sourceURI string, image core.Image, sourceImageType string) (string, error) {
+	return d.importImageCopy(ctx, d.computeClient, sourceURI, image, sourceImageType)
+}
+
+// importImageCopy imports a copy of a custom image through client from the
+// URL of its export, keeping its name, compartment, launch mode and tags.
+func (d *driverOCI) importImageCopy(ctx context.Context, client core.ComputeClient, sourceURI string, image core.Image, sourceImageType string) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	res, err := client.CreateImage(ctx, core.CreateImageRequest{
+		CreateImageDetails: core.CreateImageDetails{
+			CompartmentId: image.CompartmentId,
+			DisplayName:   image.DisplayName,
+			ImageSourceDetails: core.ImageSourceViaObjectStorageUriDetails{
+				SourceUri:              &sourceURI,
+				SourceImageType:        core.ImageSourceDetailsSourceImageTypeEnum(sourceImageType),
+				OperatingSystem:        image.OperatingSystem,
+				OperatingSystemVersion: image.OperatingSystemVersion,
+			},
+			LaunchMode:   core.CreateImageDetailsLaunchModeEnum(image.LaunchMode),
+			FreeformTags: image.FreeformTags,
+			DefinedTags:  image.DefinedTags,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("CreateImage", image.Id, err)
+	}
+
+	return *res.Id, nil
+}
+
+// UpdateImageOperatingSystem sets the operating system and version of a
+// custom image, leaving either unchanged when empty.
+func (d *driverOCI) UpdateImageOperatingSystem(ctx context.Context, id string, operatingSystem string, operatingSystemVersion string) (core.Image, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	details := core.UpdateImageDetails{}
+	if operatingSystem != "" {
+		details.OperatingSystem = &operatingSystem
+	}
+	if operatingSystemVersion != "" {
+		details.OperatingSystemVersion = &operatingSystemVersion
+	}
+
+	res, err := d.computeClient.UpdateImage(ctx, core.UpdateImageRequest{
+		ImageId:            &id,
+		UpdateImageDetails: details,
+		RequestMetadata:    requestMetadata,
+	})
+	if err != nil {
+		return core.Image{}, newRequestError("UpdateImage", &id, err)
+	}
+
+	return res.Image, nil
+}
+
+// ChangeImageCompartment moves a custom image to another compartment.
+func (d *driverOCI) ChangeImageCompartment(ctx context.Context, id string, compartmentId string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.computeClient.ChangeImageCompartment(ctx, core.ChangeImageCompartmentRequest{
+		ImageId: &id,
+		ChangeImageCompartmentDetails: core.ChangeImageCompartmentDetails{
+			CompartmentId: &compartmentId,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("ChangeImageCompartment", &id, err)
+}
+
+// CreateImage creates a new custom image and returns it along with the OCID
+// of the work request creating it.
+func (d *driverOCI) CreateImage(ctx context.Context, id string) (core.Image, string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	res, err := d.computeClient.CreateImage(ctx, core.CreateImageRequest{CreateImageDetails: core.CreateImageDetails{
+		CompartmentId: &d.cfg.ImageCompartmentID,
+		InstanceId:    &id,
+		DisplayName:   common.String(d.cfg.imageName()),
+		FreeformTags:  d.cfg.imageTags(),
+		DefinedTags:   d.cfg.DefinedTags,
+		LaunchMode:    core.CreateImageDetailsLaunchModeEnum(d.cfg.LaunchMode),
+	},
+		RequestMetadata: requestMetadata,
+	})
+
+	if err != nil {
+		return core.Image{}, "", newRequestError("CreateImage", &id, err)
+	}
+
+	var workRequestId string
+	if res.OpcWorkRequestId != nil {
+		workRequestId = *res.OpcWorkRequestId
+	}
+
+	return res.Image, workRequestId, nil
+}
+
+// UpdateImageCapabilitySchema creates a new custom image.
+func (d *driverOCI) UpdateImageCapabilitySchema(ctx context.Context, imageId string) (core.UpdateComputeImageCapabilitySchemaResponse, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	// get the schema associated with the newly created image
+	schema, err := d.computeClient.ListComputeImageCapabilitySchemas(ctx, core.ListComputeImageCapabilitySchemasRequest{
+		ImageId: &imageId,
+	})
+	if err != nil {
+		return core.UpdateComputeImageCapabilitySchemaResponse{}, newRequestError("ListComputeImageCapabilitySchemas", &imageId, err)
+	}
+	// no schema found, need to download the global image schema, use it as a base to create an image schema for this image
+	// and create the schema
+	if len(schema.Items) < 1 {
+		// get the global schema list
+		globalSchemaList, err := d.computeClient.ListComputeGlobalImageCapabilitySchemas(ctx, core.ListComputeGlobalImageCapabilitySchemasRequest{})
+		if err != nil {
+			return core.UpdateComputeImageCapabilitySchemaResponse{}, newRequestError("ListComputeGlobalImageCapabilitySchemas", nil, err)
+		}
+		if len(globalSchemaList.Items) < 1 {
+			return core.UpdateComputeImageCapabilitySchemaResponse{}, errors.New("unable to find any global schemas")
+		}
+
+		// get the global schema based on ocid and latest version guid
+		var globalSchemaId = globalSchemaList.Items[0].Id
+		var globalSchemaCurrentVersion = globalSchemaList.Items[0].CurrentVersionName
+		globalSchema, err := d.computeClient.GetComputeGlobalImageCapabilitySchemaVersion(ctx,
+			core.GetComputeGlobalImageCapabilitySchemaVersionRequest{ComputeGlobalImageCapabilitySchemaId: globalSchemaId,
+				ComputeGlobalImageCapabilitySchemaVersionName: globalSchemaCurrentVersion})
+		if err != nil {
+			return core.UpdateComputeImageCapabilitySchemaResponse{}, newRequestError("GetComputeGlobalImageCapabilitySchemaVersion", globalSchemaId, err)
+		}
+
+		// update schema data to replace all instances of "source": "GLOBAL" with "source": "IMAGE"
+		newSchemaData := make(map[string]core.ImageCapabilitySchemaDescriptor)
+		for key := range globalSchema.SchemaData {
+			val := globalSchema.SchemaData[key]
+			if boolVal, ok := val.(core.BooleanImageCapabilitySchemaDescriptor); ok {
+				newSchemaData[key] = core.BooleanImageCapabilitySchemaDescriptor{Source: "IMAGE", DefaultValue: boolVal.DefaultValue}
+			}
+			if enumIntVal, ok := val.(core.EnumIntegerImageCapabilityDescriptor); ok {
+				destArr := make([]int, len(enumIntVal.Values))
+				copy(destArr, enumIntVal.Values)
+				newVal := core.EnumIntegerImageCapabilityDescriptor{Source: "IMAGE", DefaultValue: enumIntVal.DefaultValue, Values: destArr}
+				newSchemaData[key] = newVal
+			}
+			if enumStringVal, ok := val.(core.EnumStringImageCapabilitySchemaDescriptor); ok {
+				destArr := make([]string, len(enumStringVal.Values))
+				copy(destArr, enumStringVal.Values)
+				newVal := core.EnumStringImageCapabilitySchemaDescriptor{Source: "IMAGE", DefaultValue: enumStringVal.DefaultValue, Values: destArr}
+				newSchemaData[key] = newVal
+			}
+		}
+
+		// create a new schema for the image by duplication the global schema
+		req := core.CreateComputeImageCapabilitySchemaRequest{CreateComputeImageCapabilitySchemaDetails: core.CreateComputeImageCapabilitySchemaDetails{
+			DisplayName:   common.String(fmt.Sprintf("Default Image Capability Schema for %s", d.cfg.imageName())),
+			ImageId:       &imageId,
+			SchemaData:    newSchemaData,
+			CompartmentId: &d.cfg.ImageCompartmentID,
+			ComputeGlobalImageCapabilitySchemaVersionName: globalSchema.ComputeGlobalImageCapabilitySchemaVersion.Name,
+		},
+			OpcRetryToken: common.String(uuid.TimeOrderedUUID()),
+		}
+		_, err = d.computeClient.CreateComputeImageCapabilitySchema(ctx, req)
+		if err != nil {
+			return core.UpdateComputeImageCapabilitySchemaResponse{}, newRequestError("CreateComputeImageCapabilitySchema", &imageId, err)
+		}
+
+		// try to get the schema again, now it should be good
+		schema, err = d.computeClient.ListComputeImageCapabilitySchemas(ctx,
+			core.ListComputeImageCapabilitySchemasRequest{
+				ImageId: &imageId,
+			})
+		if err != nil {
+			return core.UpdateComputeImageCapabilitySchemaResponse{}, newRequestError("ListComputeImageCapabilitySchemas", &imageId, err)
+		}
+	}
+
+	// update the schema to add the new custom fields
+	if d.cfg.LaunchMode != "" {
+		schema.Items[0].SchemaData["Compute.LaunchMode"] = core.EnumStringImageCapabilitySchemaDescriptor{Values: []string{"NATIVE", "EMULATED", "PARAVIRTUALIZED", "CUSTOM"}, DefaultValue: &d.cfg.LaunchMode, Source: "IMAGE"}
+	}
+	if d.cfg.NicAttachmentType != "" {
+		schema.Items[0].SchemaData["Network.AttachmentType"] = core.EnumStringImageCapabilitySchemaDescriptor{Values: []string{"E1000", "VFIO", "PARAVIRTUALIZED"}, DefaultValue: &d.cfg.NicAttachmentType, Source: "IMAGE"}
+	}
+	if d.cfg.IscsiMultipathDeviceSupported {
+		schema.Items[0].SchemaData[iscsiMultipathCapability] = core.BooleanImageCapabilitySchemaDescriptor{DefaultValue: common.Bool(true), Source: "IMAGE"}
+	}
+	if d.cfg.ConsistentVolumeNaming {
+		schema.Items[0].SchemaData[consistentVolumeNamingCapability] = core.BooleanImageCapabilitySchemaDescriptor{DefaultValue: common.Bool(true), Source: "IMAGE"}
+	}
+	if d.cfg.ParavirtualizationAttachmentVersion != 0 {
+		schema.Items[0].SchemaData[paravirtualizationAttachmentVersionCapability] = core.EnumIntegerImageCapabilityDescriptor{Values: []int{1, 2}, DefaultValue: &d.cfg.ParavirtualizationAttachmentVersion, Source: "IMAGE"}
+	}
+	firmware := d.cfg.ImageFirmware
+	// Ampere shapes only boot UEFI images
+	if firmware == "" && isAmpereShape(d.cfg.Shape) {
+		firmware = string(core.LaunchOptionsFirmwareUefi64)
+	}
+	if firmware != "" {
+		schema.Items[0].SchemaData[firmwareCapability] = core.EnumStringImageCapabilitySchemaDescriptor{Values: core.GetLaunchOptionsFirmwareEnumStringValues(), DefaultValue: &firmware, Source: "IMAGE"}
+	}
+	if err := applyImageCapabilities(schema.Items[0].SchemaData, d.cfg.ImageCapabilities); err != nil {
+		return core.UpdateComputeImageCapabilitySchemaResponse{}, err
+	}
+
+	// update the new fields to the schema definition
+	resp, err := d.computeClient.UpdateComputeImageCapabilitySchema(ctx,
+		core.UpdateComputeImageCapabilitySchemaRequest{ComputeImageCapabilitySchemaId: schema.Items[0].Id,
+			UpdateComputeImageCapabilitySchemaDetails: core.UpdateComputeImageCapabilitySchemaDetails{SchemaData: schema.Items[0].SchemaData,
+				FreeformTags: d.cfg.imageTags(),
+				DefinedTags:  d.cfg.DefinedTags,
+			}})
+
+	if err != nil {
+		return resp, newRequestError("UpdateComputeImageCapabilitySchema", schema.Items[0].Id, err)
+	}
+
+	return resp, nil
+}
+
+// AddImageShapeCompatibility makes a custom image compatible with a shape.
+func (d *driverOCI) AddImageShapeCompatibility(ctx context.Context, imageId string, shape string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.computeClient.AddImageShapeCompatibilityEntry(ctx, core.AddImageShapeCompatibilityEntryRequest{
+		ImageId:         &imageId,
+		ShapeName:       &shape,
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("AddImageShapeCompatibilityEntry", &imageId, err)
+}
+
+// DeleteImage deletes a custom image.
+func (d *driverOCI) DeleteImage(ctx context.Context, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.computeClient.DeleteImage(ctx, core.DeleteImageRequest{
+		ImageId:         &id,
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("DeleteImage", &id, err)
+}
+
+// DeleteImageCapabilitySchemas deletes the capability schemas of a custom
+// image, which are not deleted along with it.
+func (d *driverOCI) DeleteImageCapabilitySchemas(ctx context.Context, imageId string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	schemas, err := d.computeClient.ListComputeImageCapabilitySchemas(ctx, core.ListComputeImageCapabilitySchemasRequest{
+		ImageId:         &imageId,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return newRequestError("ListComputeImageCapabilitySchemas", &imageId, err)
+	}
+
+	for _, schema := range schemas.Items {
+		_, err := d.computeClient.DeleteComputeImageCapabilitySchema(ctx, core.DeleteComputeImageCapabilitySchemaRequest{
+			ComputeImageCapabilitySchemaId: schema.Id,
+			RequestMetadata:                requestMetadata,
+		})
+		if err != nil {
+			return newRequestError("DeleteComputeImageCapabilitySchema", schema.Id, err)
+		}
+	}
+	return nil
+}
+
+// DeleteMarketplacePublication deletes a Marketplace publication.
+func (d *driverOCI) DeleteMarketplacePublication(ctx context.Context, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.marketClient.DeletePublication(ctx, marketplace.DeletePublicationRequest{
+		PublicationId:   &id,
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("DeletePublication", &id, err)
+}
+
+// DeleteImageInRegion deletes a custom image in another region, such as a
+// copy made with CopyImageToRegion.
+func (d *driverOCI) DeleteImageInRegion(ctx context.Context, region string, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	client, err := d.regionComputeClient(region)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteImage(ctx, core.DeleteImageRequest{
+		ImageId:         &id,
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("DeleteImage", &id, err)
+}
+
+// GetAgentPluginStates returns the status of each plugin reported by the
+// Oracle Cloud Agent of an instance, by plugin name.
+func (d *driverOCI) GetAgentPluginStates(ctx context.Context, instanceId string) (map[string]string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	states := map[string]string{}
+	request := computeinstanceagent.ListInstanceAgentPluginsRequest{
+		CompartmentId:   &d.cfg.CompartmentID,
+		InstanceagentId: &instanceId,
+		RequestMetadata: requestMetadata,
+	}
+	for {
+		response, err := d.pluginClient.ListInstanceAgentPlugins(ctx, request)
+		if err != nil {
+			return nil, newRequestError("ListInstanceAgentPlugins", &instanceId, err)
+		}
+		for _, plugin := range response.Items {
+			if plugin.Name != nil {
+				states[*plugin.Name] = string(plugin.Status)
+			}
+		}
+		if response.OpcNextPage == nil {
+			return states, nil
+		}
+		request.Page = response.OpcNextPage
+	}
+}
+
+// GetBootVolume returns a boot volume.
+func (d *driverOCI) GetBootVolume(ctx context.Context, id string) (core.BootVolume, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	res, err := d.blockClient.GetBootVolume(ctx, core.GetBootVolumeRequest{
+		BootVolumeId:    &id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return core.BootVolume{}, newRequestError("GetBootVolume", &id, err)
+	}
+
+	return res.BootVolume, nil
+}
+
+// CloneBootVolume clones a boot volume in its availability domain and returns
+// the OCID of the clone, which is resized and encrypted like the boot volume
+// of an instance launched from an image.
+func (d *driverOCI) CloneBootVolume(ctx context.Context, source core.BootVolume) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	displayName := fmt.Sprintf("packer-%s-boot-volume", d.cfg.PackerBuildName)
+	details := core.CreateBootVolumeDetails{
+		AvailabilityDomain: source.AvailabilityDomain,
+		CompartmentId:      &d.cfg.CompartmentID,
+		DisplayName:        &displayName,
+		SourceDetails:      core.BootVolumeSourceFromBootVolumeDetails{Id: source.Id},
+		FreeformTags:       d.cfg.InstanceTags,
+		DefinedTags:        d.cfg.InstanceDefinedTags,
+	}
+	if d.cfg.BootVolumeSizeInGBs != 0 {
+		details.SizeInGBs = &d.cfg.BootVolumeSizeInGBs
+	}
+	if d.cfg.BootVolumeKmsKeyID != "" {
+		details.KmsKeyId = &d.cfg.BootVolumeKmsKeyID
+	}
+
+	res, err := d.blockClient.CreateBootVolume(ctx, core.CreateBootVolumeRequest{
+		CreateBootVolumeDetails: details,
+		RequestMetadata:         requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("CreateBootVolume", source.Id, err)
+	}
+
+	return *res.Id, nil
+}
+
+// CreateBootVolumeBackup creates a full backup of a boot volume, named and
+// tagged like the image, and returns its OCID.
+func (d *driverOCI) CreateBootVolumeBackup(ctx context.Context, bootVolumeId string) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	res, err := d.blockClient.CreateBootVolumeBackup(ctx, core.CreateBootVolumeBackupRequest{
+		CreateBootVolumeBackupDetails: core.CreateBootVolumeBackupDetails{
+			BootVolumeId: &bootVolumeId,
+			DisplayName:  common.String(d.cfg.imageName()),
+			Type:         core.CreateBootVolumeBackupDetailsTypeFull,
+			FreeformTags: d.cfg.imageTags(),
+			DefinedTags:  d.cfg.DefinedTags,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("CreateBootVolumeBackup", &bootVolumeId, err)
+	}
+
+	return *res.Id, nil
+}
+
+// DeleteBootVolume deletes a boot volume.
+func (d *driverOCI) DeleteBootVolume(ctx context.Context, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.blockClient.DeleteBootVolume(ctx, core.DeleteBootVolumeRequest{
+		BootVolumeId:    &id,
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("DeleteBootVolume", &id, err)
+}
+
+// DeleteBootVolumeBackup deletes a boot volume backup.
+func (d *driverOCI) DeleteBootVolumeBackup(ctx context.Context, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.blockClient.DeleteBootVolumeBackup(ctx, core.DeleteBootVolumeBackupRequest{
+		BootVolumeBackupId: &id,
+		RequestMetadata:    requestMetadata,
+	})
+	return newRequestError("DeleteBootVolumeBackup", &id, err)
+}
+
+// GetBootVolumeID returns the OCID of the boot volume attached to the given
+// instance.
+func (d *driverOCI) GetBootVolumeID(ctx context.Context, instanceId string) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	attachments, err := d.computeClient.ListBootVolumeAttachments(ctx, core.ListBootVolumeAttachmentsRequest{
+		AvailabilityDomain: &d.cfg.AvailabilityDomain,
+		CompartmentId:      &d.cfg.CompartmentID,
+		InstanceId:         &instanceId,
+		RequestMetadata:    requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("ListBootVolumeAttachments", &instanceId, err)
+	}
+	if len(attachments.Items) == 0 || attachments.Items[0].BootVolumeId == nil {
+		return "", fmt.Errorf("no boot volume attached to instance %s", instanceId)
+	}
+
+	return *attachments.Items[0].BootVolumeId, nil
+}
+
+// GetBucketKmsKeyID returns the OCID of the Vault key encrypting the bucket
+// an image is exported to, or "" if it uses an Oracle managed key.
+func (d *driverOCI) GetBucketKmsKeyID(ctx context.Context, export ImageExportConfig) (string, error) {
+	ctx, cancel := d.objectStorageContext(ctx)
+	defer cancel()
+
+	namespace, err := d.namespace(ctx, export.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := d.objectClient.GetBucket(ctx, objectstorage.GetBucketRequest{
+		NamespaceName:   &namespace,
+		BucketName:      &export.Bucket,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("GetBucket", &export.Bucket, err)
+	}
+	if res.KmsKeyId == nil {
+		return "", nil
+	}
+	return *res.KmsKeyId, nil
+}
+
+// GetCompartmentState returns the lifecycle state of the given compartment.
+func (d *driverOCI) GetCompartmentState(ctx context.Context, id string) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	compartment, err := d.identityClient.GetCompartment(ctx, identity.GetCompartmentRequest{
+		CompartmentId:   &id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("GetCompartment", &id, err)
+	}
+
+	return string(compartment.LifecycleState), nil
+}
+
+// Endpoints returns the OCI endpoints the driver calls.
+func (d *driverOCI) Endpoints() []string {
+	return []string{
+		d.computeClient.Endpoint(),
+		d.vcnClient.Endpoint(),
+		d.blockClient.Endpoint(),
+		d.loggingClient.Endpoint(),
+		d.objectClient.Endpoint(),
+		d.identityClient.Endpoint(),
+		d.limitsClient.Endpoint(),
+		d.agentClient.Endpoint(),
+		d.pluginClient.Endpoint(),
+		d.requestClient.Endpoint(),
+		d.marketClient.Endpoint(),
+	}
+}
+
+// GetEndpointTime sends an unauthenticated request to the given endpoint and
+// returns the time reported in the Date header of the response, whatever its
+// status.
+func (d *driverOCI) GetEndpointTime(ctx context.Context, endpoint string) (time.Time, error) {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	res, err := sharedHTTPClient(d.cfg.HTTPClient, d.cfg.FIPSMode).Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	res.Body.Close()
+
+	date := res.Header.Get("Date")
+	if date == "" {
+		return time.Time{}, fmt.Errorf("no Date header in the response of %s", endpoint)
+	}
+	return http.ParseTime(date)
+}
+
+// GetImage returns a custom image.
+func (d *driverOCI) GetImage(ctx context.Context, id string) (core.Image, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	res, err := d.computeClient.GetImage(ctx, core.GetImageRequest{
+		ImageId:         &id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return core.Image{}, newRequestError("GetImage", &id, err)
+	}
+
+	return res.Image, nil
+}
+
+// GetImageState returns the lifecycle state of an image.
+func (d *driverOCI) GetImageState(ctx context.Context, id string) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	image, err := d.computeClient.GetImage(ctx, core.GetImageRequest{
+		ImageId:         &id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("GetImage", &id, err)
+	}
+
+	return string(image.LifecycleState), nil
+}
+
+// GetObjectSHA256 returns the hex encoded SHA256 digest of an exported
+// image. Object Storage only records MD5 digests, so the object is downloaded
+// to compute it, which is only bounded by the Object Storage timeout as it
+// takes longer than request_timeout for images of several GBs.
+func (d *driverOCI) GetObjectSHA256(ctx context.Context, export ImageExportConfig) (string, error) {
+	ctx, cancel := d.objectStorageContext(ctx)
+	defer cancel()
+
+	namespace, err := d.namespace(ctx, export.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := d.downloadClient.GetObject(ctx, objectstorage.GetObjectRequest{
+		NamespaceName:   &namespace,
+		BucketName:      &export.Bucket,
+		ObjectName:      &export.ObjectName,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("GetObject", &export.ObjectName, err)
+	}
+	defer res.Content.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, res.Content); err != nil {
+		return "", fmt.Errorf("error reading object %s: %w", export.ObjectName, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// GetShape returns the given shape as offered in an availability domain, or
+// nil if the availability domain does not offer it.
+func (d *driverOCI) GetShape(ctx context.Context, name string, availabilityDomain string) (*core.Shape, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	request := core.ListShapesRequest{
+		CompartmentId:      &d.cfg.CompartmentID,
+		AvailabilityDomain: &availabilityDomain,
+		RequestMetadata:    requestMetadata,
+	}
+	for {
+		response, err := d.computeClient.ListShapes(ctx, request)
+		if err != nil {
+			return nil, newRequestError("ListShapes", &d.cfg.CompartmentID, err)
+		}
+		for _, shape := range response.Items {
+			if shape.Shape != nil && *shape.Shape == name {
+				return &shape, nil
+			}
+		}
+		if response.OpcNextPage == nil {
+			return nil, nil
+		}
+		request.Page = response.OpcNextPage
+	}
+}
+
+// GetSubnetState returns the lifecycle state of a subnet.
+func (d *driverOCI) GetSubnetState(ctx context.Context, id string) (string, error) {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
+
+	subnet, err := d.vcnClient.GetSubnet(ctx, core.GetSubnetRequest{
+		SubnetId:        &id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("GetSubnet", &id, err)
+	}
+
+	return string(subnet.LifecycleState), nil
+}
+
+// RunCommand runs a script on an instance through the Run Command plugin of
+// the Compute Instance Agent and returns its output. The script failing is
+// reported as an error holding its exit code and output.
+func (d *driverOCI) RunCommand(ctx context.Context, instanceId string, script string, timeout time.Duration) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	displayName := fmt.Sprintf("packer-%s", d.cfg.PackerBuildName)
+	timeoutInSeconds := int(timeout.Seconds())
+	res, err := d.agentClient.CreateInstanceAgentCommand(ctx, computeinstanceagent.CreateInstanceAgentCommandRequest{
+		CreateInstanceAgentCommandDetails: computeinstanceagent.CreateInstanceAgentCommandDetails{
+			CompartmentId:             &d.cfg.CompartmentID,
+			DisplayName:               &displayName,
+			ExecutionTimeOutInSeconds: &timeoutInSeconds,
+			Target:                    &computeinstanceagent.InstanceAgentCommandTarget{InstanceId: &instanceId},
+			Content: &computeinstanceagent.InstanceAgentCommandContent{
+				Source: computeinstanceagent.InstanceAgentCommandSourceViaTextDetails{Text: &script},
+				Output: computeinstanceagent.InstanceAgentCommandOutputViaTextDetails{},
+			},
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("CreateInstanceAgentCommand", &instanceId, err)
+	}
+
+	getExecution := func() (computeinstanceagent.InstanceAgentCommandExecution, error) {
+		execution, err := d.agentClient.GetInstanceAgentCommandExecution(ctx, computeinstanceagent.GetInstanceAgentCommandExecutionRequest{
+			InstanceAgentCommandId: res.Id,
+			InstanceId:             &instanceId,
+			RequestMetadata:        requestMetadata,
+		})
+		if err != nil {
+			return computeinstanceagent.InstanceAgentCommandExecution{}, newRequestError("GetInstanceAgentCommandExecution", res.Id, err)
+		}
+		return execution.InstanceAgentCommandExecution, nil
+	}
+
+	waitErr := waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			execution, err := getExecution()
+			return string(execution.LifecycleState), err
+		},
+		*res.Id,
+		[]string{"ACCEPTED", "IN_PROGRESS"},
+		"SUCCEEDED",
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
+	)
+
+	execution, err := getExecution()
+	if err != nil {
+		return "", err
+	}
+	var output, message string
+	exitCode := -1
+	if content, ok := execution.Content.(computeinstanceagent.InstanceAgentCommandExecutionOutputViaTextDetails); ok {
+		if content.Text != nil {
+			output = *content.Text
+		}
+		if content.Message != nil {
+			message = *content.Message
+		}
+		if content.ExitCode != nil {
+			exitCode = *content.ExitCode
+		}
+	}
+
+	switch execution.LifecycleState {
+	case "SUCCEEDED":
+		if exitCode != 0 {
+			return output, fmt.Errorf("command exited with code %d: %s", exitCode, strings.TrimSpace(message+"\n"+output))
+		}
+	case "FAILED", "TIMED_OUT", "CANCELED":
+		return output, fmt.Errorf("command %s with exit code %d: %s", execution.LifecycleState, exitCode, strings.TrimSpace(message+"\n"+output))
+	default:
+		return output, waitErr
+	}
+
+	return output, nil
+}
+
+// GetVolumeAttachment returns a volume attachment, which holds the iSCSI
+// target of iSCSI attachments.
+func (d *driverOCI) GetVolumeAttachment(ctx context.Context, id string) (core.VolumeAttachment, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	res, err := d.computeClient.GetVolumeAttachment(ctx, core.GetVolumeAttachmentRequest{
+		VolumeAttachmentId: &id,
+		RequestMetadata:    requestMetadata,
+	})
+	if err != nil {
+		return nil, newRequestError("GetVolumeAttachment", &id, err)
+	}
+
+	return res.VolumeAttachment, nil
+}
+
+// GetInstanceIP returns the public, private or IPv6 address, or the private
+// FQDN, corresponding to the given instance id.
+func (d *driverOCI) GetInstanceIP(ctx context.Context, id string) (string, error) {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
+
+	vnic, err := d.getPrimaryVnic(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	if d.cfg.UsePrivateFQDN {
+		if vnic.HostnameLabel == nil || *vnic.HostnameLabel == "" {
+			return "", fmt.Errorf("error getting VNIC hostname label for: %s", id)
+		}
+		subnet, err := d.vcnClient.GetSubnet(ctx, core.GetSubnetRequest{
+			SubnetId:        vnic.SubnetId,
+			RequestMetadata: requestMetadata,
+		})
+		if err != nil {
+			return "", fmt.Errorf("error getting subnet details: %w", newRequestError("GetSubnet", vnic.SubnetId, err))
+		}
+		if subnet.SubnetDomainName == nil || *subnet.SubnetDomainName == "" {
+			return "", fmt.Errorf("subnet %s has no DNS domain name", *vnic.SubnetId)
+		}
+		return fmt.Sprintf("%s.%s", *vnic.HostnameLabel, *subnet.SubnetDomainName), nil
+	}
+
+	if d.cfg.UseIPv6 {
+		return d.getVnicIPv6(ctx, vnic)
+	}
+
+	if d.cfg.UsePrivateIP {
+		if vnic.PrivateIp == nil {
+			return "", fmt.Errorf("error getting VNIC Private Ip for: %s", id)
+		}
+		return *vnic.PrivateIp, nil
+	}
+
+	if vnic.PublicIp == nil {
+		return "", fmt.Errorf("error getting VNIC Public Ip for: %s", id)
+	}
+
+	return *vnic.PublicIp, nil
+}
+
+// GetInstanceAddresses returns the private and public IP addresses of the
+// primary VNIC of the given instance. The public IP is empty when the
+// instance has none.
+func (d *driverOCI) GetInstanceAddresses(ctx context.Context, id string) (string, string, error) {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
+
+	vnic, err := d.getPrimaryVnic(ctx, id)
+	if err != nil {
+		return "", "", err
+	}
+
+	var privateIP, publicIP string
+	if vnic.PrivateIp != nil {
+		privateIP = *vnic.PrivateIp
+	}
+	if vnic.PublicIp != nil {
+		publicIP = *vnic.PublicIp
+	}
+	return privateIP, publicIP, nil
+}
+
+// GetInstanceImage returns the image the given instance was launched from.
+func (d *driverOCI) GetInstanceImage(ctx context.Context, id string) (core.Image, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	instance, err := d.computeClient.GetInstance(ctx, core.GetInstanceRequest{
+		InstanceId:      &id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return core.Image{}, newRequestError("GetInstance", &id, err)
+	}
+
+	source, ok := instance.SourceDetails.(core.InstanceSourceViaImageDetails)
+	if !ok {
+		return core.Image{}, fmt.Errorf("instance %s was not launched from an image", id)
+	}
+
+	image, err := d.computeClient.GetImage(ctx, core.GetImageRequest{
+		ImageId:         source.ImageId,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return core.Image{}, newRequestError("GetImage", source.ImageId, err)
+	}
+
+	return image.Image, nil
+}
+
+// UpdateBootVolumePerformance changes the performance of a boot volume. The
+// change is applied online, without waiting for it to complete.
+func (d *driverOCI) UpdateBootVolumePerformance(ctx context.Context, id string, vpusPerGB int64) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.blockClient.UpdateBootVolume(ctx, core.UpdateBootVolumeRequest{
+		BootVolumeId: &id,
+		UpdateBootVolumeDetails: core.UpdateBootVolumeDetails{
+			VpusPerGB: &vpusPerGB,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("UpdateBootVolume", &id, err)
+}
+
+// UpdateBootVolumeTags tags a boot volume with instance_tags and
+// instance_defined_tags.
+func (d *driverOCI) UpdateBootVolumeTags(ctx context.Context, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.blockClient.UpdateBootVolume(ctx, core.UpdateBootVolumeRequest{
+		BootVolumeId: &id,
+		UpdateBootVolumeDetails: core.UpdateBootVolumeDetails{
+			FreeformTags: d.cfg.InstanceTags,
+			DefinedTags:  d.cfg.InstanceDefinedTags,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("UpdateBootVolume", &id, err)
+}
+
+// UpdateInstanceShapeConfig resizes a flexible shape instance. The instance is
+// rebooted to apply the new shape configuration.
+func (d *driverOCI) UpdateInstanceShapeConfig(ctx context.Context, id string, shapeConfig FlexShapeConfig) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	details := core.UpdateInstanceShapeConfigDetails{
+		Ocpus:       shapeConfig.Ocpus,
+		MemoryInGBs: shapeConfig.MemoryInGBs,
+	}
+	if shapeConfig.BaselineOcpuUtilization != nil {
+		details.BaselineOcpuUtilization = core.UpdateInstanceShapeConfigDetailsBaselineOcpuUtilizationEnum(*shapeConfig.BaselineOcpuUtilization)
+	}
+
+	_, err := d.computeClient.UpdateInstance(ctx, core.UpdateInstanceRequest{
+		InstanceId: &id,
+		UpdateInstanceDetails: core.UpdateInstanceDetails{
+			ShapeConfig: &details,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("UpdateInstance", &id, err)
+}
+
+// GetInstanceState returns the lifecycle state of the given instance.
+func (d *driverOCI) GetInstanceState(ctx context.Context, id string) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	instance, err := d.computeClient.GetInstance(ctx, core.GetInstanceRequest{
+		InstanceId:      &id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("GetInstance", &id, err)
+	}
+
+	return string(instance.LifecycleState), nil
+}
+
+// GetComputeAvailability returns the availability of the given compute
+// service limit in the build compartment and availability domain.
+func (d *driverOCI) GetComputeAvailability(ctx context.Context, limitName string, availabilityDomain string) (limits.ResourceAvailability, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	res, err := d.limitsClient.GetResourceAvailability(ctx, limits.GetResourceAvailabilityRequest{
+		ServiceName:        common.String("compute"),
+		LimitName:          &limitName,
+		CompartmentId:      &d.cfg.CompartmentID,
+		AvailabilityDomain: &availabilityDomain,
+		RequestMetadata:    requestMetadata,
+	})
+	if err != nil {
+		return limits.ResourceAvailability{}, newRequestError("GetResourceAvailability", &d.cfg.CompartmentID, err)
+	}
+
+	return res.ResourceAvailability, nil
+}
+
+// InstanceAction performs an action, such as STOP, START or SOFTRESET, on the
+// given instance. Use WaitForInstanceState to wait for the action to
+// complete.
+func (d *driverOCI) InstanceAction(ctx context.Context, id string, action string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.computeClient.InstanceAction(ctx, core.InstanceActionRequest{
+		InstanceId:      &id,
+		Action:          core.InstanceActionActionEnum(action),
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("InstanceAction", &id, err)
+}
+
+// ListCustomImages returns the available custom images of a compartment,
+// newest first.
+func (d *driverOCI) ListCustomImages(ctx context.Context, compartmentId string) ([]core.Image, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	var images []core.Image
+	request := core.ListImagesRequest{
+		CompartmentId:   &compartmentId,
+		LifecycleState:  core.ImageLifecycleStateAvailable,
+		SortBy:          core.ListImagesSortByTimecreated,
+		SortOrder:       core.ListImagesSortOrderDesc,
+		RequestMetadata: requestMetadata,
+	}
+	for {
+		response, err := d.computeClient.ListImages(ctx, request)
+		if err != nil {
+			return nil, newRequestError("ListImages", request.CompartmentId, err)
+		}
+
+		// Platform images are listed along with the custom images
+		for _, image := range response.Items {
+			if image.CompartmentId != nil && *image.CompartmentId == compartmentId {
+				images = append(images, image)
+			}
+		}
+
+		if response.OpcNextPage == nil {
+			return images, nil
+		}
+		request.Page = response.OpcNextPage
+	}
+}
+
+// GetLatestBuildCounter returns the highest build counter of the images of
+// the given build series in the image compartment, or 0 if there are none.
+// The images of previous builds are in the destination compartment when
+// they are moved there.
+func (d *driverOCI) GetLatestBuildCounter(ctx context.Context, series string) (int, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	compartmentID := d.cfg.ImageCompartmentID
+	if d.cfg.ImageDestinationCompartmentID != "" {
+		compartmentID = d.cfg.ImageDestinationCompartmentID
+	}
+
+	latest := 0
+	request := core.ListImagesRequest{
+		CompartmentId:   &compartmentID,
+		RequestMetadata: requestMetadata,
+	}
+	for {
+		response, err := d.computeClient.ListImages(ctx, request)
+		if err != nil {
+			return 0, newRequestError("ListImages", request.CompartmentId, err)
+		}
+
+		for _, image := range response.Items {
+			if image.FreeformTags[buildSeriesTag] != series {
+				continue
+			}
+			counter, err := strconv.Atoi(image.FreeformTags[buildCounterTag])
+			if err != nil {
+				log.Printf("[WARN] Ignoring invalid %s tag of image %s: %s", buildCounterTag, *image.Id, err)
+				continue
+			}
+			if counter > latest {
+				latest = counter
+			}
+		}
+
+		if response.OpcNextPage == nil {
+			return latest, nil
+		}
+		request.Page = response.OpcNextPage
+	}
+}
+
+// getVnicIPv6 returns an IPv6 address of the given VNIC, assigning one from
+// the subnet's IPv6 prefix if the VNIC has none yet.
+func (d *driverOCI) getVnicIPv6(ctx context.Context, vnic core.Vnic) (string, error) {
+	ipv6s, err := d.vcnClient.ListIpv6s(ctx, core.ListIpv6sRequest{
+		VnicId:          vnic.Id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("ListIpv6s", vnic.Id, err)
+	}
+
+	for _, ipv6 := range ipv6s.Items {
+		if ipv6.LifecycleState == core.Ipv6LifecycleStateAvailable && ipv6.IpAddress != nil {
+			return *ipv6.IpAddress, nil
+		}
+	}
+
+	ipv6, err := d.vcnClient.CreateIpv6(ctx, core.CreateIpv6Request{
+		CreateIpv6Details: core.CreateIpv6Details{VnicId: vnic.Id},
+		RequestMetadata:   requestMetadata,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error assigning IPv6 address, the subnet must have an IPv6 prefix: %w",
+			newRequestError("CreateIpv6", vnic.Id, err))
+	}
+
+	return *ipv6.IpAddress, nil
+}
+
+// CreateReservedPublicIP creates a reserved public IP assigned to the primary
+// private IP of the given instance and returns its OCID.
+func (d *driverOCI) CreateReservedPublicIP(ctx context.Context, instanceId string) (string, error) {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
+
+	privateIpId, err := d.getPrimaryPrivateIPID(ctx, instanceId)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := d.vcnClient.CreatePublicIp(ctx, core.CreatePublicIpRequest{
+		CreatePublicIpDetails: core.CreatePublicIpDetails{
+			CompartmentId: &d.cfg.CompartmentID,
+			Lifetime:      core.CreatePublicIpDetailsLifetimeReserved,
+			PrivateIpId:   &privateIpId,
+			DisplayName:   d.cfg.InstanceName,
+			FreeformTags:  d.cfg.InstanceTags,
+			DefinedTags:   d.cfg.InstanceDefinedTags,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("CreatePublicIp", &privateIpId, err)
+	}
+
+	return *res.Id, nil
+}
+
+// AssignPublicIP assigns an existing reserved public IP to the primary
+// private IP of the given instance.
+func (d *driverOCI) AssignPublicIP(ctx context.Context, publicIpId string, instanceId string) error {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
+
+	privateIpId, err := d.getPrimaryPrivateIPID(ctx, instanceId)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.vcnClient.UpdatePublicIp(ctx, core.UpdatePublicIpRequest{
+		PublicIpId:            &publicIpId,
+		UpdatePublicIpDetails: core.UpdatePublicIpDetails{PrivateIpId: &privateIpId},
+		RequestMetadata:       requestMetadata,
+	})
+	return newRequestError("UpdatePublicIp", &publicIpId, err)
+}
+
+// UnassignPublicIP unassigns a reserved public IP from its private IP.
+func (d *driverOCI) UnassignPublicIP(ctx context.Context, id string) error {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
+
+	_, err := d.vcnClient.UpdatePublicIp(ctx, core.UpdatePublicIpRequest{
+		PublicIpId:            &id,
+		UpdatePublicIpDetails: core.UpdatePublicIpDetails{PrivateIpId: common.String("")},
+		RequestMetadata:       requestMetadata,
+	})
+	return newRequestError("UpdatePublicIp", &id, err)
+}
+
+// DeletePublicIP deletes a reserved public IP.
+func (d *driverOCI) DeletePublicIP(ctx context.Context, id string) error {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
+
+	_, err := d.vcnClient.DeletePublicIp(ctx, core.DeletePublicIpRequest{
+		PublicIpId:      &id,
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("DeletePublicIp", &id, err)
+}
+
+// getPrimaryPrivateIPID returns the OCID of the primary private IP of the
+// primary VNIC of the given instance.
+func (d *driverOCI) getPrimaryPrivateIPID(ctx context.Context, instanceId string) (string, error) {
+	vnic, err := d.getPrimaryVnic(ctx, instanceId)
+	if err != nil {
+		return "", err
+	}
+
+	privateIps, err := d.vcnClient.ListPrivateIps(ctx, core.ListPrivateIpsRequest{
+		VnicId:          vnic.Id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("ListPrivateIps", vnic.Id, err)
+	}
+
+	for _, privateIp := range privateIps.Items {
+		if privateIp.IsPrimary != nil && *privateIp.IsPrimary {
+			return *privateIp.Id, nil
+		}
+	}
+
+	return "", fmt.Errorf("no primary private IP found for instance: %s", instanceId)
+}
+
+// getPrimaryVnic returns the primary VNIC of the given instance.
+func (d *driverOCI) getPrimaryVnic(ctx context.Context, id string) (core.Vnic, error) {
+	vnics, err := d.computeClient.ListVnicAttachments(ctx, core.ListVnicAttachmentsRequest{
+		InstanceId:      &id,
+		CompartmentId:   &d.cfg.CompartmentID,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return core.Vnic{}, newRequestError("ListVnicAttachments", &id, err)
+	}
+
+	if len(vnics.Items) == 0 {
+		return core.Vnic{}, errors.New("instance has zero VNICs")
+	}
+
+	// Secondary VNICs (e.g. a VLAN attachment) may be listed first, so look
+	// for the primary VNIC.
+	var vnic core.GetVnicResponse
+	for _, attachment := range vnics.Items {
+		if attachment.VnicId == nil {
+			continue
+		}
+		vnic, err = d.vcnClient.GetVnic(ctx, core.GetVnicRequest{
+			VnicId:          attachment.VnicId,
+			RequestMetadata: requestMetadata,
+		})
+		if err != nil {
+			return core.Vnic{}, fmt.Errorf("error getting VNIC details: %w", newRequestError("GetVnic", attachment.VnicId, err))
+		}
+		if vnic.IsPrimary == nil || *vnic.IsPrimary {
+			break
+		}
+	}
+
+	return vnic.Vnic, nil
+}
+
+// GetInstanceInitialCredentials returns the username and password generated
+// at the first boot of a Windows instance.
+func (d *driverOCI) GetInstanceInitialCredentials(ctx context.Context, id string) (string, string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	credentials, err := d.computeClient.GetWindowsInstanceInitialCredentials(ctx, core.GetWindowsInstanceInitialCredentialsRequest{
+		InstanceId:      &id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", "", newRequestError("GetWindowsInstanceInitialCredentials", &id, err)
+	}
+
+	return *credentials.InstanceCredentials.Username, *credentials.InstanceCredentials.Password, err
+}
+
+// AddSecurityListIngressRule appends a stateful TCP ingress rule for port,
+// identified by description, to a security list.
+func (d *driverOCI) AddSecurityListIngressRule(ctx context.Context, securityListId string, port int, description string) error {
+	return d.updateSecurityListIngressRules(ctx, securityListId, func(rules []core.IngressSecurityRule) []core.IngressSecurityRule {
+		for _, cidr := range d.cfg.SecurityListSourceCidrs {
+			rules = append(rules, core.IngressSecurityRule{
+				Protocol:    common.String("6"), // TCP
+				Source:      common.String(cidr),
+				SourceType:  core.IngressSecurityRuleSourceTypeCidrBlock,
+				IsStateless: common.Bool(false),
+				TcpOptions: &core.TcpOptions{
+					DestinationPortRange: &core.PortRange{Min: common.Int(port), Max: common.Int(port)},
+				},
+				Description: common.String(description),
+			})
+		}
+		return rules
+	})
+}
+
+// RemoveSecurityListIngressRules removes the ingress rules identified by
+// description from a security list, leaving all other rules untouched.
+func (d *driverOCI) RemoveSecurityListIngressRules(ctx context.Context, securityListId string, description string) error {
+	return d.updateSecurityListIngressRules(ctx, securityListId, func(rules []core.IngressSecurityRule) []core.IngressSecurityRule {
+		kept := make([]core.IngressSecurityRule, 0, len(rules))
+		for _, rule := range rules {
+			if rule.Description != nil && *rule.Description == description {
+				continue
+			}
+			kept = append(kept, rule)
+		}
+		return kept
+	})
+}
+
+// updateSecurityListIngressRules replaces the ingress rules of a security
+// list with the result of update. The update is guarded by the list's etag
+// and retried when the list was concurrently modified by another build.
+func (d *driverOCI) updateSecurityListIngressRules(ctx context.Context, securityListId string, update func([]core.IngressSecurityRule) []core.IngressSecurityRule) error {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
+
+	const maxAttempts = 5
+	for attempt := 1; ; attempt++ {
+		list, err := d.vcnClient.GetSecurityList(ctx, core.GetSecurityListRequest{
+			SecurityListId:  &securityListId,
+			RequestMetadata: requestMetadata,
+		})
+		if err != nil {
+			return newRequestError("GetSecurityList", &securityListId, err)
+		}
+
+		_, err = d.vcnClient.UpdateSecurityList(ctx, core.UpdateSecurityListRequest{
+			SecurityListId: &securityListId,
+			IfMatch:        list.Etag,
+			UpdateSecurityListDetails: core.UpdateSecurityListDetails{
+				IngressSecurityRules: update(list.IngressSecurityRules),
+			},
+			RequestMetadata: requestMetadata,
+		})
+		var e common.ServiceError
+		if errors.As(err, &e) && e.GetHTTPStatusCode() == http.StatusPreconditionFailed && attempt < maxAttempts {
+			continue
+		}
+		return newRequestError("UpdateSecurityList", &securityListId, err)
+	}
+}
+
+// CreateLockObject creates an Object Storage object only if it does not exist
+// yet and returns its ETag. It returns errLockHeld if the object exists.
+func (d *driverOCI) CreateLockObject(ctx context.Context, bucket string, name string, content string) (string, error) {
+	return d.putLockObject(ctx, bucket, name, content, objectstorage.PutObjectRequest{IfNoneMatch: common.String("*")})
+}
+
+// ReplaceLockObject overwrites an Object Storage object only if it still has
+// the given ETag and returns its new ETag. It returns errLockHeld if the
+// object changed in the meantime.
+func (d *driverOCI) ReplaceLockObject(ctx context.Context, bucket string, name string, content string, etag string) (string, error) {
+	return d.putLockObject(ctx, bucket, name, content, objectstorage.PutObjectRequest{IfMatch: &etag})
+}
+
+// putLockObject writes a lock object with the preconditions of request.
+func (d *driverOCI) putLockObject(ctx context.Context, bucket string, name string, content string, request objectstorage.PutObjectRequest) (string, error) {
+	ctx, cancel := d.objectStorageContext(ctx)
+	defer cancel()
+
+	namespace, err := d.namespace(ctx, "")
+	if err != nil {
+		return "", err
+	}
+
+	request.NamespaceName = &namespace
+	request.BucketName = &bucket
+	request.ObjectName = &name
+	request.ContentLength = common.Int64(int64(len(content)))
+	request.PutObjectBody = io.NopCloser(strings.NewReader(content))
+	request.RequestMetadata = requestMetadata
+	res, err := d.objectClient.PutObject(ctx, request)
+	var e common.ServiceError
+	if errors.As(err, &e) && e.GetHTTPStatusCode() == http.StatusPreconditionFailed {
+		return "", errLockHeld
+	}
+	if err != nil {
+		return "", newRequestError("PutObject", &bucket, err)
+	}
+
+	return *res.ETag, nil
+}
+
+// GetLockObject returns the ETag of an Object Storage object and when it was
+// last modified, or an empty ETag if the object does not exist.
+func (d *driverOCI) GetLockObject(ctx context.Context, bucket string, name string) (string, time.Time, error) {
+	ctx, cancel := d.objectStorageContext(ctx)
+	defer cancel()
+
+	namespace, err := d.namespace(ctx, "")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	res, err := d.objectClient.HeadObject(ctx, objectstorage.HeadObjectRequest{
+		NamespaceName:   &namespace,
+		BucketName:      &bucket,
+		ObjectName:      &name,
+		RequestMetadata: requestMetadata,
+	})
+	var e common.ServiceError
+	if errors.As(err, &e) && e.GetHTTPStatusCode() == http.StatusNotFound {
+		return "", time.Time{}, nil
+	}
+	if err != nil {
+		return "", time.Time{}, newRequestError("HeadObject", &bucket, err)
+	}
+
+	var modified time.Time
+	if res.LastModified != nil {
+		modified = res.LastModified.Time
+	}
+	return *res.ETag, modified, nil
+}
+
+// DeleteLockObject deletes an Object Storage object, provided it still has
+// the given ETag.
+func (d *driverOCI) DeleteLockObject(ctx context.Context, bucket string, name string, etag string) error {
+	ctx, cancel := d.objectStorageContext(ctx)
+	defer cancel()
+
+	namespace, err := d.namespace(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	_, err = d.objectClient.DeleteObject(ctx, objectstorage.DeleteObjectRequest{
+		NamespaceName:   &namespace,
+		BucketName:      &bucket,
+		ObjectName:      &name,
+		IfMatch:         &etag,
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("DeleteObject", &bucket, err)
+}
+
+// PutLogs sends a batch of log entries to an OCI Logging custom log.
+func (d *driverOCI) PutLogs(ctx context.Context, logId string, subject string, entries []loggingingestion.LogEntry) error {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
+
+	_, err := d.loggingClient.PutLogs(ctx, loggingingestion.PutLogsRequest{
+		LogId: &logId,
+		PutLogsDetails: loggingingestion.PutLogsDetails{
+			Specversion: common.String("1.0"),
+			LogEntryBatches: []loggingingestion.LogEntryBatch{{
+				Entries:             entries,
+				Source:              common.String("packer"),
+				Type:                common.String("packer.provisioner"),
+				Subject:             common.String(subject),
+				Defaultlogentrytime: &common.SDKTime{Time: time.Now()},
+			}},
+		},
+		RequestMetadata: requestMetadata,
+	})
+	return newRequestError("PutLogs", &logId, err)
+}
+
+// TerminateInstance terminates a compute instance, along with its boot
+// volume unless preserve_boot_volume is set.
+func (d *driverOCI) TerminateInstance(ctx context.Context, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.computeClient.TerminateInstance(ctx, core.TerminateInstanceRequest{
+		InstanceId:         &id,
+		PreserveBootVolume: &d.cfg.PreserveBootVolume,
+		RequestMetadata:    requestMetadata,
+	})
+	return newRequestError("TerminateInstance", &id, err)
+}
+
+// CaptureConsoleHistory captures the recent serial console output of the
+// given instance and returns up to a megabyte of it. The captured history is
+// deleted once read.
+func (d *driverOCI) CaptureConsoleHistory(ctx context.Context, instanceId string) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	res, err := d.computeClient.CaptureConsoleHistory(ctx, core.CaptureConsoleHistoryRequest{
+		CaptureConsoleHistoryDetails: core.CaptureConsoleHistoryDetails{
+			InstanceId: &instanceId,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("CaptureConsoleHistory", &instanceId, err)
+	}
+	historyId := *res.Id
+
+	defer func() {
+		_, err := d.computeClient.DeleteConsoleHistory(ctx, core.DeleteConsoleHistoryRequest{
+			InstanceConsoleHistoryId: &historyId,
+			RequestMetadata:          requestMetadata,
+		})
+		if err != nil {
+			log.Printf("[WARN] Failed to delete console history %s: %s", historyId, err)
+		}
+	}()
+
+	err = waitForResourceToReachState(
+		ctx,
+		func(id string) (string, error) {
+			history, err := d.computeClient.GetConsoleHistory(ctx, core.GetConsoleHistoryRequest{
+				InstanceConsoleHistoryId: &id,
+				RequestMetadata:          requestMetadata,
+			})
+			if err != nil {
+				return "", newRequestError("GetConsoleHistory", &id, err)
+			}
+			return string(history.LifecycleState), nil
+		},
+		historyId,
+		[]string{"REQUESTED", "GETTING-HISTORY"},
+		"SUCCEEDED",
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
+	)
+	if err != nil {
+		return "", err
+	}
 
-	if vnic.PublicIp == nil {
-		return "", fmt.Errorf("error getting VNIC Public Ip for: %s", id)
+	length := 1024 * 1024
+	content, err := d.computeClient.GetConsoleHistoryContent(ctx, core.GetConsoleHistoryContentRequest{
+		InstanceConsoleHistoryId: &historyId,
+		Length:                   &length,
+		RequestMetadata:          requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("GetConsoleHistoryContent", &historyId, err)
 	}
+	if content.Value == nil {
+		return "", nil
+	}
+	return *content.Value, nil
+}
 
-	return *vnic.PublicIp, nil
+// CreateMarketplacePublication publishes an image as a Marketplace community
+// listing with marketplace_publication and returns the OCID of the
+// publication.
+func (d *driverOCI) CreateMarketplacePublication(ctx context.Context, image core.Image) (string, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	p := d.cfg.MarketplacePublication
+	name := p.Name
+	if name == "" {
+		name = *image.DisplayName
+	}
+	packageVersion := p.PackageVersion
+	if packageVersion == "" {
+		packageVersion = *image.DisplayName
+	}
+	operatingSystem := p.OperatingSystem
+	if operatingSystem == "" && image.OperatingSystem != nil {
+		operatingSystem = *image.OperatingSystem
+	}
+	if operatingSystem == "" {
+		return "", errors.New("the operating system of the image is unknown, set 'marketplace_publication.operating_system'")
+	}
+
+	eula := []marketplace.Eula{}
+	if p.EulaText != "" {
+		eula = append(eula, marketplace.TextBasedEula{LicenseText: &p.EulaText})
+	}
+	contact := marketplace.SupportContact{Email: &p.SupportEmail}
+	if p.SupportName != "" {
+		contact.Name = &p.SupportName
+	}
+
+	details := marketplace.CreatePublicationDetails{
+		ListingType:      marketplace.ListingTypeCommunity,
+		Name:             &name,
+		ShortDescription: &p.ShortDescription,
+		SupportContacts:  []marketplace.SupportContact{contact},
+		CompartmentId:    &p.CompartmentID,
+		PackageDetails: marketplace.CreateImagePublicationPackage{
+			PackageVersion:  &packageVersion,
+			OperatingSystem: &marketplace.OperatingSystem{Name: &operatingSystem},
+			Eula:            eula,
+			ImageId:         image.Id,
+		},
+		IsAgreementAcknowledged: &p.AgreementAcknowledged,
+		FreeformTags:            d.cfg.imageTags(),
+		DefinedTags:             d.cfg.DefinedTags,
+	}
+	if p.LongDescription != "" {
+		details.LongDescription = &p.LongDescription
+	}
+
+	res, err := d.marketClient.CreatePublication(ctx, marketplace.CreatePublicationRequest{
+		CreatePublicationDetails: details,
+		RequestMetadata:          requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("CreatePublication", image.Id, err)
+	}
+
+	return *res.Id, nil
 }
 
-func (d *driverOCI) GetInstanceInitialCredentials(ctx context.Context, id string) (string, string, error) {
-	credentials, err := d.computeClient.GetWindowsInstanceInitialCredentials(ctx, core.GetWindowsInstanceInitialCredentialsRequest{
-		InstanceId:      &id,
+// CreateConsoleConnection creates a console connection to the given instance
+// for the given SSH public key and waits for it to become active.
+func (d *driverOCI) CreateConsoleConnection(ctx context.Context, instanceId string, publicKey string) (core.InstanceConsoleConnection, error) {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	res, err := d.computeClient.CreateInstanceConsoleConnection(ctx, core.CreateInstanceConsoleConnectionRequest{
+		CreateInstanceConsoleConnectionDetails: core.CreateInstanceConsoleConnectionDetails{
+			InstanceId:   &instanceId,
+			PublicKey:    &publicKey,
+			FreeformTags: d.cfg.InstanceTags,
+			DefinedTags:  d.cfg.InstanceDefinedTags,
+		},
 		RequestMetadata: requestMetadata,
 	})
 	if err != nil {
-		return "", "", err
+		return core.InstanceConsoleConnection{}, newRequestError("CreateInstanceConsoleConnection", &instanceId, err)
 	}
 
-	return *credentials.InstanceCredentials.Username, *credentials.InstanceCredentials.Password, err
+	// The connection strings are only known once the connection is active
+	connection := res.InstanceConsoleConnection
+	err = waitForResourceToReachState(
+		ctx,
+		func(id string) (string, error) {
+			got, err := d.computeClient.GetInstanceConsoleConnection(ctx, core.GetInstanceConsoleConnectionRequest{
+				InstanceConsoleConnectionId: &id,
+				RequestMetadata:             requestMetadata,
+			})
+			if err != nil {
+				return "", newRequestError("GetInstanceConsoleConnection", &id, err)
+			}
+			connection = got.InstanceConsoleConnection
+			return string(connection.LifecycleState), nil
+		},
+		*res.Id,
+		[]string{"CREATING"},
+		"ACTIVE",
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
+	)
+	return connection, err
 }
 
-// TerminateInstance terminates a compute instance.
-func (d *driverOCI) TerminateInstance(ctx context.Context, id string) error {
-	_, err := d.computeClient.TerminateInstance(ctx, core.TerminateInstanceRequest{
-		InstanceId:      &id,
-		RequestMetadata: requestMetadata,
+// DeleteConsoleConnection deletes an instance console connection.
+func (d *driverOCI) DeleteConsoleConnection(ctx context.Context, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	_, err := d.computeClient.DeleteInstanceConsoleConnection(ctx, core.DeleteInstanceConsoleConnectionRequest{
+		InstanceConsoleConnectionId: &id,
+		RequestMetadata:             requestMetadata,
 	})
-	return err
+	return newRequestError("DeleteInstanceConsoleConnection", &id, err)
 }
 
 // WaitForImageCreation waits for a provisioning custom image to reach the
-// "AVAILABLE" state.
-func (d *driverOCI) WaitForImageCreation(ctx context.Context, id string) error {
-	return waitForResourceToReachState(
+// "AVAILABLE" state. When the OCID of the work request creating the image is
+// given, the work request is tracked instead, and progress is called whenever
+// its percentage of completion changes. The wait is bounded by
+// image_creation_timeout instead of the compute timeout, when set.
+func (d *driverOCI) WaitForImageCreation(ctx context.Context, id string, workRequestId string, progress func(percentComplete float32)) error {
+	ctx, cancel := withTimeout(ctx, d.cfg.imageCreationTimeout())
+	defer cancel()
+
+	interval := d.cfg.ImagePollingInterval
+	if interval == 0 {
+		interval = d.cfg.Timeouts.PollingInterval
+	}
+
+	if workRequestId != "" {
+		return d.waitAdaptively(
+			ctx,
+			"image/"+d.cfg.Shape,
+			interval,
+			d.workRequestStatus(ctx, progress),
+			workRequestId,
+			workRequestWaitStates,
+			string(workrequests.WorkRequestStatusSucceeded),
+		)
+	}
+
+	return d.waitAdaptively(
+		ctx,
+		"image/"+d.cfg.Shape,
+		interval,
 		func(string) (string, error) {
 			image, err := d.computeClient.GetImage(ctx, core.GetImageRequest{
 				ImageId:         &id,
 				RequestMetadata: requestMetadata,
 			})
 			if err != nil {
-				return "", err
+				return "", newRequestError("GetImage", &id, err)
 			}
 			return string(image.LifecycleState), nil
 		},
 		id,
 		[]string{"PROVISIONING"},
 		"AVAILABLE",
-		0,             //Unlimited Retries
-		5*time.Second, //5 second wait between retries
+	)
+}
+
+// WaitForImageCopy waits for a custom image imported in another region to
+// become available.
+func (d *driverOCI) WaitForImageCopy(ctx context.Context, region string, id string) error {
+	client, err := d.regionComputeClient(region)
+	if err != nil {
+		return err
+	}
+	return d.waitForImageImport(ctx, client, id)
+}
+
+// WaitForCompartmentImageCopy waits for a custom image imported in another
+// compartment with CopyImageToCompartment to become available.
+func (d *driverOCI) WaitForCompartmentImageCopy(ctx context.Context, id string) error {
+	return d.waitForImageImport(ctx, d.computeClient, id)
+}
+
+// waitForImageImport waits for a custom image imported through client to
+// become available.
+func (d *driverOCI) waitForImageImport(ctx context.Context, client core.ComputeClient, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			image, err := client.GetImage(ctx, core.GetImageRequest{
+				ImageId:         &id,
+				RequestMetadata: requestMetadata,
+			})
+			if err != nil {
+				return "", newRequestError("GetImage", &id, err)
+			}
+			return string(image.LifecycleState), nil
+		},
+		id,
+		[]string{"PROVISIONING", "IMPORTING"},
+		"AVAILABLE",
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
 	)
 }
 
 // WaitForInstanceState waits for an instance to reach the a given terminal
 // state.
 func (d *driverOCI) WaitForInstanceState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	return d.waitAdaptively(
+		ctx,
+		"instance/"+terminalState+"/"+d.cfg.Shape,
+		d.cfg.Timeouts.PollingInterval,
+		func(string) (string, error) {
+			instance, err := d.computeClient.GetInstance(ctx, core.GetInstanceRequest{
+				InstanceId:      &id,
+				RequestMetadata: requestMetadata,
+			})
+			if err != nil {
+				return "", newRequestError("GetInstance", &id, err)
+			}
+			return string(instance.LifecycleState), nil
+		},
+		id,
+		waitStates,
+		terminalState,
+	)
+}
+
+// WaitForInstanceShapeConfig waits for a resized instance to be running with
+// the given number of OCPUs.
+func (d *driverOCI) WaitForInstanceShapeConfig(ctx context.Context, id string, ocpus float32) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
 	return waitForResourceToReachState(
+		ctx,
 		func(string) (string, error) {
 			instance, err := d.computeClient.GetInstance(ctx, core.GetInstanceRequest{
 				InstanceId:      &id,
 				RequestMetadata: requestMetadata,
 			})
 			if err != nil {
-				return "", err
+				return "", newRequestError("GetInstance", &id, err)
+			}
+			// A running instance may not have started resizing yet
+			if instance.LifecycleState == core.InstanceLifecycleStateRunning {
+				if instance.ShapeConfig != nil && instance.ShapeConfig.Ocpus != nil && *instance.ShapeConfig.Ocpus == ocpus {
+					return "RESIZED", nil
+				}
+				return "RESIZING", nil
 			}
 			return string(instance.LifecycleState), nil
 		},
 		id,
+		[]string{"RESIZING", "STOPPING", "STOPPED", "STARTING", "MOVING"},
+		"RESIZED",
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
+	)
+}
+
+// WaitForPublicIPState waits for a public IP to reach a given terminal state.
+func (d *driverOCI) WaitForPublicIPState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+	ctx, cancel := d.networkContext(ctx)
+	defer cancel()
+
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			publicIp, err := d.vcnClient.GetPublicIp(ctx, core.GetPublicIpRequest{
+				PublicIpId:      &id,
+				RequestMetadata: requestMetadata,
+			})
+			if err != nil {
+				return "", newRequestError("GetPublicIp", &id, err)
+			}
+			return string(publicIp.LifecycleState), nil
+		},
+		id,
+		waitStates,
+		terminalState,
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
+	)
+}
+
+// WaitForVolumeState waits for a block volume to reach a given terminal
+// state.
+func (d *driverOCI) WaitForVolumeState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			volume, err := d.blockClient.GetVolume(ctx, core.GetVolumeRequest{
+				VolumeId:        &id,
+				RequestMetadata: requestMetadata,
+			})
+			if err != nil {
+				return "", newRequestError("GetVolume", &id, err)
+			}
+			return string(volume.LifecycleState), nil
+		},
+		id,
+		waitStates,
+		terminalState,
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
+	)
+}
+
+// WaitForMarketplacePublication waits for a Marketplace publication to become
+// active.
+func (d *driverOCI) WaitForMarketplacePublication(ctx context.Context, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			res, err := d.marketClient.GetPublication(ctx, marketplace.GetPublicationRequest{
+				PublicationId:   &id,
+				RequestMetadata: requestMetadata,
+			})
+			if err != nil {
+				return "", newRequestError("GetPublication", &id, err)
+			}
+			return string(res.LifecycleState), nil
+		},
+		id,
+		[]string{"CREATING"},
+		"ACTIVE",
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
+	)
+}
+
+// WaitForBootVolumeBackup waits for a boot volume backup to become
+// available.
+func (d *driverOCI) WaitForBootVolumeBackup(ctx context.Context, id string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			res, err := d.blockClient.GetBootVolumeBackup(ctx, core.GetBootVolumeBackupRequest{
+				BootVolumeBackupId: &id,
+				RequestMetadata:    requestMetadata,
+			})
+			if err != nil {
+				return "", newRequestError("GetBootVolumeBackup", &id, err)
+			}
+			return string(res.LifecycleState), nil
+		},
+		id,
+		[]string{"REQUEST_RECEIVED", "CREATING"},
+		"AVAILABLE",
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
+	)
+}
+
+// WaitForBootVolumeState waits for a boot volume to reach a given terminal
+// state.
+func (d *driverOCI) WaitForBootVolumeState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			volume, err := d.GetBootVolume(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			return string(volume.LifecycleState), nil
+		},
+		id,
+		waitStates,
+		terminalState,
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
+	)
+}
+
+// WaitForVolumeAttachmentState waits for a volume attachment to reach a given
+// terminal state.
+func (d *driverOCI) WaitForVolumeAttachmentState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			attachment, err := d.computeClient.GetVolumeAttachment(ctx, core.GetVolumeAttachmentRequest{
+				VolumeAttachmentId: &id,
+				RequestMetadata:    requestMetadata,
+			})
+			if err != nil {
+				return "", newRequestError("GetVolumeAttachment", &id, err)
+			}
+			return string(attachment.VolumeAttachment.GetLifecycleState()), nil
+		},
+		id,
+		waitStates,
+		terminalState,
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
+	)
+}
+
+// WaitForWorkRequest waits for a work request to succeed, calling progress
+// whenever its percentage of completion changes.
+func (d *driverOCI) WaitForWorkRequest(ctx context.Context, id string, progress func(percentComplete float32)) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	return waitForResourceToReachState(
+		ctx,
+		d.workRequestStatus(ctx, progress),
+		id,
+		workRequestWaitStates,
+		string(workrequests.WorkRequestStatusSucceeded),
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
+	)
+}
+
+// workRequestWaitStates are the statuses of a work request that has not
+// finished yet.
+var workRequestWaitStates = []string{
+	string(workrequests.WorkRequestStatusAccepted),
+	string(workrequests.WorkRequestStatusInProgress),
+	string(workrequests.WorkRequestStatusCanceling),
+}
+
+// workRequestStatus returns a polled get of the status of a work request,
+// which calls progress, if not nil, whenever the percentage of completion of
+// the work request changes. A failed or canceled work request is returned as
+// an error carrying the errors it reported.
+func (d *driverOCI) workRequestStatus(ctx context.Context, progress func(percentComplete float32)) func(string) (string, error) {
+	var reported *float32
+	return func(id string) (string, error) {
+		res, err := d.requestClient.GetWorkRequest(ctx, workrequests.GetWorkRequestRequest{
+			WorkRequestId:   &id,
+			RequestMetadata: requestMetadata,
+		})
+		if err != nil {
+			return "", newRequestError("GetWorkRequest", &id, err)
+		}
+
+		if progress != nil && res.PercentComplete != nil && (reported == nil || *reported != *res.PercentComplete) {
+			reported = res.PercentComplete
+			progress(*res.PercentComplete)
+		}
+
+		switch res.Status {
+		case workrequests.WorkRequestStatusFailed, workrequests.WorkRequestStatusCanceled:
+			return "", d.workRequestError(ctx, id, res.Status)
+		}
+		return string(res.Status), nil
+	}
+}
+
+// workRequestError returns an error for a work request that did not succeed,
+// with the messages of the errors it reported.
+func (d *driverOCI) workRequestError(ctx context.Context, id string, status workrequests.WorkRequestStatusEnum) error {
+	var messages []string
+	request := workrequests.ListWorkRequestErrorsRequest{
+		WorkRequestId:   &id,
+		RequestMetadata: requestMetadata,
+	}
+	for {
+		res, err := d.requestClient.ListWorkRequestErrors(ctx, request)
+		if err != nil {
+			log.Printf("[WARN] Failed to list the errors of work request %s: %s", id, newRequestError("ListWorkRequestErrors", &id, err))
+			break
+		}
+		for _, e := range res.Items {
+			messages = append(messages, fmt.Sprintf("%s: %s", *e.Code, *e.Message))
+		}
+		if res.OpcNextPage == nil {
+			break
+		}
+		request.Page = res.OpcNextPage
+	}
+
+	if len(messages) == 0 {
+		return fmt.Errorf("work request %s %s", id, strings.ToLower(string(status)))
+	}
+	return fmt.Errorf("work request %s %s: %s", id, strings.ToLower(string(status)), strings.Join(messages, "; "))
+}
+
+// WaitForVnicAttachmentState waits for a VNIC attachment to reach a given
+// terminal state.
+func (d *driverOCI) WaitForVnicAttachmentState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+	ctx, cancel := d.computeContext(ctx)
+	defer cancel()
+
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			attachment, err := d.computeClient.GetVnicAttachment(ctx, core.GetVnicAttachmentRequest{
+				VnicAttachmentId: &id,
+				RequestMetadata:  requestMetadata,
+			})
+			if err != nil {
+				return "", newRequestError("GetVnicAttachment", &id, err)
+			}
+			return string(attachment.LifecycleState), nil
+		},
+		id,
 		waitStates,
 		terminalState,
-		0,             //Unlimited Retries
-		5*time.Second, //5 second wait between retries
+		0, //Unlimited Retries
+		d.cfg.Timeouts.PollingInterval,
 	)
 }
 
+// namespace returns the given Object Storage namespace, or the namespace of
+// the tenancy if it is empty.
+func (d *driverOCI) namespace(ctx context.Context, namespace string) (string, error) {
+	if namespace != "" {
+		return namespace, nil
+	}
+
+	res, err := d.objectClient.GetNamespace(ctx, objectstorage.GetNamespaceRequest{
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", newRequestError("GetNamespace", nil, err)
+	}
+	return *res.Value, nil
+}
+
+// regionComputeClient returns a copy of the compute client for another
+// region, checking that its endpoint is FIPS compliant in FIPS mode.
+func (d *driverOCI) regionComputeClient(region string) (core.ComputeClient, error) {
+	client := d.computeClient
+	client.SetRegion(region)
+	if d.cfg.FIPSMode {
+		if err := verifyFIPSEndpoints(region, client.Endpoint()); err != nil {
+			return core.ComputeClient{}, err
+		}
+	}
+	return client, nil
+}
+
+// computeContext bounds ctx by the configured compute operation timeout.
+func (d *driverOCI) computeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, d.cfg.Timeouts.Compute)
+}
+
+// networkContext bounds ctx by the configured networking operation timeout.
+func (d *driverOCI) networkContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, d.cfg.Timeouts.Network)
+}
+
+// objectStorageContext bounds ctx by the configured Object Storage operation
+// timeout.
+func (d *driverOCI) objectStorageContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, d.cfg.Timeouts.ObjectStorage)
+}
+
+// withTimeout returns a copy of ctx that is cancelled after timeout, or only
+// when the returned cancel func is called if timeout is zero.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 // WaitForResourceToReachState checks the response of a request through a
-// polled get and waits until the desired state or until the max retried has
-// been reached.
-func waitForResourceToReachState(getResourceState func(string) (string, error), id string, waitStates []string, terminalState string, maxRetries int, waitDuration time.Duration) error {
+// polled get and waits until the desired state, until the max retried has
+// been reached or until ctx is done.
+func waitForResourceToReachState(ctx context.Context, getResourceState func(string) (string, error), id string, waitStates []string, terminalState string, maxRetries int, waitDuration time.Duration) error {
+	return waitForResourceToReachStateWithInterval(ctx, getResourceState, id, waitStates, terminalState, maxRetries, constantInterval(waitDuration))
+}
+
+// waitForResourceToReachStateWithInterval behaves like
+// waitForResourceToReachState, but asks interval how long to wait before each
+// poll given the time elapsed since the wait started.
+func waitForResourceToReachStateWithInterval(ctx context.Context, getResourceState func(string) (string, error), id string, waitStates []string, terminalState string, maxRetries int, interval func(time.Duration) time.Duration) error {
+	start := time.Now()
 	for i := 0; maxRetries == 0 || i < maxRetries; i++ {
 		state, err := getResourceState(id)
 		if err != nil {
@@ -443,7 +2939,11 @@ func waitForResourceToReachState(getResourceState func(string) (string, error),
 		}
 
 		if stringSliceContains(waitStates, state) {
-			time.Sleep(waitDuration)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("timed out waiting for resource to reach state %q (last state %q): %w", terminalState, state, ctx.Err())
+			case <-time.After(interval(time.Since(start))):
+			}
 			continue
 		} else if state == terminalState {
 			return nil
@@ -453,6 +2953,71 @@ func waitForResourceToReachState(getResourceState func(string) (string, error),
 	return fmt.Errorf("maximum number of retries (%d) exceeded; resource did not reach state %q", maxRetries, terminalState)
 }
 
+// waitAdaptively waits for a resource to reach a given terminal state. With
+// adaptive polling enabled, the polling interval follows the duration of the
+// operation identified by key in previous builds, polling every base near the
+// expected completion, and the duration of a successful wait is recorded for
+// the next ones.
+func (d *driverOCI) waitAdaptively(ctx context.Context, key string, base time.Duration, getResourceState func(string) (string, error), id string, waitStates []string, terminalState string) error {
+	if !d.cfg.Timeouts.AdaptivePolling {
+		return waitForResourceToReachState(ctx, getResourceState, id, waitStates, terminalState, 0, base)
+	}
+
+	cache := sharedDurationCache(d.cfg.Timeouts.AdaptivePollingCache)
+	interval := constantInterval(base)
+	if expected, ok := cache.Expected(key); ok {
+		log.Printf("Expecting %s to take about %s", key, expected.Round(time.Second))
+		interval = adaptiveInterval(base, expected)
+	}
+
+	start := time.Now()
+	if err := waitForResourceToReachStateWithInterval(ctx, getResourceState, id, waitStates, terminalState, 0, interval); err != nil {
+		return err
+	}
+	cache.Record(key, time.Since(start))
+	return nil
+}
+
+// imageHasTags returns whether an image has the freeform and defined tags of
+// a base image filter. ListImages cannot filter by tags, so they are matched
+// on the listed images.
+func imageHasTags(image core.Image, filter ListImagesRequest) bool {
+	for key, value := range filter.FreeformTags {
+		if actual, ok := image.FreeformTags[key]; !ok || actual != value {
+			return false
+		}
+	}
+	for key, value := range filter.DefinedTags {
+		namespace, name, _ := strings.Cut(key, ".")
+		actual, ok := image.DefinedTags[namespace][name]
+		if !ok || fmt.Sprint(actual) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// displayNameVersion returns the version extracted from the display name of
+// an image by the capture groups of re, joined with dots, such as 8.9.2024.1.26
+// for Oracle-Linux-8.9-2024.01.26-0 and `-([0-9.]+)-([0-9.]+)-`.
+func displayNameVersion(re *regexp.Regexp, name string) (*version.Version, bool) {
+	match := re.FindStringSubmatch(name)
+	if match == nil {
+		return nil, false
+	}
+	var segments []string
+	for _, group := range match[1:] {
+		if group != "" {
+			segments = append(segments, group)
+		}
+	}
+	v, err := version.NewVersion(strings.Join(segments, "."))
+	if err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
 // stringSliceContains loops through a slice of strings returning a boolean
 // based on whether a given value is contained in the slice.
 func stringSliceContains(slice []string, value string) bool {
@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	ocicommon "github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestInstanceWaitStates(t *testing.T) {
+	for _, terminal := range []string{"RUNNING", "STOPPED", "TERMINATED"} {
+		states := InstanceWaitStates(terminal)
+		if len(states) == 0 {
+			t.Errorf("Expected wait states for %s", terminal)
+		}
+		if stringSliceContains(states, terminal) {
+			t.Errorf("Wait states for %s should not contain it: %v", terminal, states)
+		}
+	}
+
+	if states := InstanceWaitStates("UNKNOWN"); states != nil {
+		t.Errorf("Expected no wait states for an unknown state, got %v", states)
+	}
+}
+
+func TestImageHasTags(t *testing.T) {
+	image := core.Image{
+		FreeformTags: map[string]string{"approved": "true", "team": "platform"},
+		DefinedTags:  map[string]map[string]interface{}{"Security": {"approved": true, "level": "high"}},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		filter   ListImagesRequest
+		expected bool
+	}{
+		{"NoTags", ListImagesRequest{}, true},
+		{"FreeformTag", ListImagesRequest{FreeformTags: map[string]string{"approved": "true"}}, true},
+		{"FreeformTagValue", ListImagesRequest{FreeformTags: map[string]string{"approved": "false"}}, false},
+		{"FreeformTagMissing", ListImagesRequest{FreeformTags: map[string]string{"owner": "me"}}, false},
+		{"DefinedTag", ListImagesRequest{DefinedTags: map[string]string{"Security.approved": "true", "Security.level": "high"}}, true},
+		{"DefinedTagValue", ListImagesRequest{DefinedTags: map[string]string{"Security.level": "low"}}, false},
+		{"DefinedTagNamespace", ListImagesRequest{DefinedTags: map[string]string{"Operations.approved": "true"}}, false},
+		{"Both", ListImagesRequest{
+			FreeformTags: map[string]string{"team": "platform"},
+			DefinedTags:  map[string]string{"Security.approved": "true"},
+		}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := imageHasTags(image, tc.filter); actual != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDisplayNameVersion(t *testing.T) {
+	re := regexp.MustCompile(`^Oracle-Linux-([0-9.]+)-([0-9]{4})\.([0-9]{2})\.([0-9]{2})-([0-9]+)$`)
+
+	for name, expected := range map[string]string{
+		"Oracle-Linux-8.9-2024.01.26-0":       "8.9.2024.1.26.0",
+		"Oracle-Linux-8.10-2023.06.30-1":      "8.10.2023.6.30.1",
+		"Oracle-Linux-8.9-GPU-2024.01.26":     "",
+		"Canonical-Ubuntu-22.04-2024.01.12-0": "",
+	} {
+		v, ok := displayNameVersion(re, name)
+		if expected == "" {
+			if ok {
+				t.Errorf("Expected no version for %q, got %s", name, v)
+			}
+			continue
+		}
+		if !ok || !v.Equal(version.Must(version.NewVersion(expected))) {
+			t.Errorf("Expected version %s for %q, got %v", expected, name, v)
+		}
+	}
+
+	// 8.10 is a higher version than 8.9, even when published earlier
+	older, _ := displayNameVersion(re, "Oracle-Linux-8.9-2024.01.26-0")
+	newer, _ := displayNameVersion(re, "Oracle-Linux-8.10-2023.06.30-1")
+	if !newer.GreaterThan(older) {
+		t.Errorf("Expected %s to be greater than %s", newer, older)
+	}
+}
+
+func TestDriverOCI_GetObjectSHA256_slowBody(t *testing.T) {
+	// The body takes several times request_timeout to be sent
+	chunk := strings.Repeat("a", 64*1024)
+	const chunks = 8
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/n/ns/b/images/o/golden" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		for i := 0; i < chunks; i++ {
+			w.Write([]byte(chunk))
+			w.(http.Flusher).Flush()
+			time.Sleep(50 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	d := testDriverOCI(t, &Config{HTTPClient: HTTPClientConfig{RequestTimeout: 100 * time.Millisecond}})
+	d.objectClient.Host = server.URL
+	d.downloadClient.Host = server.URL
+
+	digest, err := d.GetObjectSHA256(context.Background(), ImageExportConfig{Namespace: "ns", Bucket: "images", ObjectName: "golden"})
+	if err != nil {
+		t.Fatalf("should've read the whole object: %s", err)
+	}
+	sum := sha256.Sum256([]byte(strings.Repeat(chunk, chunks)))
+	if digest != hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected digest: %s", digest)
+	}
+}
+
+func TestDriverOCI_Endpoints(t *testing.T) {
+	d := testDriverOCI(t, &Config{})
+
+	endpoints := d.Endpoints()
+	for _, want := range []string{
+		d.computeClient.Endpoint(),
+		d.objectClient.Endpoint(),
+		d.marketClient.Endpoint(),
+	} {
+		found := false
+		for _, endpoint := range endpoints {
+			found = found || endpoint == want
+		}
+		if !found {
+			t.Errorf("Expected endpoint %s to be listed, got %v", want, endpoints)
+		}
+	}
+}
+
+// testDriverOCI returns a driver for cfg authenticating with a generated key
+// in us-ashburn-1.
+func testDriverOCI(t *testing.T, cfg *Config) *driverOCI {
+	keyFile, err := generateRSAKeyFile()
+	if err != nil {
+		t.Fatalf("Unable to generate key: %s", err)
+	}
+	defer os.Remove(keyFile.Name())
+	key, err := os.ReadFile(keyFile.Name())
+	if err != nil {
+		t.Fatalf("Unable to read key: %s", err)
+	}
+
+	cfg.configProvider = ocicommon.NewRawConfigurationProvider("ocid1.tenancy", "ocid1.user", "us-ashburn-1", "fingerprint", string(key), nil)
+	driver, err := NewDriverOCI(cfg)
+	if err != nil {
+		t.Fatalf("Unable to create driver: %s", err)
+	}
+	return driver.(*driverOCI)
+}
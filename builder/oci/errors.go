@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// RequestError records the OCI API request that failed. Its Error string
+// ends with a bracketed list of key=value pairs so that CI systems can
+// classify failures (e.g. capacity, authorization, quota) from the build
+// output alone.
+type RequestError struct {
+	// Operation is the name of the SDK operation, e.g. "LaunchInstance".
+	Operation string
+	// TargetID is the OCID of the resource the request targeted, if any.
+	TargetID string
+	// StatusCode is the HTTP status code returned by the service, or 0 if
+	// the request never got a response.
+	StatusCode int
+	// ServiceCode is the service error code, e.g. "LimitExceeded".
+	ServiceCode string
+	// OpcRequestID is the opc-request-id Oracle support needs to trace the
+	// request.
+	OpcRequestID string
+
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	msg := e.Err.Error()
+	var se common.ServiceError
+	if errors.As(e.Err, &se) {
+		msg = se.GetMessage()
+	}
+
+	fields := []string{"operation=" + e.Operation}
+	if e.TargetID != "" {
+		fields = append(fields, "target_ocid="+e.TargetID)
+	}
+	if e.StatusCode != 0 {
+		fields = append(fields, fmt.Sprintf("status_code=%d", e.StatusCode))
+	}
+	if e.ServiceCode != "" {
+		fields = append(fields, "service_code="+e.ServiceCode)
+	}
+	if e.OpcRequestID != "" {
+		fields = append(fields, "opc_request_id="+e.OpcRequestID)
+	}
+
+	return fmt.Sprintf("%s [%s]", msg, strings.Join(fields, " "))
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// newRequestError wraps err, as returned by the SDK operation, in a
+// RequestError. It returns nil if err is nil.
+func newRequestError(operation string, targetID *string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	reqErr := &RequestError{
+		Operation: operation,
+		Err:       err,
+	}
+	if targetID != nil {
+		reqErr.TargetID = *targetID
+	}
+
+	var se common.ServiceError
+	if errors.As(err, &se) {
+		reqErr.StatusCode = se.GetHTTPStatusCode()
+		reqErr.ServiceCode = se.GetCode()
+		reqErr.OpcRequestID = se.GetOpcRequestID()
+	}
+
+	return reqErr
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"errors"
+	"testing"
+)
+
+type testServiceError struct {
+	statusCode   int
+	code         string
+	message      string
+	opcRequestID string
+}
+
+func (e testServiceError) Error() string          { return e.code + ": " + e.message }
+func (e testServiceError) GetHTTPStatusCode() int { return e.statusCode }
+func (e testServiceError) GetMessage() string     { return e.message }
+func (e testServiceError) GetCode() string        { return e.code }
+func (e testServiceError) GetOpcRequestID() string {
+	return e.opcRequestID
+}
+
+func TestNewRequestError_Nil(t *testing.T) {
+	if err := newRequestError("LaunchInstance", nil, nil); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func TestNewRequestError_ServiceError(t *testing.T) {
+	target := "ocid1.compartment.oc1..aaa"
+	serviceErr := testServiceError{
+		statusCode:   500,
+		code:         "InternalError",
+		message:      "Out of host capacity.",
+		opcRequestID: "ABC123",
+	}
+
+	err := newRequestError("LaunchInstance", &target, serviceErr)
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected a *RequestError, got %T", err)
+	}
+	if reqErr.Operation != "LaunchInstance" || reqErr.TargetID != target ||
+		reqErr.StatusCode != 500 || reqErr.ServiceCode != "InternalError" || reqErr.OpcRequestID != "ABC123" {
+		t.Fatalf("unexpected request error fields: %+v", reqErr)
+	}
+	if !errors.Is(err, serviceErr) {
+		t.Fatalf("expected request error to wrap the service error")
+	}
+
+	expected := "Out of host capacity. [operation=LaunchInstance target_ocid=ocid1.compartment.oc1..aaa status_code=500 service_code=InternalError opc_request_id=ABC123]"
+	if err.Error() != expected {
+		t.Fatalf("unexpected error string:\n got: %s\nwant: %s", err.Error(), expected)
+	}
+}
+
+func TestNewRequestError_NonServiceError(t *testing.T) {
+	err := newRequestError("GetInstance", nil, errors.New("connection reset"))
+
+	expected := "connection reset [operation=GetInstance]"
+	if err.Error() != expected {
+		t.Fatalf("unexpected error string:\n got: %s\nwant: %s", err.Error(), expected)
+	}
+}
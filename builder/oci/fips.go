@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"strings"
+
+	ocicommon "github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// fipsCipherSuites are the FIPS 140 approved cipher suites offered in FIPS
+// mode.
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// applyFIPSTLSConfig restricts a TLS configuration to FIPS approved
+// settings. TLS 1.3 is disabled because Go does not allow its cipher suites
+// to be restricted.
+func applyFIPSTLSConfig(cfg *tls.Config) {
+	cfg.MinVersion = tls.VersionTLS12
+	cfg.MaxVersion = tls.VersionTLS12
+	cfg.CipherSuites = fipsCipherSuites
+	cfg.CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+}
+
+// verifyFIPSEndpoints checks that every endpoint is reached over HTTPS within
+// the domain of the realm of region, so that no call leaves the realm the
+// build is scoped to.
+func verifyFIPSEndpoints(region string, endpoints ...string) error {
+	r := ocicommon.StringToRegion(region)
+	realmID, err := r.RealmID()
+	if err != nil {
+		return fmt.Errorf("fips_mode requires a region of a known realm: %s", err)
+	}
+
+	// Endpoint is formatted as <service>.<region>.<realm domain>
+	prefix := "service." + string(r) + "."
+	domain := strings.TrimPrefix(r.Endpoint("service"), prefix)
+
+	for _, endpoint := range endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return fmt.Errorf("fips_mode: invalid endpoint %q: %s", endpoint, err)
+		}
+		if u.Scheme != "https" {
+			return fmt.Errorf("fips_mode: endpoint %q does not use https", endpoint)
+		}
+		if !strings.HasSuffix(u.Hostname(), "."+domain) {
+			return fmt.Errorf("fips_mode: endpoint %q is outside of realm %s (%s)", endpoint, realmID, domain)
+		}
+	}
+	return nil
+}
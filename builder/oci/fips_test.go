@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import "testing"
+
+func TestVerifyFIPSEndpoints(t *testing.T) {
+	cases := []struct {
+		name     string
+		region   string
+		endpoint string
+		ok       bool
+	}{
+		{"commercial", "us-ashburn-1", "https://iaas.us-ashburn-1.oraclecloud.com", true},
+		{"government", "us-langley-1", "https://iaas.us-langley-1.oraclegovcloud.com", true},
+		{"other realm", "us-langley-1", "https://iaas.us-ashburn-1.oraclecloud.com", false},
+		{"plain http", "us-ashburn-1", "http://iaas.us-ashburn-1.oraclecloud.com", false},
+		{"unknown realm", "xx-nowhere-1", "https://iaas.xx-nowhere-1.oraclecloud.com", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyFIPSEndpoints(tc.region, tc.endpoint)
+			if tc.ok && err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !tc.ok && err == nil {
+				t.Fatal("Expected an error")
+			}
+		})
+	}
+}
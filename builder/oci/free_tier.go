@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Always Free limits of a tenancy, see
+// https://docs.oracle.com/iaas/Content/FreeTier/freetier_topic-Always_Free_Resources.htm
+const (
+	freeTierAmpereShape       = "VM.Standard.A1.Flex"
+	freeTierMicroShape        = "VM.Standard.E2.1.Micro"
+	freeTierAmpereOcpus       = 4
+	freeTierAmpereMemoryInGBs = 24
+	freeTierBlockStorageInGBs = 200
+
+	// defaultBootVolumeSizeInGBs is counted against the block storage limit
+	// when disk_size is unset.
+	defaultBootVolumeSizeInGBs = 50
+)
+
+// prepareFreeTier defaults the shape to an Always Free one and checks that
+// the build stays within the Always Free limits.
+func (c *Config) prepareFreeTier() []error {
+	var errs []error
+
+	if c.Shape == "" {
+		c.Shape = freeTierAmpereShape
+		if c.ShapeConfig.Ocpus == nil {
+			ocpus, memory := float32(1), float32(6)
+			c.ShapeConfig.Ocpus = &ocpus
+			c.ShapeConfig.MemoryInGBs = &memory
+		}
+	}
+
+	switch c.Shape {
+	case freeTierMicroShape:
+	case freeTierAmpereShape:
+		for key, shapeConfig := range map[string]FlexShapeConfig{
+			"shape_config":         c.ShapeConfig,
+			"capture_shape_config": c.CaptureShapeConfig,
+		} {
+			if shapeConfig.Ocpus != nil && *shapeConfig.Ocpus > freeTierAmpereOcpus {
+				errs = append(errs, fmt.Errorf("'%s.ocpus' must be at most %d with 'free_tier'", key, freeTierAmpereOcpus))
+			}
+			if shapeConfig.MemoryInGBs != nil && *shapeConfig.MemoryInGBs > freeTierAmpereMemoryInGBs {
+				errs = append(errs, fmt.Errorf("'%s.memory_in_gbs' must be at most %d with 'free_tier'", key, freeTierAmpereMemoryInGBs))
+			}
+		}
+	default:
+		errs = append(errs, fmt.Errorf("'shape' must be one of %s with 'free_tier', got %q",
+			strings.Join([]string{freeTierAmpereShape, freeTierMicroShape}, ", "), c.Shape))
+	}
+
+	if c.IsPreemptible {
+		errs = append(errs, errors.New("'is_preemptible' is not available with 'free_tier'"))
+	}
+
+	storage := c.BootVolumeSizeInGBs
+	if storage == 0 {
+		storage = defaultBootVolumeSizeInGBs
+	}
+	for _, volume := range c.BlockVolumes {
+		storage += volume.SizeInGBs
+	}
+	if storage > freeTierBlockStorageInGBs {
+		errs = append(errs, fmt.Errorf("the boot volume and 'block_volumes' add up to %d GBs, more than the %d GBs of block storage available with 'free_tier'",
+			storage, freeTierBlockStorageInGBs))
+	}
+
+	return errs
+}
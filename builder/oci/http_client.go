@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tlsSessionCacheSize is the number of TLS sessions kept for resumption by a
+// shared HTTP client.
+const tlsSessionCacheSize = 64
+
+// httpClientKey identifies a shared HTTP client.
+type httpClientKey struct {
+	HTTPClientConfig
+	fips bool
+}
+
+var (
+	httpClientsMu sync.Mutex
+	httpClients   = map[httpClientKey]*http.Client{}
+)
+
+// sharedHTTPClient returns the HTTP client used for OCI API calls with the
+// given tunables. Clients are shared by every driver in the process, so
+// parallel builds reuse pooled connections and TLS sessions instead of each
+// opening their own. In FIPS mode the client only negotiates FIPS approved
+// TLS settings.
+func sharedHTTPClient(cfg HTTPClientConfig, fips bool) *http.Client {
+	httpClientsMu.Lock()
+	defer httpClientsMu.Unlock()
+
+	key := httpClientKey{cfg, fips}
+	if client, ok := httpClients[key]; ok {
+		return client
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ExpectContinueTimeout: 3 * time.Second,
+		TLSClientConfig:       &tls.Config{},
+	}
+	if !cfg.DisableTLSSessionReuse {
+		transport.TLSClientConfig.ClientSessionCache = tls.NewLRUClientSessionCache(tlsSessionCacheSize)
+	}
+	if fips {
+		applyFIPSTLSConfig(transport.TLSClientConfig)
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   cfg.RequestTimeout,
+	}
+	httpClients[key] = client
+	return client
+}
+
+// downloadHTTPClient returns an HTTP client sharing the connections of
+// sharedHTTPClient without its request timeout, for response bodies too large
+// to be read within request_timeout. Requests are bounded by their context.
+func downloadHTTPClient(cfg HTTPClientConfig, fips bool) *http.Client {
+	return &http.Client{Transport: sharedHTTPClient(cfg, fips).Transport}
+}
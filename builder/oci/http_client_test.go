@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSharedHTTPClient(t *testing.T) {
+	cfg := HTTPClientConfig{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+		RequestTimeout:      60 * time.Second,
+	}
+
+	client := sharedHTTPClient(cfg, false)
+	if client != sharedHTTPClient(cfg, false) {
+		t.Fatal("Expected the same client for the same tunables")
+	}
+
+	transport := client.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 10 {
+		t.Errorf("Expected MaxIdleConnsPerHost 10, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.TLSClientConfig.ClientSessionCache == nil {
+		t.Error("Expected TLS session reuse to be enabled")
+	}
+
+	cfg.DisableTLSSessionReuse = true
+	other := sharedHTTPClient(cfg, false)
+	if other == client {
+		t.Fatal("Expected a different client for different tunables")
+	}
+	if other.Transport.(*http.Transport).TLSClientConfig.ClientSessionCache != nil {
+		t.Error("Expected TLS session reuse to be disabled")
+	}
+
+	fips := sharedHTTPClient(cfg, true)
+	if fips == other {
+		t.Fatal("Expected a different client in FIPS mode")
+	}
+	if fips.Transport.(*http.Transport).TLSClientConfig.MaxVersion != tls.VersionTLS12 {
+		t.Error("Expected FIPS mode to restrict TLS to 1.2")
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	core "github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// Capabilities set by dedicated options rather than image_capabilities.
+const (
+	iscsiMultipathCapability                      = "Storage.Iscsi.MultipathDeviceSupported"
+	consistentVolumeNamingCapability              = "Storage.ConsistentVolumeNaming"
+	paravirtualizationAttachmentVersionCapability = "Storage.ParaVirtualization.AttachmentVersion"
+	firmwareCapability                            = "Compute.Firmware"
+)
+
+// applyImageCapabilities sets the default values of image_capabilities in the
+// capability schema data of an image. A value is parsed according to the
+// type of the capability in the schema, and must be one of the values an
+// enum capability allows.
+func applyImageCapabilities(schemaData map[string]core.ImageCapabilitySchemaDescriptor, capabilities map[string]string) error {
+	keys := make([]string, 0, len(capabilities))
+	for key := range capabilities {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := capabilities[key]
+		switch descriptor := schemaData[key].(type) {
+		case core.BooleanImageCapabilitySchemaDescriptor:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("image capability %s must be true or false, got %q", key, value)
+			}
+			schemaData[key] = core.BooleanImageCapabilitySchemaDescriptor{Source: "IMAGE", DefaultValue: &b}
+		case core.EnumStringImageCapabilitySchemaDescriptor:
+			if !stringSliceContains(descriptor.Values, value) {
+				return fmt.Errorf("image capability %s must be one of %v, got %q", key, descriptor.Values, value)
+			}
+			schemaData[key] = core.EnumStringImageCapabilitySchemaDescriptor{Source: "IMAGE", DefaultValue: &value, Values: descriptor.Values}
+		case core.EnumIntegerImageCapabilityDescriptor:
+			n, err := strconv.Atoi(value)
+			if err != nil || !intSliceContains(descriptor.Values, n) {
+				return fmt.Errorf("image capability %s must be one of %v, got %q", key, descriptor.Values, value)
+			}
+			schemaData[key] = core.EnumIntegerImageCapabilityDescriptor{Source: "IMAGE", DefaultValue: &n, Values: descriptor.Values}
+		case nil:
+			return fmt.Errorf("unknown image capability %s", key)
+		default:
+			return fmt.Errorf("image capability %s has unsupported type %T", key, descriptor)
+		}
+	}
+
+	return nil
+}
+
+// intSliceContains reports whether a slice of ints contains a given value.
+func intSliceContains(slice []int, value int) bool {
+	for _, v := range slice {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
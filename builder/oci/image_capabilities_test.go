@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"strings"
+	"testing"
+
+	core "github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func testSchemaData() map[string]core.ImageCapabilitySchemaDescriptor {
+	return map[string]core.ImageCapabilitySchemaDescriptor{
+		"Compute.SecureBoot": core.BooleanImageCapabilitySchemaDescriptor{Source: "IMAGE"},
+		"Storage.BootVolumeType": core.EnumStringImageCapabilitySchemaDescriptor{
+			Source: "IMAGE",
+			Values: []string{"ISCSI", "SCSI", "IDE", "PARAVIRTUALIZED"},
+		},
+		"Compute.MaxVcpus": core.EnumIntegerImageCapabilityDescriptor{
+			Source: "IMAGE",
+			Values: []int{8, 16, 32},
+		},
+	}
+}
+
+func TestApplyImageCapabilities(t *testing.T) {
+	schemaData := testSchemaData()
+	err := applyImageCapabilities(schemaData, map[string]string{
+		"Compute.SecureBoot":     "true",
+		"Storage.BootVolumeType": "PARAVIRTUALIZED",
+		"Compute.MaxVcpus":       "16",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if b := schemaData["Compute.SecureBoot"].(core.BooleanImageCapabilitySchemaDescriptor); b.DefaultValue == nil || !*b.DefaultValue {
+		t.Errorf("Expected Compute.SecureBoot to default to true, got %#v", b)
+	}
+	if e := schemaData["Storage.BootVolumeType"].(core.EnumStringImageCapabilitySchemaDescriptor); e.DefaultValue == nil || *e.DefaultValue != "PARAVIRTUALIZED" || len(e.Values) != 4 {
+		t.Errorf("Expected Storage.BootVolumeType to default to PARAVIRTUALIZED, got %#v", e)
+	}
+	if e := schemaData["Compute.MaxVcpus"].(core.EnumIntegerImageCapabilityDescriptor); e.DefaultValue == nil || *e.DefaultValue != 16 {
+		t.Errorf("Expected Compute.MaxVcpus to default to 16, got %#v", e)
+	}
+}
+
+func TestApplyImageCapabilities_invalid(t *testing.T) {
+	tests := []struct {
+		key, value, expected string
+	}{
+		{"Compute.SecureBoot", "yes", "must be true or false"},
+		{"Storage.BootVolumeType", "NVME", "must be one of [ISCSI SCSI IDE PARAVIRTUALIZED]"},
+		{"Compute.MaxVcpus", "12", "must be one of [8 16 32]"},
+		{"Compute.Unknown", "true", "unknown image capability Compute.Unknown"},
+	}
+
+	for _, tt := range tests {
+		err := applyImageCapabilities(testSchemaData(), map[string]string{tt.key: tt.value})
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s = %s: expected %q error, got %v", tt.key, tt.value, tt.expected, err)
+		}
+	}
+}
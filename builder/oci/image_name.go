@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// buildCounterPlaceholder stands for {{ .BuildCounter }} in the image name
+// until stepImageName looked up the counter.
+const buildCounterPlaceholder = "{{ .BuildCounter }}"
+
+// Freeform tags persisting the build counter on images.
+const (
+	buildCounterTag = "packer_build_counter"
+	buildSeriesTag  = "packer_build_series"
+)
+
+// imageNameData is the data available when rendering image_name.
+type imageNameData struct {
+	// GitCommit and GitShortCommit are the current commit of the repository
+	// Packer runs from.
+	GitCommit      string
+	GitShortCommit string
+	// GitBranch is the current branch of the repository Packer runs from.
+	GitBranch string
+	// BuildCounter is one more than the highest counter of the images built
+	// from the same image_name template.
+	BuildCounter string
+}
+
+func newImageNameData(template string) *imageNameData {
+	data := &imageNameData{BuildCounter: buildCounterPlaceholder}
+	if strings.Contains(template, ".Git") {
+		data.GitCommit = gitOutput("rev-parse", "HEAD")
+		data.GitShortCommit = gitOutput("rev-parse", "--short", "HEAD")
+		data.GitBranch = gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+	}
+	return data
+}
+
+// gitOutput runs git in the working directory and returns its trimmed
+// output, or an empty string if git fails.
+func gitOutput(args ...string) string {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		log.Printf("[WARN] Unable to read git metadata (git %s): %s", strings.Join(args, " "), err)
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// imageName returns the name of the image of the current build attempt.
+func (c *Config) imageName() string {
+	if c.attemptImageName != "" {
+		return c.attemptImageName
+	}
+	return c.ImageName
+}
+
+// imageTags returns the freeform tags of the image of the current build
+// attempt.
+func (c *Config) imageTags() map[string]string {
+	if c.attemptImageTags != nil {
+		return c.attemptImageTags
+	}
+	return c.Tags
+}
+
+// resetAttempt forgets the image name and tags rendered by a previous build
+// attempt.
+func (c *Config) resetAttempt() {
+	c.attemptImageName = ""
+	c.attemptImageTags = nil
+}
+
+// usesBuildCounter reports whether the image name contains the build counter.
+func (c *Config) usesBuildCounter() bool {
+	return strings.Contains(c.ImageName, buildCounterPlaceholder)
+}
+
+// buildSeries identifies the images sharing a build counter, which are the
+// images built from the same image_name template.
+func (c *Config) buildSeries() string {
+	sum := sha256.Sum256([]byte(c.imageNameTemplate))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Schemes of the version image_version_scheme appends to the image name.
+const (
+	imageVersionTimestamp = "timestamp"
+	imageVersionCounter   = "counter"
+	imageVersionSemver    = "semver"
+)
+
+// imageVersionTimestampLayout formats the versions of the timestamp scheme,
+// which sort in the order they were built.
+const imageVersionTimestampLayout = "20060102150405"
+
+// nextImageVersion returns the version to append to the image name base,
+// greater than the versions of the existing images named after base. The
+// semver scheme uses the given version, and fails if it is not greater.
+func nextImageVersion(scheme string, base string, semver string, names []string, now time.Time) (string, error) {
+	var existing []string
+	for _, name := range names {
+		if strings.HasPrefix(name, base+"-") {
+			existing = append(existing, strings.TrimPrefix(name, base+"-"))
+		}
+	}
+
+	switch scheme {
+	case imageVersionTimestamp:
+		next := now.UTC().Truncate(time.Second)
+		for _, v := range existing {
+			t, err := time.Parse(imageVersionTimestampLayout, v)
+			if err == nil && !next.After(t) {
+				next = t.Add(time.Second)
+			}
+		}
+		return next.Format(imageVersionTimestampLayout), nil
+	case imageVersionCounter:
+		latest := 0
+		for _, v := range existing {
+			if n, err := strconv.Atoi(v); err == nil && n > latest {
+				latest = n
+			}
+		}
+		return strconv.Itoa(latest + 1), nil
+	case imageVersionSemver:
+		next, err := version.NewSemver(semver)
+		if err != nil {
+			return "", err
+		}
+		for _, v := range existing {
+			if other, err := version.NewSemver(v); err == nil && !next.GreaterThan(other) {
+				return "", fmt.Errorf("version %s is not greater than the version %s of an existing image", semver, v)
+			}
+		}
+		return semver, nil
+	}
+
+	return "", fmt.Errorf("unknown image version scheme %q", scheme)
+}
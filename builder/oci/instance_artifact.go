@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+)
+
+// InstanceArtifact is an artifact implementation that contains a configured,
+// running instance.
+type InstanceArtifact struct {
+	InstanceID string
+	// IP is the address Packer connected to the instance with.
+	IP string
+	// PrivateIP and PublicIP are the addresses of the primary VNIC of the
+	// instance. PublicIP is empty when the instance has none.
+	PrivateIP string
+	PublicIP  string
+	Region    string
+	driver    Driver
+
+	// StateData should store data such as GeneratedData
+	// to be shared with post-processors
+	StateData map[string]interface{}
+}
+
+// BuilderId uniquely identifies the builder.
+func (a *InstanceArtifact) BuilderId() string {
+	return InstanceBuilderId
+}
+
+// Files lists the files associated with an artifact. We don't have any files
+// as the instance runs server side.
+func (a *InstanceArtifact) Files() []string {
+	return nil
+}
+
+// Id returns the OCID of the associated instance.
+func (a *InstanceArtifact) Id() string {
+	return a.InstanceID
+}
+
+func (a *InstanceArtifact) String() string {
+	s := fmt.Sprintf(
+		"An instance is running: %v (IP: %v) in region '%v'",
+		a.InstanceID, a.IP, a.Region,
+	)
+	s += fmt.Sprintf("\nPrivate IP: %v", a.PrivateIP)
+	if a.PublicIP != "" {
+		s += fmt.Sprintf("\nPublic IP: %v", a.PublicIP)
+	}
+	return s
+}
+
+func (a *InstanceArtifact) State(name string) interface{} {
+	return a.StateData[name]
+}
+
+// Destroy terminates the instance associated with the artifact.
+func (a *InstanceArtifact) Destroy() error {
+	return a.driver.TerminateInstance(context.TODO(), a.InstanceID)
+}
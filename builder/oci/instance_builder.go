@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// InstanceBuilderId uniquely identifies the running instance builder
+const InstanceBuilderId = "packer.oracle.oci-instance"
+
+// InstanceBuilder is a builder implementation whose artifact is the
+// configured, running instance rather than a custom image. It shares its
+// configuration with Builder.
+type InstanceBuilder struct {
+	Builder
+}
+
+func (b *InstanceBuilder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook) (packersdk.Artifact, error) {
+	b.keepInstance = true
+
+	driver, state, err := b.run(ctx, ui, hook)
+	if err != nil {
+		return nil, err
+	}
+
+	region, err := b.config.configProvider.Region()
+	if err != nil {
+		return nil, err
+	}
+
+	instanceID := state.Get("instance_id").(string)
+	privateIP, publicIP, err := driver.GetInstanceAddresses(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the addresses of instance %s: %s", instanceID, err)
+	}
+
+	// Build the artifact and return it
+	artifact := &InstanceArtifact{
+		InstanceID: instanceID,
+		IP:         state.Get("instance_ip").(string),
+		PrivateIP:  privateIP,
+		PublicIP:   publicIP,
+		Region:     region,
+		driver:     driver,
+		StateData: gpuStateData(state, map[string]interface{}{
+			"generated_data":      state.Get("generated_data"),
+			"instance_private_ip": privateIP,
+			"instance_public_ip":  publicIP,
+		}),
+	}
+
+	return artifact, nil
+}
+
+// keepInstance reports whether the resources making up the instance must be
+// kept rather than cleaned up, which is the case once an InstanceBuilder
+// build succeeded.
+func keepInstance(state multistep.StateBag) bool {
+	if keep, ok := state.GetOk("keep_instance"); !ok || !keep.(bool) {
+		return false
+	}
+
+	for _, key := range []string{"error", multistep.StateCancelled, multistep.StateHalted} {
+		if _, ok := state.GetOk(key); ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func TestInstanceBuilder_ImplementsBuilder(t *testing.T) {
+	var raw interface{}
+	raw = &InstanceBuilder{}
+	if _, ok := raw.(packersdk.Builder); !ok {
+		t.Fatalf("InstanceBuilder should be a builder")
+	}
+}
+
+func TestInstanceArtifactImpl(t *testing.T) {
+	var raw interface{}
+	raw = &InstanceArtifact{}
+	if _, ok := raw.(packersdk.Artifact); !ok {
+		t.Fatalf("InstanceArtifact should be artifact")
+	}
+}
+
+func TestStepCreateInstance_KeepInstance(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	state.Put("keep_instance", true)
+
+	step := new(stepCreateInstance)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	step.Cleanup(state)
+
+	if driver.TerminateInstanceID != "" {
+		t.Fatalf("should NOT have terminated a kept instance")
+	}
+
+	// A failed build never keeps the instance
+	state.Put("error", errors.New("error"))
+
+	step.Cleanup(state)
+
+	if driver.TerminateInstanceID == "" {
+		t.Fatalf("should've terminated the instance of a failed build")
+	}
+}
+
+func TestInstanceBuilder_steps(t *testing.T) {
+	b := &Builder{keepInstance: true}
+
+	for _, step := range b.steps() {
+		switch step.(type) {
+		case *stepImageLock, *stepImageName, *stepImage:
+			t.Fatalf("should not have image step %T", step)
+		}
+	}
+}
+
+func TestInstanceArtifactString(t *testing.T) {
+	artifact := &InstanceArtifact{
+		InstanceID: "ocid1.instance",
+		IP:         "ip",
+		PrivateIP:  "private_ip",
+		PublicIP:   "ip",
+		Region:     "us-phoenix-1",
+	}
+
+	for _, expected := range []string{"ocid1.instance", "Private IP: private_ip", "Public IP: ip"} {
+		if !strings.Contains(artifact.String(), expected) {
+			t.Errorf("Bad: artifact string %q should include %q", artifact.String(), expected)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/template/interpolate"
+)
+
+// hostnameLabelRe matches valid hostname labels (RFC 952 and RFC 1123).
+var hostnameLabelRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]{0,62}$`)
+
+// instanceNameData is the data available when rendering instance_name, on
+// top of the build functions such as build_name, uuid and timestamp.
+type instanceNameData struct {
+	// SourceImageName is the display name of the base image.
+	SourceImageName string
+}
+
+// usesSourceImageName reports whether the instance name can only be rendered
+// once the base image is known.
+func (c *Config) usesSourceImageName() bool {
+	return strings.Contains(c.instanceNameTemplate, ".SourceImageName")
+}
+
+// renderInstanceName renders instance_name for the given base image.
+func (c *Config) renderInstanceName(sourceImageName string) (string, error) {
+	ctx := c.ctx
+	ctx.Data = &instanceNameData{SourceImageName: sourceImageName}
+	return interpolate.Render(c.instanceNameTemplate, &ctx)
+}
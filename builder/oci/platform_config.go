@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"errors"
+	"fmt"
+
+	core "github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// launchPlatformConfig converts platform_config to the launch details of its
+// platform type. It returns an error for an option the platform type does
+// not support, since the API would otherwise reject the launch.
+func launchPlatformConfig(p PlatformConfig) (core.LaunchInstancePlatformConfig, error) {
+	if p.PercentageOfCoresEnabled != nil && (*p.PercentageOfCoresEnabled < 1 || *p.PercentageOfCoresEnabled > 100) {
+		return nil, errors.New("'platform_config.percentage_of_cores_enabled' must be between 1 and 100")
+	}
+
+	set := map[string]bool{
+		"is_symmetric_multi_threading_enabled": p.IsSymmetricMultiThreadingEnabled != nil,
+		"numa_nodes_per_socket":                p.NumaNodesPerSocket != "",
+		"percentage_of_cores_enabled":          p.PercentageOfCoresEnabled != nil,
+	}
+	// unsupported returns an error for the first of the given options that
+	// is set
+	unsupported := func(names ...string) error {
+		for _, name := range names {
+			if set[name] {
+				return fmt.Errorf("'platform_config.%s' is not supported by platform type %s", name, p.Type)
+			}
+		}
+		return nil
+	}
+	cpuOptions := []string{"is_symmetric_multi_threading_enabled", "numa_nodes_per_socket", "percentage_of_cores_enabled"}
+	invalidNuma := fmt.Errorf("'platform_config.numa_nodes_per_socket' %q is not supported by platform type %s", p.NumaNodesPerSocket, p.Type)
+
+	switch core.LaunchInstancePlatformConfigTypeEnum(p.Type) {
+	case core.LaunchInstancePlatformConfigTypeAmdMilanBm:
+		numa, ok := core.GetMappingAmdMilanBmLaunchInstancePlatformConfigNumaNodesPerSocketEnum(p.NumaNodesPerSocket)
+		if !ok && p.NumaNodesPerSocket != "" {
+			return nil, invalidNuma
+		}
+		return core.AmdMilanBmLaunchInstancePlatformConfig{
+			IsSecureBootEnabled:              p.IsSecureBootEnabled,
+			IsTrustedPlatformModuleEnabled:   p.IsTrustedPlatformModuleEnabled,
+			IsMeasuredBootEnabled:            p.IsMeasuredBootEnabled,
+			IsSymmetricMultiThreadingEnabled: p.IsSymmetricMultiThreadingEnabled,
+			PercentageOfCoresEnabled:         p.PercentageOfCoresEnabled,
+			NumaNodesPerSocket:               numa,
+		}, nil
+	case core.LaunchInstancePlatformConfigTypeAmdRomeBm:
+		numa, ok := core.GetMappingAmdRomeBmLaunchInstancePlatformConfigNumaNodesPerSocketEnum(p.NumaNodesPerSocket)
+		if !ok && p.NumaNodesPerSocket != "" {
+			return nil, invalidNuma
+		}
+		return core.AmdRomeBmLaunchInstancePlatformConfig{
+			IsSecureBootEnabled:              p.IsSecureBootEnabled,
+			IsTrustedPlatformModuleEnabled:   p.IsTrustedPlatformModuleEnabled,
+			IsMeasuredBootEnabled:            p.IsMeasuredBootEnabled,
+			IsSymmetricMultiThreadingEnabled: p.IsSymmetricMultiThreadingEnabled,
+			PercentageOfCoresEnabled:         p.PercentageOfCoresEnabled,
+			NumaNodesPerSocket:               numa,
+		}, nil
+	case core.LaunchInstancePlatformConfigTypeAmdRomeBmGpu:
+		if err := unsupported("percentage_of_cores_enabled"); err != nil {
+			return nil, err
+		}
+		numa, ok := core.GetMappingAmdRomeBmGpuLaunchInstancePlatformConfigNumaNodesPerSocketEnum(p.NumaNodesPerSocket)
+		if !ok && p.NumaNodesPerSocket != "" {
+			return nil, invalidNuma
+		}
+		return core.AmdRomeBmGpuLaunchInstancePlatformConfig{
+			IsSecureBootEnabled:              p.IsSecureBootEnabled,
+			IsTrustedPlatformModuleEnabled:   p.IsTrustedPlatformModuleEnabled,
+			IsMeasuredBootEnabled:            p.IsMeasuredBootEnabled,
+			IsSymmetricMultiThreadingEnabled: p.IsSymmetricMultiThreadingEnabled,
+			NumaNodesPerSocket:               numa,
+		}, nil
+	case core.LaunchInstancePlatformConfigTypeIntelIcelakeBm:
+		numa, ok := core.GetMappingIntelIcelakeBmLaunchInstancePlatformConfigNumaNodesPerSocketEnum(p.NumaNodesPerSocket)
+		if !ok && p.NumaNodesPerSocket != "" {
+			return nil, invalidNuma
+		}
+		return core.IntelIcelakeBmLaunchInstancePlatformConfig{
+			IsSecureBootEnabled:              p.IsSecureBootEnabled,
+			IsTrustedPlatformModuleEnabled:   p.IsTrustedPlatformModuleEnabled,
+			IsMeasuredBootEnabled:            p.IsMeasuredBootEnabled,
+			IsSymmetricMultiThreadingEnabled: p.IsSymmetricMultiThreadingEnabled,
+			PercentageOfCoresEnabled:         p.PercentageOfCoresEnabled,
+			NumaNodesPerSocket:               numa,
+		}, nil
+	case core.LaunchInstancePlatformConfigTypeIntelSkylakeBm:
+		if err := unsupported(cpuOptions...); err != nil {
+			return nil, err
+		}
+		return core.IntelSkylakeBmLaunchInstancePlatformConfig{
+			IsSecureBootEnabled:            p.IsSecureBootEnabled,
+			IsTrustedPlatformModuleEnabled: p.IsTrustedPlatformModuleEnabled,
+			IsMeasuredBootEnabled:          p.IsMeasuredBootEnabled,
+		}, nil
+	case core.LaunchInstancePlatformConfigTypeAmdVm:
+		if err := unsupported(cpuOptions...); err != nil {
+			return nil, err
+		}
+		return core.AmdVmLaunchInstancePlatformConfig{
+			IsSecureBootEnabled:            p.IsSecureBootEnabled,
+			IsTrustedPlatformModuleEnabled: p.IsTrustedPlatformModuleEnabled,
+			IsMeasuredBootEnabled:          p.IsMeasuredBootEnabled,
+		}, nil
+	case core.LaunchInstancePlatformConfigTypeIntelVm:
+		if err := unsupported(cpuOptions...); err != nil {
+			return nil, err
+		}
+		return core.IntelVmLaunchInstancePlatformConfig{
+			IsSecureBootEnabled:            p.IsSecureBootEnabled,
+			IsTrustedPlatformModuleEnabled: p.IsTrustedPlatformModuleEnabled,
+			IsMeasuredBootEnabled:          p.IsMeasuredBootEnabled,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown platform type %q", p.Type)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"testing"
+
+	core "github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestLaunchPlatformConfig(t *testing.T) {
+	smt := false
+	cores := 50
+	details, err := launchPlatformConfig(PlatformConfig{
+		Type:                             "AMD_MILAN_BM",
+		IsSymmetricMultiThreadingEnabled: &smt,
+		NumaNodesPerSocket:               "NPS4",
+		PercentageOfCoresEnabled:         &cores,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	milan, ok := details.(core.AmdMilanBmLaunchInstancePlatformConfig)
+	if !ok {
+		t.Fatalf("unexpected platform config: %#v", details)
+	}
+	if *milan.IsSymmetricMultiThreadingEnabled || milan.NumaNodesPerSocket != "NPS4" || *milan.PercentageOfCoresEnabled != 50 {
+		t.Fatalf("unexpected platform config: %#v", milan)
+	}
+}
+
+func TestLaunchPlatformConfig_unsupported(t *testing.T) {
+	smt := true
+	cores := 50
+	cases := map[string]PlatformConfig{
+		"smt on a VM":          {Type: "INTEL_VM", IsSymmetricMultiThreadingEnabled: &smt},
+		"cores on a GPU host":  {Type: "AMD_ROME_BM_GPU", PercentageOfCoresEnabled: &cores},
+		"NPS4 on Ice Lake":     {Type: "INTEL_ICELAKE_BM", NumaNodesPerSocket: "NPS4"},
+		"invalid NUMA setting": {Type: "AMD_ROME_BM", NumaNodesPerSocket: "NPS3"},
+	}
+	for name, p := range cases {
+		if _, err := launchPlatformConfig(p); err == nil {
+			t.Errorf("%s: should have error", name)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// durationSmoothing is the weight of the latest observed duration in the
+	// moving average kept for an operation.
+	durationSmoothing = 0.3
+
+	// maxAdaptiveIntervalFactor bounds how much slower than the configured
+	// polling interval an adaptive wait may poll.
+	maxAdaptiveIntervalFactor = 6
+)
+
+// defaultDurationCachePath returns the default location of the file in which
+// observed operation durations are kept between builds.
+func defaultDurationCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "packer-plugin-oracle", "durations.json")
+}
+
+// durationCache records how long long-running operations, such as launching
+// an instance or capturing an image, took in previous builds.
+type durationCache struct {
+	path string
+	mu   sync.Mutex
+}
+
+var (
+	durationCachesMu sync.Mutex
+	durationCaches   = map[string]*durationCache{}
+)
+
+// sharedDurationCache returns the duration cache backed by the file at the
+// given path. Caches are shared by every driver in the process so that
+// parallel builds do not overwrite each other's records.
+func sharedDurationCache(path string) *durationCache {
+	durationCachesMu.Lock()
+	defer durationCachesMu.Unlock()
+
+	if c, ok := durationCaches[path]; ok {
+		return c
+	}
+	c := &durationCache{path: path}
+	durationCaches[path] = c
+	return c
+}
+
+func (c *durationCache) load() (map[string]time.Duration, error) {
+	durations := map[string]time.Duration{}
+	raw, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return durations, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &durations); err != nil {
+		return nil, err
+	}
+	return durations, nil
+}
+
+// Expected returns the expected duration of an operation, if it has been
+// observed before.
+func (c *durationCache) Expected(key string) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	durations, err := c.load()
+	if err != nil {
+		log.Printf("[WARN] Ignoring unreadable duration cache %s: %s", c.path, err)
+		return 0, false
+	}
+	d, ok := durations[key]
+	return d, ok && d > 0
+}
+
+// Record folds the observed duration of an operation into its moving
+// average. Failing to persist the cache only disables adaptive polling for
+// later builds, so errors are logged rather than returned.
+func (c *durationCache) Record(key string, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	durations, err := c.load()
+	if err != nil {
+		durations = map[string]time.Duration{}
+	}
+	if prev, ok := durations[key]; ok && prev > 0 {
+		d = time.Duration(durationSmoothing*float64(d) + (1-durationSmoothing)*float64(prev))
+	}
+	durations[key] = d
+
+	if err := c.save(durations); err != nil {
+		log.Printf("[WARN] Failed to update duration cache %s: %s", c.path, err)
+	}
+}
+
+func (c *durationCache) save(durations map[string]time.Duration) error {
+	raw, err := json.MarshalIndent(durations, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+
+	// Replace the file atomically so that concurrent readers never see a
+	// partial write.
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// constantInterval returns a polling schedule that always waits for the given
+// duration.
+func constantInterval(d time.Duration) func(time.Duration) time.Duration {
+	return func(time.Duration) time.Duration { return d }
+}
+
+// adaptiveInterval returns a polling schedule for an operation expected to
+// take the given duration. Early on it polls up to maxAdaptiveIntervalFactor
+// times slower than the base interval, sleeping for a quarter of the expected
+// remaining time, and it falls back to the base interval once the operation
+// is within a quarter of its expected completion or overdue.
+func adaptiveInterval(base, expected time.Duration) func(time.Duration) time.Duration {
+	return func(elapsed time.Duration) time.Duration {
+		remaining := expected - elapsed
+		if remaining <= expected/4 {
+			return base
+		}
+		interval := remaining / 4
+		if interval < base {
+			return base
+		}
+		if max := base * maxAdaptiveIntervalFactor; interval > max {
+			return max
+		}
+		return interval
+	}
+}
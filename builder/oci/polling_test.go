@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveInterval(t *testing.T) {
+	interval := adaptiveInterval(5*time.Second, 10*time.Minute)
+
+	cases := []struct {
+		elapsed  time.Duration
+		expected time.Duration
+	}{
+		{0, 30 * time.Second},
+		{6 * time.Minute, 30 * time.Second},
+		{8 * time.Minute, 5 * time.Second},
+		{9*time.Minute + 30*time.Second, 5 * time.Second},
+		{20 * time.Minute, 5 * time.Second},
+	}
+	for _, tc := range cases {
+		if got := interval(tc.elapsed); got != tc.expected {
+			t.Errorf("After %s, expected an interval of %s, got %s", tc.elapsed, tc.expected, got)
+		}
+	}
+
+	short := adaptiveInterval(5*time.Second, 40*time.Second)
+	if got := short(0); got != 10*time.Second {
+		t.Errorf("Expected a quarter of the remaining time, got %s", got)
+	}
+	if got := short(30 * time.Second); got != 5*time.Second {
+		t.Errorf("Expected the base interval near completion, got %s", got)
+	}
+}
+
+func TestDurationCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "durations.json")
+	cache := sharedDurationCache(path)
+	if cache != sharedDurationCache(path) {
+		t.Fatal("Expected the same cache for the same path")
+	}
+
+	if _, ok := cache.Expected("image/VM.Standard.E4.Flex"); ok {
+		t.Fatal("Expected no duration before any record")
+	}
+
+	cache.Record("image/VM.Standard.E4.Flex", 10*time.Minute)
+	if d, ok := cache.Expected("image/VM.Standard.E4.Flex"); !ok || d != 10*time.Minute {
+		t.Fatalf("Expected 10m, got %s (%t)", d, ok)
+	}
+
+	cache.Record("image/VM.Standard.E4.Flex", 20*time.Minute)
+	if d, _ := cache.Expected("image/VM.Standard.E4.Flex"); d != 13*time.Minute {
+		t.Errorf("Expected the moving average 13m, got %s", d)
+	}
+
+	// A fresh cache on the same file sees the persisted records.
+	reloaded := &durationCache{path: path}
+	if d, _ := reloaded.Expected("image/VM.Standard.E4.Flex"); d != 13*time.Minute {
+		t.Errorf("Expected the persisted duration 13m, got %s", d)
+	}
+	if _, ok := reloaded.Expected("image/VM.Standard2.1"); ok {
+		t.Error("Expected no duration for another shape")
+	}
+}
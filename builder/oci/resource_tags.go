@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+// buildUUIDTag is the freeform tag set on every transient resource of a
+// build, so that cost reports and cleanup tooling can group them and find
+// the ones left behind by a build that was killed.
+const buildUUIDTag = "packer-build-uuid"
+
+// mergeFreeformTags returns instance_tags along with the freeform tags of a
+// given resource, which take precedence.
+func mergeFreeformTags(instanceTags map[string]string, tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return instanceTags
+	}
+	merged := make(map[string]string, len(instanceTags)+len(tags))
+	for k, v := range instanceTags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
+// mergeDefinedTags returns instance_defined_tags along with the defined tags
+// of a given resource, which take precedence key by key.
+func mergeDefinedTags(instanceTags map[string]map[string]interface{}, tags map[string]map[string]interface{}) map[string]map[string]interface{} {
+	if len(tags) == 0 {
+		return instanceTags
+	}
+	merged := make(map[string]map[string]interface{}, len(instanceTags)+len(tags))
+	for namespace, keys := range instanceTags {
+		merged[namespace] = make(map[string]interface{}, len(keys))
+		for k, v := range keys {
+			merged[namespace][k] = v
+		}
+	}
+	for namespace, keys := range tags {
+		if merged[namespace] == nil {
+			merged[namespace] = make(map[string]interface{}, len(keys))
+		}
+		for k, v := range keys {
+			merged[namespace][k] = v
+		}
+	}
+	return merged
+}
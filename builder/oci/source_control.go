@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// Freeform tags recording where an image was built from.
+const (
+	gitCommitTag     = "packer:git-commit"
+	gitBranchTag     = "packer:git-branch"
+	templatePathTag  = "packer:template-path"
+	packerVersionTag = "packer:packer-version"
+	baseImageTag     = "packer:base-image"
+	buildTimeTag     = "packer:build-time"
+)
+
+// maxTagValueLength is the maximum length of a freeform tag value.
+const maxTagValueLength = 100
+
+// sourceControlTags returns the source control metadata of the build, or nil
+// when Packer does not run from a git checkout. The template path is relative
+// to the root of the checkout.
+func sourceControlTags(templatePath string) map[string]string {
+	commit := gitOutput("rev-parse", "HEAD")
+	if commit == "" {
+		return nil
+	}
+
+	tags := map[string]string{gitCommitTag: commit}
+	if branch := gitOutput("rev-parse", "--abbrev-ref", "HEAD"); branch != "" {
+		tags[gitBranchTag] = truncateTagValue(branch)
+	}
+	if templatePath != "" {
+		if root := gitOutput("rev-parse", "--show-toplevel"); root != "" {
+			if abs, err := filepath.Abs(templatePath); err == nil {
+				if rel, err := filepath.Rel(root, abs); err == nil {
+					templatePath = filepath.ToSlash(rel)
+				}
+			}
+		}
+		tags[templatePathTag] = truncateTagValue(templatePath)
+	}
+	return tags
+}
+
+// provenanceTags adds the Packer version and the build time to the source
+// control tags of the build, along with the template file name when Packer
+// does not run from a git checkout. The base image is only tagged by stepImage
+// once it is resolved when launching the instance.
+func provenanceTags(tags map[string]string, templatePath string, packerVersion string, now time.Time) map[string]string {
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	if _, ok := tags[templatePathTag]; !ok && templatePath != "" {
+		tags[templatePathTag] = truncateTagValue(filepath.Base(templatePath))
+	}
+	if packerVersion != "" {
+		tags[packerVersionTag] = packerVersion
+	}
+	tags[buildTimeTag] = now.UTC().Format(time.RFC3339)
+	return tags
+}
+
+// withBaseImageTag returns a copy of tags tagged with the base image the
+// instance was launched from. A base image tag set in the template takes
+// precedence.
+func withBaseImageTag(tags map[string]string, baseImageID string) map[string]string {
+	if _, ok := tags[baseImageTag]; ok {
+		return tags
+	}
+	tagged := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		tagged[k] = v
+	}
+	tagged[baseImageTag] = baseImageID
+	return tagged
+}
+
+// truncateTagValue keeps the end of values too long for a freeform tag, which
+// is the most specific part of branch names and paths.
+func truncateTagValue(v string) string {
+	if len(v) <= maxTagValueLength {
+		return v
+	}
+	return v[len(v)-maxTagValueLength:]
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSourceControlTags(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// The checkout root reported by git has symlinks resolved
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"-c", "user.name=packer", "-c", "user.email=packer@example.com", "commit", "-q", "--allow-empty", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %s: %s", strings.Join(args, " "), err, out)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	tags := sourceControlTags(filepath.Join(dir, "images", "base.pkr.hcl"))
+	if len(tags[gitCommitTag]) != 40 {
+		t.Errorf("Expected a commit hash, got %q", tags[gitCommitTag])
+	}
+	if tags[gitBranchTag] != "main" {
+		t.Errorf("Expected branch main, got %q", tags[gitBranchTag])
+	}
+	if tags[templatePathTag] != "images/base.pkr.hcl" {
+		t.Errorf("Expected template path relative to the checkout, got %q", tags[templatePathTag])
+	}
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if tags := sourceControlTags("base.pkr.hcl"); tags != nil {
+		t.Errorf("Expected no tags outside a git checkout, got %v", tags)
+	}
+}
+
+func TestProvenanceTags(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	tags := provenanceTags(nil, "/home/ci/images/base.pkr.hcl", "1.10.0", now)
+	expected := map[string]string{
+		templatePathTag:  "base.pkr.hcl",
+		packerVersionTag: "1.10.0",
+		buildTimeTag:     "2024-03-01T11:00:00Z",
+	}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected %v outside a git checkout, got %v", expected, tags)
+	}
+
+	tags = provenanceTags(map[string]string{gitCommitTag: "abc", templatePathTag: "images/base.pkr.hcl"}, "/home/ci/images/base.pkr.hcl", "", now)
+	if tags[templatePathTag] != "images/base.pkr.hcl" || tags[gitCommitTag] != "abc" {
+		t.Errorf("Expected the source control tags to be kept, got %v", tags)
+	}
+	if _, ok := tags[packerVersionTag]; ok {
+		t.Errorf("Expected no Packer version tag without a version, got %v", tags)
+	}
+}
+
+func TestTruncateTagValue(t *testing.T) {
+	long := strings.Repeat("a", 50) + strings.Repeat("b", 100)
+	if got := truncateTagValue(long); got != strings.Repeat("b", 100) {
+		t.Errorf("Expected the last 100 characters, got %q", got)
+	}
+	if got := truncateTagValue("main"); got != "main" {
+		t.Errorf("Expected short values unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepAttachVlan attaches a secondary VNIC in the configured VLAN when the
+// primary VNIC of the instance lives in a subnet.
+type stepAttachVlan struct{}
+
+func (s *stepAttachVlan) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	// Without a subnet the VLAN is already used by the primary VNIC
+	if config.VlanID == "" || config.CreateVnicDetails.SubnetId == nil {
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Attaching VLAN (%s) to instance...", config.VlanID))
+
+	attachmentID, err := driver.AttachVlanVnic(ctx, id)
+	if err != nil {
+		err = fmt.Errorf("Error attaching VLAN to instance: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if err = driver.WaitForVnicAttachmentState(ctx, attachmentID, []string{"ATTACHING"}, "ATTACHED"); err != nil {
+		err = fmt.Errorf("Error waiting for VLAN attachment: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	state.Put("vlan_vnic_attachment_id", attachmentID)
+
+	ui.Say(fmt.Sprintf("Attached VLAN (%s).", attachmentID))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepAttachVlan) Cleanup(state multistep.StateBag) {
+	// The VNIC attachment is removed when the instance is terminated
+}
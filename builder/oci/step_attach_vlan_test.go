@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepAttachVlan(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).VlanID = "ocid1.vlan..."
+
+	step := new(stepAttachVlan)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("vlan_vnic_attachment_id"); !ok {
+		t.Fatalf("should have vlan_vnic_attachment_id")
+	}
+}
+
+func TestStepAttachVlan_NoVlan(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepAttachVlan)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.AttachVlanVnicID != "" {
+		t.Fatalf("should NOT have attached a VLAN")
+	}
+}
+
+func TestStepAttachVlan_AttachVlanVnicErr(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).VlanID = "ocid1.vlan..."
+
+	step := new(stepAttachVlan)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.AttachVlanVnicErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/packerbuilderdata"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+const (
+	volumeAttachmentTypeParavirtualized = "paravirtualized"
+	volumeAttachmentTypeISCSI           = "iscsi"
+)
+
+// stepBlockVolumes creates the configured block volumes, each with its own
+// encryption key and performance, and attaches them to the instance for the
+// duration of the build. Existing volumes are attached as they are and are
+// not deleted afterwards. iSCSI volumes must be logged in to from the
+// instance, so the commands doing so are exported as the ISCSIAttachCommands
+// and ISCSIDetachCommands build variables. The volumes are detached by
+// stepDetachBlockVolumes before the image is captured, or in Cleanup when the
+// build fails.
+type stepBlockVolumes struct {
+	volumeIDs      []string
+	attachments    []volumeAttachment
+	detachCommands []string
+}
+
+// volumeAttachment is the attachment of a block volume to the instance.
+type volumeAttachment struct {
+	id       string
+	volumeID string
+}
+
+func (s *stepBlockVolumes) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+
+		attachCommands, detachCommands []string
+	)
+
+	for i, volume := range config.BlockVolumes {
+		volumeID := volume.VolumeID
+		if volumeID != "" {
+			ui.Say(fmt.Sprintf("Attaching existing block volume %d (%s)...", i, volumeID))
+		} else {
+			ui.Say(fmt.Sprintf("Creating block volume %d (%d GBs)...", i, volume.SizeInGBs))
+
+			var err error
+			volumeID, err = driver.CreateVolume(ctx, volume)
+			if err != nil {
+				err = fmt.Errorf("Error creating block volume: %s", err)
+				ui.Error(err.Error())
+				state.Put("error", err)
+				return multistep.ActionHalt
+			}
+			s.volumeIDs = append(s.volumeIDs, volumeID)
+
+			if err = driver.WaitForVolumeState(ctx, volumeID, []string{"PROVISIONING"}, "AVAILABLE"); err != nil {
+				err = fmt.Errorf("Error waiting for block volume to become available: %s", err)
+				ui.Error(err.Error())
+				state.Put("error", err)
+				return multistep.ActionHalt
+			}
+		}
+
+		attachmentID, err := driver.AttachVolume(ctx, id, volumeID, volume.AttachmentType)
+		if err != nil {
+			err = fmt.Errorf("Error attaching block volume to instance: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		s.attachments = append(s.attachments, volumeAttachment{id: attachmentID, volumeID: volumeID})
+
+		if err = driver.WaitForVolumeAttachmentState(ctx, attachmentID, []string{"ATTACHING"}, "ATTACHED"); err != nil {
+			err = fmt.Errorf("Error waiting for block volume attachment: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+
+		if volume.AttachmentType == volumeAttachmentTypeISCSI {
+			attachment, err := driver.GetVolumeAttachment(ctx, attachmentID)
+			if err != nil {
+				err = fmt.Errorf("Error getting iSCSI target of block volume: %s", err)
+				ui.Error(err.Error())
+				state.Put("error", err)
+				return multistep.ActionHalt
+			}
+			if iscsi, ok := attachment.(core.IScsiVolumeAttachment); ok {
+				attach, detach := iscsiCommands(iscsi)
+				attachCommands = append(attachCommands, attach...)
+				detachCommands = append(detachCommands, detach...)
+			}
+		}
+
+		ui.Say(fmt.Sprintf("Attached block volume (%s).", volumeID))
+	}
+
+	generatedData := &packerbuilderdata.GeneratedData{State: state}
+	generatedData.Put("ISCSIAttachCommands", strings.Join(attachCommands, "\n"))
+	generatedData.Put("ISCSIDetachCommands", strings.Join(detachCommands, "\n"))
+	s.detachCommands = detachCommands
+
+	return multistep.ActionContinue
+}
+
+// iscsiCommands returns the commands logging in to and out of the target of
+// an iSCSI volume attachment, as shown by the OCI console.
+func iscsiCommands(attachment core.IScsiVolumeAttachment) (attach []string, detach []string) {
+	target := fmt.Sprintf("-T %s -p %s:%d", *attachment.Iqn, *attachment.Ipv4, *attachment.Port)
+	attach = []string{
+		fmt.Sprintf("sudo iscsiadm -m node -o new %s", target),
+		fmt.Sprintf("sudo iscsiadm -m node -o update %s -n node.startup -v automatic", target),
+		fmt.Sprintf("sudo iscsiadm -m node %s -l", target),
+	}
+	detach = []string{
+		fmt.Sprintf("sudo iscsiadm -m node %s -u", target),
+		fmt.Sprintf("sudo iscsiadm -m node -o delete %s", target),
+	}
+	return attach, detach
+}
+
+func (s *stepBlockVolumes) Cleanup(state multistep.StateBag) {
+	// Block volumes stay attached to a kept instance
+	if keepInstance(state) {
+		return
+	}
+
+	s.release(context.TODO(), state)
+}
+
+// release detaches the block volumes from the instance and deletes the
+// volumes it created, reporting any failure in the state. Volumes that failed
+// to detach are not deleted, as that is bound to fail too. It returns whether
+// every volume was released.
+func (s *stepBlockVolumes) release(ctx context.Context, state multistep.StateBag) bool {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		ok     = true
+	)
+
+	stillAttached := map[string]bool{}
+	for _, attachment := range s.attachments {
+		ui.Say(fmt.Sprintf("Detaching block volume (%s)...", attachment.id))
+
+		err := driver.DetachVolume(ctx, attachment.id)
+		if err == nil {
+			err = driver.WaitForVolumeAttachmentState(ctx, attachment.id, []string{"DETACHING"}, "DETACHED")
+		}
+		if err != nil {
+			err = fmt.Errorf("Error detaching block volume. Please detach and delete %s manually: %s", attachment.volumeID, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			stillAttached[attachment.volumeID] = true
+			ok = false
+		}
+	}
+
+	for _, volumeID := range s.volumeIDs {
+		if stillAttached[volumeID] {
+			continue
+		}
+
+		ui.Say(fmt.Sprintf("Deleting block volume (%s)...", volumeID))
+
+		if err := driver.DeleteVolume(ctx, volumeID); err != nil {
+			err = fmt.Errorf("Error deleting block volume. Please delete it manually: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			ok = false
+			continue
+		}
+
+		ui.Say("Deleted block volume.")
+	}
+
+	s.attachments = nil
+	s.volumeIDs = nil
+	return ok
+}
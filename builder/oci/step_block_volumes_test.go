@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepBlockVolumes(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).BlockVolumes = []BlockVolumeConfig{
+		{SizeInGBs: 50},
+		{SizeInGBs: 100, KmsKeyID: "ocid1.key.oc1..aaa"},
+	}
+
+	step := new(stepBlockVolumes)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if len(driver.CreateVolumeIDs) != 2 || len(driver.AttachVolumeIDs) != 2 {
+		t.Fatalf("should've created and attached 2 volumes")
+	}
+
+	step.Cleanup(state)
+
+	if !reflect.DeepEqual(driver.DetachVolumeIDs, driver.AttachVolumeIDs) {
+		t.Fatalf("should've detached volumes (%v != %v)", driver.DetachVolumeIDs, driver.AttachVolumeIDs)
+	}
+	if !reflect.DeepEqual(driver.DeleteVolumeIDs, driver.CreateVolumeIDs) {
+		t.Fatalf("should've deleted volumes (%v != %v)", driver.DeleteVolumeIDs, driver.CreateVolumeIDs)
+	}
+}
+
+func TestStepBlockVolumes_existingVolume(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).BlockVolumes = []BlockVolumeConfig{
+		{VolumeID: "ocid1.volume.oc1..aaa"},
+		{SizeInGBs: 50},
+	}
+
+	step := new(stepBlockVolumes)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if len(driver.CreateVolumeIDs) != 1 || len(driver.AttachVolumeIDs) != 2 {
+		t.Fatalf("should've created 1 volume and attached 2 volumes")
+	}
+
+	step.Cleanup(state)
+
+	if len(driver.DetachVolumeIDs) != 2 {
+		t.Fatalf("should've detached both volumes")
+	}
+	if !reflect.DeepEqual(driver.DeleteVolumeIDs, driver.CreateVolumeIDs) {
+		t.Fatalf("should've deleted only the created volume (%v != %v)", driver.DeleteVolumeIDs, driver.CreateVolumeIDs)
+	}
+}
+
+func TestStepBlockVolumes_iscsi(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).BlockVolumes = []BlockVolumeConfig{
+		{SizeInGBs: 50, AttachmentType: volumeAttachmentTypeISCSI},
+		{SizeInGBs: 50, AttachmentType: volumeAttachmentTypeParavirtualized},
+	}
+
+	step := new(stepBlockVolumes)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if expected := []string{"iscsi", "paravirtualized"}; !reflect.DeepEqual(driver.AttachVolumeTypes, expected) {
+		t.Fatalf("unexpected attachment types: %q", driver.AttachVolumeTypes)
+	}
+
+	data := state.Get("generated_data").(map[string]interface{})
+	attach := "sudo iscsiadm -m node -o new -T iqn.2015-12.com.oracleiaas:ocid1.volumeattachment.0 -p 169.254.2.2:3260\n" +
+		"sudo iscsiadm -m node -o update -T iqn.2015-12.com.oracleiaas:ocid1.volumeattachment.0 -p 169.254.2.2:3260 -n node.startup -v automatic\n" +
+		"sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:ocid1.volumeattachment.0 -p 169.254.2.2:3260 -l"
+	if data["ISCSIAttachCommands"] != attach {
+		t.Fatalf("unexpected attach commands: %q", data["ISCSIAttachCommands"])
+	}
+	detach := "sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:ocid1.volumeattachment.0 -p 169.254.2.2:3260 -u\n" +
+		"sudo iscsiadm -m node -o delete -T iqn.2015-12.com.oracleiaas:ocid1.volumeattachment.0 -p 169.254.2.2:3260"
+	if data["ISCSIDetachCommands"] != detach {
+		t.Fatalf("unexpected detach commands: %q", data["ISCSIDetachCommands"])
+	}
+}
+
+func TestStepBlockVolumes_AttachVolumeErr(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).BlockVolumes = []BlockVolumeConfig{{SizeInGBs: 50}}
+
+	step := new(stepBlockVolumes)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.AttachVolumeErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+
+	step.Cleanup(state)
+
+	if len(driver.DetachVolumeIDs) != 0 {
+		t.Fatalf("should NOT have detached a volume that was not attached")
+	}
+	if !reflect.DeepEqual(driver.DeleteVolumeIDs, driver.CreateVolumeIDs) {
+		t.Fatalf("should've deleted the created volume")
+	}
+}
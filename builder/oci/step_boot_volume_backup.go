@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepBootVolumeBackup creates a full backup of the boot volume of the
+// instance with boot_volume_backup, for workflows deploying from boot volume
+// backups rather than images. It is taken after the image, from the same
+// state of the instance.
+type stepBootVolumeBackup struct{}
+
+func (s *stepBootVolumeBackup) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if !config.BootVolumeBackup {
+		return multistep.ActionContinue
+	}
+
+	bootVolumeID, err := driver.GetBootVolumeID(ctx, id)
+	if err != nil {
+		err = fmt.Errorf("Error looking up the boot volume of the instance: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say(fmt.Sprintf("Backing up boot volume (%s)...", bootVolumeID))
+
+	backupID, err := driver.CreateBootVolumeBackup(ctx, bootVolumeID)
+	if err != nil {
+		err = fmt.Errorf("Error backing up boot volume: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if err := driver.WaitForBootVolumeBackup(ctx, backupID); err != nil {
+		err = fmt.Errorf("Error waiting for boot volume backup (%s) to become available: %s", backupID, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	state.Put("boot_volume_backup_id", backupID)
+
+	ui.Say(fmt.Sprintf("Created boot volume backup (%s).", backupID))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepBootVolumeBackup) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
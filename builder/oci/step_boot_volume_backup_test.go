@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepBootVolumeBackup(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).BootVolumeBackup = true
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepBootVolumeBackup)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CreateBootVolumeBackupVolumeID != "ocid1.bootvolume..." {
+		t.Fatalf("should've backed up the boot volume of the instance: %q", driver.CreateBootVolumeBackupVolumeID)
+	}
+	if id := state.Get("boot_volume_backup_id"); id != "ocid1.bootvolumebackup" {
+		t.Fatalf("unexpected boot volume backup: %v", id)
+	}
+}
+
+func TestStepBootVolumeBackup_waitErr(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).BootVolumeBackup = true
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForBootVolumeBackupErr = errors.New("error")
+
+	step := new(stepBootVolumeBackup)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+	if _, ok := state.GetOk("boot_volume_backup_id"); ok {
+		t.Fatalf("should not have a boot volume backup")
+	}
+}
+
+func TestStepBootVolumeBackup_disabled(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepBootVolumeBackup)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if driver.CreateBootVolumeBackupVolumeID != "" {
+		t.Fatalf("should not have backed up the boot volume")
+	}
+}
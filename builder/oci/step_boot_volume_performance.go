@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepBootVolumePerformance changes the performance of the boot volume of the
+// instance as soon as it is launched, so that I/O heavy provisioning runs on
+// a faster volume. Instance launch details do not accept a boot volume
+// performance, so it is changed once the volume exists.
+type stepBootVolumePerformance struct{}
+
+func (s *stepBootVolumePerformance) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if config.BootVolumeVpusPerGB == nil {
+		return multistep.ActionContinue
+	}
+
+	vpusPerGB := *config.BootVolumeVpusPerGB
+	ui.Say(fmt.Sprintf("Setting boot volume performance to %d VPUs/GB...", vpusPerGB))
+
+	bootVolumeID, err := driver.GetBootVolumeID(ctx, id)
+	if err != nil {
+		err = fmt.Errorf("Error looking up the boot volume of the instance: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if err := driver.UpdateBootVolumePerformance(ctx, bootVolumeID, vpusPerGB); err != nil {
+		err = fmt.Errorf("Error setting boot volume performance: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say(fmt.Sprintf("Set boot volume (%s) performance.", bootVolumeID))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepBootVolumePerformance) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepBootVolumePerformance(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	vpusPerGB := int64(30)
+	state.Get("config").(*Config).BootVolumeVpusPerGB = &vpusPerGB
+
+	step := new(stepBootVolumePerformance)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.UpdateBootVolumePerformanceVpusPerGB != 30 {
+		t.Fatalf("should have set 30 VPUs/GB, got %d", driver.UpdateBootVolumePerformanceVpusPerGB)
+	}
+}
+
+func TestStepBootVolumePerformance_Unset(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepBootVolumePerformance)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.UpdateBootVolumePerformanceVpusPerGB != 0 {
+		t.Fatal("should NOT have changed the boot volume performance")
+	}
+}
+
+func TestStepBootVolumePerformance_Error(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	vpusPerGB := int64(30)
+	state.Get("config").(*Config).BootVolumeVpusPerGB = &vpusPerGB
+
+	step := new(stepBootVolumePerformance)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.UpdateBootVolumePerformanceErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
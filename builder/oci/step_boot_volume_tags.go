@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepBootVolumeTags tags the boot volume of the instance like the other
+// transient resources of the build. The boot volume created by the launch
+// does not inherit the tags of the instance, and it outlives the build with
+// preserve_boot_volume.
+type stepBootVolumeTags struct{}
+
+func (s *stepBootVolumeTags) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	// The clone of source_boot_volume_ocid is tagged when it is created,
+	// and boot_volume_ocid is not a resource of the build
+	if config.launchBootVolumeID != "" {
+		return multistep.ActionContinue
+	}
+
+	bootVolumeID, err := driver.GetBootVolumeID(ctx, id)
+	if err != nil {
+		err = fmt.Errorf("Error looking up the boot volume of the instance: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if err := driver.UpdateBootVolumeTags(ctx, bootVolumeID); err != nil {
+		err = fmt.Errorf("Error tagging boot volume: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepBootVolumeTags) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
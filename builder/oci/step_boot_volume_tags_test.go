@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepBootVolumeTags(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepBootVolumeTags)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.UpdateBootVolumeTagsID != "ocid1.bootvolume..." {
+		t.Fatalf("should've tagged the boot volume of the instance: %q", driver.UpdateBootVolumeTagsID)
+	}
+}
+
+func TestStepBootVolumeTags_launchBootVolume(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).launchBootVolumeID = "ocid1.bootvolume.golden"
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepBootVolumeTags)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.UpdateBootVolumeTagsID != "" {
+		t.Fatalf("should not have tagged the boot volume the instance was launched from")
+	}
+}
+
+func TestStepBootVolumeTags_Error(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	driver := state.Get("driver").(*driverMock)
+	driver.UpdateBootVolumeTagsErr = errors.New("error")
+
+	step := new(stepBootVolumeTags)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
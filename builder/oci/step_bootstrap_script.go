@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/template/interpolate"
+)
+
+// bootstrapScriptData is the data available when rendering bootstrap_script,
+// on top of the build functions such as build_name.
+type bootstrapScriptData struct {
+	// SSHPublicKey is the public key the communicator authenticates with.
+	SSHPublicKey string
+}
+
+// renderBootstrapScript renders bootstrap_script with the given public key.
+func (c *Config) renderBootstrapScript(publicKey string) (string, error) {
+	ctx := c.ctx
+	ctx.Data = &bootstrapScriptData{SSHPublicKey: publicKey}
+	return interpolate.Render(c.BootstrapScript, &ctx)
+}
+
+// stepBootstrapScript runs bootstrap_script through the Run Command plugin of
+// the Compute Instance Agent, which does not depend on SSH or WinRM, so that
+// base images locking out remote access by default can still be connected to.
+type stepBootstrapScript struct{}
+
+func (s *stepBootstrapScript) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if config.BootstrapScript == "" {
+		return multistep.ActionContinue
+	}
+
+	script, err := config.renderBootstrapScript(strings.TrimSpace(string(config.Comm.SSHPublicKey)))
+	if err != nil {
+		err = fmt.Errorf("Error rendering bootstrap script: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say("Running bootstrap script through the instance agent...")
+
+	ctx, cancel := context.WithTimeout(ctx, config.BootstrapTimeout)
+	defer cancel()
+
+	output, err := driver.RunCommand(ctx, id, script, config.BootstrapTimeout)
+	if err != nil {
+		err = fmt.Errorf("Error running bootstrap script: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if output = strings.TrimSpace(output); output != "" {
+		ui.Message(output)
+	}
+	ui.Say("Bootstrap script finished.")
+
+	return multistep.ActionContinue
+}
+
+func (s *stepBootstrapScript) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
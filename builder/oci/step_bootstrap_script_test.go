@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepBootstrapScript(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.BootstrapScript = "echo '{{ .SSHPublicKey }}' >> /home/opc/.ssh/authorized_keys"
+	config.Comm.SSHPublicKey = []byte("ssh-ed25519 AAAA packer\n")
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepBootstrapScript)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if expected := "echo 'ssh-ed25519 AAAA packer' >> /home/opc/.ssh/authorized_keys"; driver.RunCommandScript != expected {
+		t.Fatalf("unexpected script: %q", driver.RunCommandScript)
+	}
+}
+
+func TestStepBootstrapScript_failed(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).BootstrapScript = "exit 1"
+	driver := state.Get("driver").(*driverMock)
+	driver.RunCommandErr = errors.New("command exited with code 1")
+
+	step := new(stepBootstrapScript)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
+
+func TestStepBootstrapScript_unset(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	driver := state.Get("driver").(*driverMock)
+	driver.RunCommandErr = errors.New("should not be called")
+
+	step := new(stepBootstrapScript)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepCaptureShape resizes the instance once it is provisioned, so the image
+// capture does not run on a shape sized for the provisioning workload.
+type stepCaptureShape struct{}
+
+func (s *stepCaptureShape) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if (config.CaptureShapeConfig == FlexShapeConfig{}) || config.SkipCreateImage {
+		return multistep.ActionContinue
+	}
+
+	// Resizing a VM reboots it
+	state.Put("instance_stopped_by_build", true)
+
+	ocpus := *config.CaptureShapeConfig.Ocpus
+	ui.Say(fmt.Sprintf("Resizing instance to %g OCPUs for image capture...", ocpus))
+
+	if err := driver.UpdateInstanceShapeConfig(ctx, id, config.CaptureShapeConfig); err != nil {
+		err = fmt.Errorf("Error resizing instance: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if err := driver.WaitForInstanceShapeConfig(ctx, id, ocpus); err != nil {
+		err = fmt.Errorf("Error waiting for instance to be resized: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say("Resized instance.")
+
+	return multistep.ActionContinue
+}
+
+func (s *stepCaptureShape) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
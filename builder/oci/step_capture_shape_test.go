@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepCaptureShape(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	ocpus := float32(1)
+	state.Get("config").(*Config).CaptureShapeConfig = FlexShapeConfig{Ocpus: &ocpus}
+
+	step := new(stepCaptureShape)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.UpdateInstanceShapeConfigOcpus != ocpus {
+		t.Fatalf("should've resized the instance to %g OCPUs, got %g", ocpus, driver.UpdateInstanceShapeConfigOcpus)
+	}
+}
+
+func TestStepCaptureShape_waitError(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	ocpus := float32(1)
+	state.Get("config").(*Config).CaptureShapeConfig = FlexShapeConfig{Ocpus: &ocpus}
+
+	step := new(stepCaptureShape)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForInstanceShapeConfigErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
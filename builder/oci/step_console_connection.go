@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepConsoleConnection creates a console connection to the instance, so that
+// the boot of an image that does not come up on the network can be watched,
+// and deletes it on teardown.
+type stepConsoleConnection struct {
+	Debug bool
+
+	connectionID string
+}
+
+func (s *stepConsoleConnection) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if !config.CreateConsoleConnection && !s.Debug {
+		return multistep.ActionContinue
+	}
+
+	if len(config.Comm.SSHPublicKey) == 0 {
+		ui.Say("No SSH public key available, skipping the console connection.")
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Creating instance console connection...")
+	connection, err := driver.CreateConsoleConnection(ctx, id, string(config.Comm.SSHPublicKey))
+	if connection.Id != nil {
+		s.connectionID = *connection.Id
+	}
+	if err != nil {
+		err = fmt.Errorf("Error creating instance console connection: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say(fmt.Sprintf("Created instance console connection (%s).", s.connectionID))
+	if connection.ConnectionString != nil {
+		ui.Message(fmt.Sprintf("Serial console: %s", *connection.ConnectionString))
+	}
+	if connection.VncConnectionString != nil {
+		ui.Message(fmt.Sprintf("VNC (then connect to localhost:5900): %s", *connection.VncConnectionString))
+	}
+	if config.Comm.SSHPrivateKeyFile != "" {
+		ui.Message(fmt.Sprintf("Use the private key %s, e.g. with ssh -i.", config.Comm.SSHPrivateKeyFile))
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepConsoleConnection) Cleanup(state multistep.StateBag) {
+	if s.connectionID == "" {
+		return
+	}
+
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+	)
+
+	ui.Say(fmt.Sprintf("Deleting instance console connection (%s)...", s.connectionID))
+	if err := driver.DeleteConsoleConnection(context.TODO(), s.connectionID); err != nil {
+		err = fmt.Errorf("Error deleting instance console connection. Please delete it manually: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return
+	}
+	ui.Say("Deleted instance console connection.")
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepConsoleConnection(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.CreateConsoleConnection = true
+	config.Comm.SSHPublicKey = []byte("ssh-rsa AAAA...")
+
+	step := new(stepConsoleConnection)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CreateConsoleConnectionID == "" {
+		t.Fatal("should have created a console connection")
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteConsoleConnectionID != driver.CreateConsoleConnectionID {
+		t.Fatalf("should've deleted console connection (%s != %s)", driver.DeleteConsoleConnectionID, driver.CreateConsoleConnectionID)
+	}
+}
+
+func TestStepConsoleConnection_Debug(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).Comm.SSHPublicKey = []byte("ssh-rsa AAAA...")
+
+	step := &stepConsoleConnection{Debug: true}
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CreateConsoleConnectionID == "" {
+		t.Fatal("should have created a console connection in debug mode")
+	}
+}
+
+func TestStepConsoleConnection_Disabled(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepConsoleConnection)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CreateConsoleConnectionID != "" {
+		t.Fatal("should NOT have created a console connection")
+	}
+}
+
+func TestStepConsoleConnection_Error(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.CreateConsoleConnection = true
+	config.Comm.SSHPublicKey = []byte("ssh-rsa AAAA...")
+
+	step := new(stepConsoleConnection)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateConsoleConnectionErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepConsoleHistory saves the serial console output of the instance to Path
+// when the build fails before the communicator could connect, so that a
+// timeout waiting for SSH comes with the boot log explaining it. It runs on
+// teardown, before the instance is terminated.
+type stepConsoleHistory struct {
+	Path string
+}
+
+func (s *stepConsoleHistory) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	return multistep.ActionContinue
+}
+
+func (s *stepConsoleHistory) Cleanup(state multistep.StateBag) {
+	if _, failed := state.GetOk("error"); !failed {
+		return
+	}
+	// Once connected, the provisioner output tells more than the console
+	if _, connected := state.GetOk("communicator"); connected {
+		return
+	}
+	idRaw, ok := state.GetOk("instance_id")
+	if !ok {
+		return
+	}
+
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		id     = idRaw.(string)
+	)
+
+	// The build has already failed, so failing to capture the console
+	// history is only reported.
+	ui.Say("Capturing instance console history...")
+	history, err := driver.CaptureConsoleHistory(context.TODO(), id)
+	if err != nil {
+		ui.Error(fmt.Sprintf("Error capturing instance console history: %s", err))
+		return
+	}
+
+	if err := os.WriteFile(s.Path, []byte(history), 0o600); err != nil {
+		ui.Error(fmt.Sprintf("Error saving instance console history: %s", err))
+		return
+	}
+	ui.Say(fmt.Sprintf("Saved instance console history to %s.", s.Path))
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func TestStepConsoleHistory(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	path := filepath.Join(t.TempDir(), "console.log")
+	step := &stepConsoleHistory{Path: path}
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CaptureConsoleHistoryContent = "Kernel panic - not syncing"
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	state.Put("error", errors.New("Timeout waiting for SSH."))
+	step.Cleanup(state)
+
+	if driver.CaptureConsoleHistoryInstanceID != "ocid1..." {
+		t.Fatal("should have captured the console history")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("should have saved the console history: %s", err)
+	}
+	if string(content) != driver.CaptureConsoleHistoryContent {
+		t.Fatalf("unexpected console history %q", content)
+	}
+}
+
+func TestStepConsoleHistory_Success(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := &stepConsoleHistory{Path: filepath.Join(t.TempDir(), "console.log")}
+
+	driver := state.Get("driver").(*driverMock)
+
+	step.Run(context.Background(), state)
+	step.Cleanup(state)
+
+	if driver.CaptureConsoleHistoryInstanceID != "" {
+		t.Fatal("should NOT have captured the console history of a successful build")
+	}
+}
+
+func TestStepConsoleHistory_Connected(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put("communicator", new(packersdk.MockCommunicator))
+
+	step := &stepConsoleHistory{Path: filepath.Join(t.TempDir(), "console.log")}
+
+	driver := state.Get("driver").(*driverMock)
+
+	step.Run(context.Background(), state)
+	state.Put("error", errors.New("provisioning failed"))
+	step.Cleanup(state)
+
+	if driver.CaptureConsoleHistoryInstanceID != "" {
+		t.Fatal("should NOT have captured the console history once connected")
+	}
+}
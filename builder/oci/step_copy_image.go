@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// imageCopyURLLifetime bounds how long the pre-authenticated request the
+// image copies are imported from remains valid, should it not be deleted.
+const imageCopyURLLifetime = 24 * time.Hour
+
+// stepCopyImage copies the image to image_copy_regions and
+// image_copy_compartment_ocids by importing the object exported with
+// export_to_object_storage in each region and compartment, through a
+// pre-authenticated request deleted once the copies are available. The
+// copies are deleted again if the build fails before they are all available.
+type stepCopyImage struct {
+	parID             string
+	copies            map[string]string
+	compartmentCopies map[string]string
+}
+
+func (s *stepCopyImage) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	rawImage, ok := state.GetOk("image")
+	if !ok || (len(config.ImageCopyRegions) == 0 && len(config.ImageCopyCompartments) == 0) {
+		return multistep.ActionContinue
+	}
+	image := rawImage.(core.Image)
+
+	export := config.ExportToObjectStorage
+
+	// The OCI format carries the image type along with the image
+	var sourceImageType string
+	if export.ExportFormat != string(core.ExportImageDetailsExportFormatOci) {
+		sourceImageType = export.ExportFormat
+	}
+
+	parID, uri, err := driver.CreateObjectReadURL(ctx, export, "image-copy", time.Now().Add(imageCopyURLLifetime))
+	if err != nil {
+		err = fmt.Errorf("Error creating pre-authenticated request for the exported image: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+	s.parID = parID
+
+	s.copies = map[string]string{}
+	names := map[string]string{}
+	for _, region := range config.ImageCopyRegions {
+		name := config.imageName()
+		if image.DisplayName != nil {
+			name = *image.DisplayName
+		}
+		if n, ok := config.ImageCopyNames[region]; ok {
+			name = n
+		}
+		names[region] = name
+		copyImage := image
+		copyImage.DisplayName = &name
+
+		ui.Say(fmt.Sprintf("Copying image to region %s as %s...", region, names[region]))
+
+		id, err := driver.CopyImageToRegion(ctx, region, uri, copyImage, sourceImageType)
+		if err != nil {
+			err = fmt.Errorf("Error copying image to region %s: %s", region, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		s.copies[region] = id
+	}
+
+	s.compartmentCopies = map[string]string{}
+	for _, compartment := range config.ImageCopyCompartments {
+		compartment := compartment
+		copyImage := image
+		copyImage.CompartmentId = &compartment
+
+		ui.Say(fmt.Sprintf("Copying image to compartment %s...", compartment))
+
+		id, err := driver.CopyImageToCompartment(ctx, uri, copyImage, sourceImageType)
+		if err != nil {
+			err = fmt.Errorf("Error copying image to compartment %s: %s", compartment, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		s.compartmentCopies[compartment] = id
+	}
+
+	// The copies are imported in parallel
+	for _, region := range config.ImageCopyRegions {
+		if err := driver.WaitForImageCopy(ctx, region, s.copies[region]); err != nil {
+			err = fmt.Errorf("Error waiting for image copy (%s) in region %s to become available: %s", s.copies[region], region, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		ui.Say(fmt.Sprintf("Copied image to region %s (%s).", region, s.copies[region]))
+	}
+	for _, compartment := range config.ImageCopyCompartments {
+		id := s.compartmentCopies[compartment]
+		if err := driver.WaitForCompartmentImageCopy(ctx, id); err != nil {
+			err = fmt.Errorf("Error waiting for image copy (%s) in compartment %s to become available: %s", id, compartment, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		ui.Say(fmt.Sprintf("Copied image to compartment %s (%s).", compartment, id))
+	}
+
+	state.Put("region_images", s.copies)
+	state.Put("region_image_names", names)
+	state.Put("compartment_images", s.compartmentCopies)
+
+	return multistep.ActionContinue
+}
+
+func (s *stepCopyImage) Cleanup(state multistep.StateBag) {
+	if s.parID == "" {
+		return
+	}
+
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	// The copies are part of the artifact once they are all available
+	if _, ok := state.GetOk("region_images"); !ok {
+		for _, region := range sortedKeys(s.copies) {
+			id := s.copies[region]
+			ui.Say(fmt.Sprintf("Deleting image copy (%s) in region %s...", id, region))
+			if err := driver.DeleteImageInRegion(context.TODO(), region, id); err != nil {
+				err = fmt.Errorf("Error deleting image copy. Please delete %s in region %s manually: %s", id, region, err)
+				ui.Error(err.Error())
+				state.Put("error", err)
+			}
+		}
+		for _, compartment := range sortedKeys(s.compartmentCopies) {
+			id := s.compartmentCopies[compartment]
+			ui.Say(fmt.Sprintf("Deleting image copy (%s) in compartment %s...", id, compartment))
+			if err := driver.DeleteImage(context.TODO(), id); err != nil {
+				err = fmt.Errorf("Error deleting image copy. Please delete %s in compartment %s manually: %s", id, compartment, err)
+				ui.Error(err.Error())
+				state.Put("error", err)
+			}
+		}
+	}
+
+	export := config.ExportToObjectStorage
+	if err := driver.DeleteObjectReadURL(context.TODO(), export, s.parID); err != nil {
+		err = fmt.Errorf("Error deleting pre-authenticated request. Please delete %s from bucket %s manually: %s", s.parID, export.Bucket, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestStepCopyImage(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	config := state.Get("config").(*Config)
+	config.ExportToObjectStorage = ImageExportConfig{Bucket: "images", ObjectName: "golden", ExportFormat: "OCI"}
+	config.ImageCopyRegions = []string{"us-ashburn-1", "eu-frankfurt-1"}
+	config.ImageName = "golden"
+	config.ImageCopyNames = map[string]string{"eu-frankfurt-1": "golden-eu"}
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepCopyImage)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if !reflect.DeepEqual(driver.CopyImageToRegionRegions, config.ImageCopyRegions) {
+		t.Fatalf("should've copied the image to every region: %q", driver.CopyImageToRegionRegions)
+	}
+	if driver.CopyImageToRegionURI != "https://objectstorage.us-phoenix-1.oraclecloud.com/p/token/n/ns/b/images/o/golden" {
+		t.Fatalf("should've imported the copies from the pre-authenticated request: %q", driver.CopyImageToRegionURI)
+	}
+	expected := map[string]string{
+		"us-ashburn-1":   "ocid1.image.oc1.us-ashburn-1",
+		"eu-frankfurt-1": "ocid1.image.oc1.eu-frankfurt-1",
+	}
+	if copies := state.Get("region_images"); !reflect.DeepEqual(copies, expected) {
+		t.Fatalf("unexpected image copies: %v", copies)
+	}
+	expectedNames := map[string]string{
+		"us-ashburn-1":   "golden",
+		"eu-frankfurt-1": "golden-eu",
+	}
+	if names := state.Get("region_image_names"); !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("unexpected image copy names: %v", names)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteImageInRegionIDs != nil {
+		t.Fatalf("should've kept the image copies: %q", driver.DeleteImageInRegionIDs)
+	}
+	if driver.DeleteObjectReadURLID != "ocid1.par" {
+		t.Fatalf("should've deleted the pre-authenticated request: %q", driver.DeleteObjectReadURLID)
+	}
+}
+
+func TestStepCopyImage_waitErr(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	config := state.Get("config").(*Config)
+	config.ExportToObjectStorage = ImageExportConfig{Bucket: "images", ObjectName: "golden", ExportFormat: "OCI"}
+	config.ImageCopyRegions = []string{"us-ashburn-1"}
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForImageCopyErr = errors.New("error")
+
+	step := new(stepCopyImage)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+	if _, ok := state.GetOk("region_images"); ok {
+		t.Fatalf("should not have image copies")
+	}
+
+	step.Cleanup(state)
+
+	if !reflect.DeepEqual(driver.DeleteImageInRegionIDs, []string{"ocid1.image.oc1.us-ashburn-1"}) {
+		t.Fatalf("should've deleted the image copies: %q", driver.DeleteImageInRegionIDs)
+	}
+	if driver.DeleteObjectReadURLID != "ocid1.par" {
+		t.Fatalf("should've deleted the pre-authenticated request: %q", driver.DeleteObjectReadURLID)
+	}
+}
+
+func TestStepCopyImage_compartments(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image"), CompartmentId: stringPtr("ocid1.compartment.dev")})
+	config := state.Get("config").(*Config)
+	config.ExportToObjectStorage = ImageExportConfig{Bucket: "images", ObjectName: "golden", ExportFormat: "OCI"}
+	config.ImageCopyCompartments = []string{"ocid1.compartment.test", "ocid1.compartment.prod"}
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepCopyImage)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if !reflect.DeepEqual(driver.CopyImageToCompartmentCompartments, config.ImageCopyCompartments) {
+		t.Fatalf("should've copied the image to every compartment: %q", driver.CopyImageToCompartmentCompartments)
+	}
+	if driver.CopyImageToCompartmentURI != "https://objectstorage.us-phoenix-1.oraclecloud.com/p/token/n/ns/b/images/o/golden" {
+		t.Fatalf("should've imported the copies from the pre-authenticated request: %q", driver.CopyImageToCompartmentURI)
+	}
+	if driver.CopyImageToRegionRegions != nil {
+		t.Fatalf("should not have copied the image to other regions: %q", driver.CopyImageToRegionRegions)
+	}
+	expected := map[string]string{
+		"ocid1.compartment.test": "ocid1.image.oc1.copy.ocid1.compartment.test",
+		"ocid1.compartment.prod": "ocid1.image.oc1.copy.ocid1.compartment.prod",
+	}
+	if copies := state.Get("compartment_images"); !reflect.DeepEqual(copies, expected) {
+		t.Fatalf("unexpected image copies: %v", copies)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteImageIDs != nil {
+		t.Fatalf("should've kept the image copies: %q", driver.DeleteImageIDs)
+	}
+	if driver.DeleteObjectReadURLID != "ocid1.par" {
+		t.Fatalf("should've deleted the pre-authenticated request: %q", driver.DeleteObjectReadURLID)
+	}
+}
+
+func TestStepCopyImage_compartmentsWaitErr(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	config := state.Get("config").(*Config)
+	config.ExportToObjectStorage = ImageExportConfig{Bucket: "images", ObjectName: "golden", ExportFormat: "OCI"}
+	config.ImageCopyCompartments = []string{"ocid1.compartment.prod"}
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForImageCopyErr = errors.New("error")
+
+	step := new(stepCopyImage)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("compartment_images"); ok {
+		t.Fatalf("should not have image copies")
+	}
+
+	step.Cleanup(state)
+
+	if !reflect.DeepEqual(driver.DeleteImageIDs, []string{"ocid1.image.oc1.copy.ocid1.compartment.prod"}) {
+		t.Fatalf("should've deleted the image copies: %q", driver.DeleteImageIDs)
+	}
+}
+
+func TestStepCopyImage_noRegions(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepCopyImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if driver.CopyImageToRegionRegions != nil {
+		t.Fatalf("should not have copied the image")
+	}
+}
@@ -6,12 +6,21 @@ package oci
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
 	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
 )
 
-type stepCreateInstance struct{}
+const (
+	instanceDisposalTerminate = "terminate"
+	instanceDisposalStop      = "stop"
+)
+
+type stepCreateInstance struct {
+	// maxRunTimer terminates the instance once it exceeded max_run_duration.
+	maxRunTimer *time.Timer
+}
 
 func (s *stepCreateInstance) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
 	var (
@@ -20,23 +29,52 @@ func (s *stepCreateInstance) Run(ctx context.Context, state multistep.StateBag)
 		config = state.Get("config").(*Config)
 	)
 
+	if len(config.AvailabilityDomains) > 0 {
+		config.AvailabilityDomain = config.AvailabilityDomains[0]
+	}
+
 	ui.Say("Creating instance...")
 
-	instanceID, err := driver.CreateInstance(ctx, string(config.Comm.SSHPublicKey))
+	instanceID, baseImageID, err := s.launch(ctx, driver, ui, config)
+	// Capacity on popular shapes frequently frees up within minutes
+	deadline := time.Now().Add(config.CapacityRetryTimeout)
+	for err != nil && isCapacityError(err) && time.Now().Add(config.CapacityRetryInterval).Before(deadline) {
+		ui.Say(fmt.Sprintf("Out of host capacity, retrying in %s...", config.CapacityRetryInterval))
+		select {
+		case <-ctx.Done():
+		case <-time.After(config.CapacityRetryInterval):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if len(config.AvailabilityDomains) > 0 {
+			config.AvailabilityDomain = config.AvailabilityDomains[0]
+		}
+		instanceID, baseImageID, err = s.launch(ctx, driver, ui, config)
+	}
 	if err != nil {
-		err = fmt.Errorf("Problem creating instance: %s", err)
+		err = fmt.Errorf("Problem creating instance: %w", err)
 		ui.Error(err.Error())
 		state.Put("error", err)
 		return multistep.ActionHalt
 	}
 
 	state.Put("instance_id", instanceID)
+	if baseImageID != "" {
+		state.Put("base_image_id", baseImageID)
+	}
 
 	ui.Say(fmt.Sprintf("Created instance (%s).", instanceID))
 
+	if config.MaxRunDuration > 0 {
+		s.maxRunTimer = time.AfterFunc(config.MaxRunDuration, func() {
+			s.terminateOverrunInstance(state, instanceID)
+		})
+	}
+
 	ui.Say("Waiting for instance to enter 'RUNNING' state...")
 
-	if err = driver.WaitForInstanceState(ctx, instanceID, []string{"STARTING", "PROVISIONING"}, "RUNNING"); err != nil {
+	if err = driver.WaitForInstanceState(ctx, instanceID, InstanceWaitStates("RUNNING"), "RUNNING"); err != nil {
 		err = fmt.Errorf("Error waiting for instance to start: %s", err)
 		ui.Error(err.Error())
 		state.Put("error", err)
@@ -48,9 +86,50 @@ func (s *stepCreateInstance) Run(ctx context.Context, state multistep.StateBag)
 	return multistep.ActionContinue
 }
 
+// launch creates the instance, falling back to the next availability domain
+// when out of capacity. The config keeps the one that was used, for the
+// resources created later on in the same availability domain. It returns the
+// instance and the base image it was launched from.
+func (s *stepCreateInstance) launch(ctx context.Context, driver Driver, ui packersdk.Ui, config *Config) (string, string, error) {
+	instanceID, baseImageID, err := driver.CreateInstance(ctx, string(config.Comm.SSHPublicKey))
+	for i := 1; err != nil && isCapacityError(err) && i < len(config.AvailabilityDomains); i++ {
+		ui.Say(fmt.Sprintf("Availability domain %s is out of capacity, trying %s...", config.AvailabilityDomain, config.AvailabilityDomains[i]))
+		config.AvailabilityDomain = config.AvailabilityDomains[i]
+		instanceID, baseImageID, err = driver.CreateInstance(ctx, string(config.Comm.SSHPublicKey))
+	}
+	return instanceID, baseImageID, err
+}
+
+// terminateOverrunInstance force-terminates an instance that exceeded
+// max_run_duration and cancels the build attempt, protecting the tenancy from
+// build instances left running by hung provisioners.
+func (s *stepCreateInstance) terminateOverrunInstance(state multistep.StateBag, id string) {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	state.Put("max_run_duration_exceeded", true)
+	ui.Error(fmt.Sprintf("Instance (%s) exceeded max_run_duration of %s, terminating it...", id, config.MaxRunDuration))
+
+	if err := driver.TerminateInstance(context.TODO(), id); err != nil {
+		ui.Error(fmt.Sprintf("Error terminating instance. Please terminate manually: %s", err))
+	}
+
+	if cancel, ok := state.GetOk("cancel_attempt"); ok {
+		cancel.(context.CancelFunc)()
+	}
+}
+
 func (s *stepCreateInstance) Cleanup(state multistep.StateBag) {
+	if s.maxRunTimer != nil {
+		s.maxRunTimer.Stop()
+	}
+
 	driver := state.Get("driver").(Driver)
 	ui := state.Get("ui").(packersdk.Ui)
+	config := state.Get("config").(*Config)
 
 	idRaw, ok := state.GetOk("instance_id")
 	if !ok {
@@ -58,8 +137,47 @@ func (s *stepCreateInstance) Cleanup(state multistep.StateBag) {
 	}
 	id := idRaw.(string)
 
+	if keepInstance(state) {
+		if ip, ok := state.GetOk("instance_ip"); ok {
+			ui.Say(fmt.Sprintf("Keeping instance (%s) running at %s.", id, ip))
+		} else {
+			ui.Say(fmt.Sprintf("Keeping instance (%s) running.", id))
+		}
+		return
+	}
+
+	// Record what happened to the instance when the build failed, so that
+	// failures caused by the platform (e.g. preemption) can be retried.
+	if _, failed := state.GetOk("error"); failed {
+		if instanceState, err := driver.GetInstanceState(context.TODO(), id); err == nil {
+			state.Put("instance_failure_state", instanceState)
+			if instanceState == "TERMINATED" {
+				if config.IsPreemptible {
+					ui.Say(fmt.Sprintf("Instance (%s) is already terminated, it was likely preempted.", id))
+				} else {
+					ui.Say(fmt.Sprintf("Instance (%s) is already terminated.", id))
+				}
+				return
+			}
+		}
+	}
+
+	if config.InstanceDisposal == instanceDisposalStop {
+		s.stopInstance(state, id)
+		return
+	}
+
 	ui.Say(fmt.Sprintf("Terminating instance (%s)...", id))
 
+	// Look the boot volume up while it is still attached
+	var bootVolumeID string
+	if config.PreserveBootVolume {
+		var err error
+		if bootVolumeID, err = driver.GetBootVolumeID(context.TODO(), id); err != nil {
+			ui.Error(fmt.Sprintf("Error looking up the boot volume of the instance: %s", err))
+		}
+	}
+
 	if err := driver.TerminateInstance(context.TODO(), id); err != nil {
 		err = fmt.Errorf("Error terminating instance. Please terminate manually: %s", err)
 		ui.Error(err.Error())
@@ -67,7 +185,7 @@ func (s *stepCreateInstance) Cleanup(state multistep.StateBag) {
 		return
 	}
 
-	err := driver.WaitForInstanceState(context.TODO(), id, []string{"TERMINATING"}, "TERMINATED")
+	err := driver.WaitForInstanceState(context.TODO(), id, InstanceWaitStates("TERMINATED"), "TERMINATED")
 	if err != nil {
 		err = fmt.Errorf("Error terminating instance. Please terminate manually: %s", err)
 		ui.Error(err.Error())
@@ -76,4 +194,47 @@ func (s *stepCreateInstance) Cleanup(state multistep.StateBag) {
 	}
 
 	ui.Say("Terminated instance.")
+	if bootVolumeID != "" {
+		ui.Say(fmt.Sprintf("Preserved boot volume (%s). Please delete it once no longer needed.", bootVolumeID))
+	}
+}
+
+// stopInstance stops the instance instead of terminating it, keeping it
+// around for inspection or reuse. An instance that is already stopped or
+// terminated is left as it is.
+func (s *stepCreateInstance) stopInstance(state multistep.StateBag, id string) {
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packersdk.Ui)
+
+	// The instance restarted after the image was captured is kept running,
+	// unless the build failed afterwards
+	if _, ok := state.GetOk("instance_restarted"); ok {
+		if _, failed := state.GetOk("error"); !failed {
+			ui.Say(fmt.Sprintf("Keeping instance (%s) running. Please terminate it once no longer needed.", id))
+			return
+		}
+	}
+
+	instanceState, err := driver.GetInstanceState(context.TODO(), id)
+	if err == nil && instanceState == "TERMINATED" {
+		ui.Say(fmt.Sprintf("Instance (%s) is already terminated.", id))
+		return
+	}
+
+	ui.Say(fmt.Sprintf("Stopping instance (%s)...", id))
+
+	if err != nil || instanceState != "STOPPED" {
+		err = driver.InstanceAction(context.TODO(), id, "SOFTSTOP")
+		if err == nil {
+			err = driver.WaitForInstanceState(context.TODO(), id, InstanceWaitStates("STOPPED"), "STOPPED")
+		}
+		if err != nil {
+			err = fmt.Errorf("Error stopping instance. Please stop or terminate it manually: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return
+		}
+	}
+
+	ui.Say(fmt.Sprintf("Stopped instance (%s). Please terminate it once no longer needed.", id))
 }
@@ -6,9 +6,13 @@ package oci
 import (
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
 )
 
 func TestStepCreateInstance(t *testing.T) {
@@ -38,6 +42,112 @@ func TestStepCreateInstance(t *testing.T) {
 	}
 }
 
+func TestStepCreateInstance_resolvedBaseImage(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	state.Get("config").(*Config).BaseImageID = ""
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateInstanceBaseImageID = "ocid1.image.oc1.iad.resolved"
+
+	step := new(stepCreateInstance)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if baseImageID, _ := state.GetOk("base_image_id"); baseImageID != "ocid1.image.oc1.iad.resolved" {
+		t.Fatalf("should've recorded the resolved base image: %#v", baseImageID)
+	}
+}
+
+func TestStepCreateInstance_PreserveBootVolume(t *testing.T) {
+	state := testState()
+	ui := &packersdk.MockUi{}
+	state.Put("ui", ui)
+	state.Get("config").(*Config).PreserveBootVolume = true
+
+	step := new(stepCreateInstance)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	step.Cleanup(state)
+
+	if driver.TerminateInstanceID == "" {
+		t.Fatal("should've terminated instance")
+	}
+	last := ui.SayMessages[len(ui.SayMessages)-1].Message
+	if !strings.Contains(last, "Preserved boot volume (ocid1.bootvolume...)") {
+		t.Fatalf("should've reported the preserved boot volume, got %q", last)
+	}
+}
+
+func TestStepCreateInstance_InstanceDisposalStop(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).InstanceDisposal = instanceDisposalStop
+
+	step := new(stepCreateInstance)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	step.Cleanup(state)
+
+	if driver.TerminateInstanceID != "" {
+		t.Fatal("should NOT have terminated instance")
+	}
+	if !reflect.DeepEqual(driver.InstanceActionActions, []string{"SOFTSTOP"}) {
+		t.Fatalf("should've stopped instance: %q", driver.InstanceActionActions)
+	}
+}
+
+func TestStepCreateInstance_InstanceDisposalStopStopped(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).InstanceDisposal = instanceDisposalStop
+
+	step := new(stepCreateInstance)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	driver.GetInstanceStateState = "STOPPED"
+	step.Cleanup(state)
+
+	if driver.TerminateInstanceID != "" || len(driver.InstanceActionActions) != 0 {
+		t.Fatal("should've left the stopped instance as it is")
+	}
+}
+
+func TestStepCreateInstance_InstanceDisposalStopRestarted(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).InstanceDisposal = instanceDisposalStop
+
+	step := new(stepCreateInstance)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	state.Put("instance_restarted", true)
+	step.Cleanup(state)
+
+	if driver.TerminateInstanceID != "" || len(driver.InstanceActionActions) != 0 {
+		t.Fatal("should've kept the restarted instance running")
+	}
+}
+
 func TestStepCreateInstance_CreateInstanceErr(t *testing.T) {
 	state := testState()
 	state.Put("publicKey", "key")
@@ -132,3 +242,174 @@ func TestStepCreateInstanceCleanup_WaitForInstanceStateErr(t *testing.T) {
 		t.Fatalf("should have error")
 	}
 }
+
+func TestStepCreateInstance_PreemptedInstance(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+
+	step := new(stepCreateInstance)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	// A later step failed after the instance was terminated by the platform.
+	state.Put("error", errors.New("error"))
+	driver.GetInstanceStateState = "TERMINATED"
+
+	step.Cleanup(state)
+
+	if instanceState := state.Get("instance_failure_state"); instanceState != "TERMINATED" {
+		t.Fatalf("should have recorded instance state, got %v", instanceState)
+	}
+
+	if driver.TerminateInstanceID != "" {
+		t.Fatalf("should NOT have tried to terminate an already terminated instance")
+	}
+}
+
+func TestStepCreateInstance_AvailabilityDomainFallback(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	config := state.Get("config").(*Config)
+	config.AvailabilityDomains = []string{"aaaa:US-ASHBURN-AD-1", "aaaa:US-ASHBURN-AD-2", "aaaa:US-ASHBURN-AD-3"}
+
+	step := new(stepCreateInstance)
+	defer step.Cleanup(state)
+
+	capacityErr := newRequestError("LaunchInstance", nil, testServiceError{
+		statusCode: 500,
+		code:       "InternalError",
+		message:    "Out of host capacity.",
+	})
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateInstanceADErrs = map[string]error{
+		"aaaa:US-ASHBURN-AD-1": capacityErr,
+		"aaaa:US-ASHBURN-AD-2": capacityErr,
+	}
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if config.AvailabilityDomain != "aaaa:US-ASHBURN-AD-3" {
+		t.Fatalf("should've launched in the third availability domain, got %s", config.AvailabilityDomain)
+	}
+}
+
+func TestStepCreateInstance_AvailabilityDomainNoFallback(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	config := state.Get("config").(*Config)
+	config.AvailabilityDomains = []string{"aaaa:US-ASHBURN-AD-1", "aaaa:US-ASHBURN-AD-2"}
+
+	step := new(stepCreateInstance)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateInstanceADErrs = map[string]error{
+		"aaaa:US-ASHBURN-AD-1": errors.New("not authorized"),
+	}
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if config.AvailabilityDomain != "aaaa:US-ASHBURN-AD-1" {
+		t.Fatalf("should NOT have fallen back on a non capacity error")
+	}
+}
+
+func TestStepCreateInstance_CapacityRetry(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	config := state.Get("config").(*Config)
+	config.CapacityRetryTimeout = time.Minute
+	config.CapacityRetryInterval = time.Millisecond
+
+	step := new(stepCreateInstance)
+	defer step.Cleanup(state)
+
+	capacityErr := newRequestError("LaunchInstance", nil, testServiceError{
+		statusCode: 500,
+		code:       "InternalError",
+		message:    "Out of host capacity.",
+	})
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateInstanceErrs = []error{capacityErr, capacityErr}
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("instance_id"); !ok {
+		t.Fatalf("should have launched the instance once capacity freed up")
+	}
+}
+
+func TestStepCreateInstance_CapacityRetryTimeout(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	config := state.Get("config").(*Config)
+	config.CapacityRetryTimeout = 0
+
+	step := new(stepCreateInstance)
+	defer step.Cleanup(state)
+
+	capacityErr := newRequestError("LaunchInstance", nil, testServiceError{
+		statusCode: 500,
+		code:       "InternalError",
+		message:    "Out of host capacity.",
+	})
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateInstanceErrs = []error{capacityErr}
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
+
+func TestStepCreateInstance_MaxRunDuration(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	state.Get("config").(*Config).MaxRunDuration = time.Millisecond
+
+	cancelled := make(chan struct{})
+	state.Put("cancel_attempt", context.CancelFunc(func() { close(cancelled) }))
+
+	step := new(stepCreateInstance)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(10 * time.Second):
+		t.Fatal("should have cancelled the build attempt")
+	}
+
+	if driver.TerminateInstanceID == "" {
+		t.Fatal("should have terminated the instance")
+	}
+	if _, ok := state.GetOk("max_run_duration_exceeded"); !ok {
+		t.Fatal("should have recorded that max_run_duration was exceeded")
+	}
+
+	// The build fails once cancelled, and the instance is not mistaken for
+	// a preempted one
+	state.Put("error", errors.New("error"))
+	driver.GetInstanceStateState = "TERMINATING"
+	step.Cleanup(state)
+	if class := buildFailureClass(state); class != "" {
+		t.Fatalf("should NOT be a retryable failure, got %q", class)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepDetachBlockVolumes logs out of the iSCSI block_volumes and detaches and
+// deletes the volumes of stepBlockVolumes once provisioning is over, so that
+// the captured image does not reference them.
+type stepDetachBlockVolumes struct {
+	volumes *stepBlockVolumes
+}
+
+func (s *stepDetachBlockVolumes) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	ui := state.Get("ui").(packersdk.Ui)
+
+	if len(s.volumes.attachments) == 0 && len(s.volumes.volumeIDs) == 0 {
+		return multistep.ActionContinue
+	}
+
+	if len(s.volumes.detachCommands) > 0 {
+		comm, ok := state.GetOk("communicator")
+		if !ok || comm == nil {
+			ui.Say("No communicator, skipping iSCSI logout...")
+		} else {
+			ui.Say("Logging out of iSCSI block volumes...")
+			for _, command := range s.volumes.detachCommands {
+				cmd := &packersdk.RemoteCmd{Command: command}
+				err := cmd.RunWithUi(ctx, comm.(packersdk.Communicator), ui)
+				if err == nil && cmd.ExitStatus() != 0 {
+					err = fmt.Errorf("%q exited with status %d", command, cmd.ExitStatus())
+				}
+				if err != nil {
+					err = fmt.Errorf("Error logging out of iSCSI block volume: %s", err)
+					ui.Error(err.Error())
+					state.Put("error", err)
+					return multistep.ActionHalt
+				}
+			}
+		}
+	}
+
+	if !s.volumes.release(ctx, state) {
+		return multistep.ActionHalt
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepDetachBlockVolumes) Cleanup(state multistep.StateBag) {
+	// stepBlockVolumes releases the volumes when the build fails
+}
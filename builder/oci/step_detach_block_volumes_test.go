@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func TestStepDetachBlockVolumes(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).BlockVolumes = []BlockVolumeConfig{
+		{SizeInGBs: 50, AttachmentType: volumeAttachmentTypeISCSI},
+		{VolumeID: "ocid1.volume.oc1..aaa"},
+	}
+	comm := new(packersdk.MockCommunicator)
+	state.Put("communicator", comm)
+	driver := state.Get("driver").(*driverMock)
+
+	volumes := new(stepBlockVolumes)
+	step := &stepDetachBlockVolumes{volumes: volumes}
+
+	if action := volumes.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if !strings.HasPrefix(comm.StartCmd.Command, "sudo iscsiadm -m node -o delete") {
+		t.Fatalf("should've logged out of the iSCSI volume: %q", comm.StartCmd.Command)
+	}
+	if !reflect.DeepEqual(driver.DetachVolumeIDs, driver.AttachVolumeIDs) {
+		t.Fatalf("should've detached volumes (%v != %v)", driver.DetachVolumeIDs, driver.AttachVolumeIDs)
+	}
+	if !reflect.DeepEqual(driver.DeleteVolumeIDs, driver.CreateVolumeIDs) {
+		t.Fatalf("should've deleted the created volume (%v != %v)", driver.DeleteVolumeIDs, driver.CreateVolumeIDs)
+	}
+
+	// Nothing is left for the cleanup
+	step.Cleanup(state)
+	volumes.Cleanup(state)
+
+	if len(driver.DetachVolumeIDs) != 2 || len(driver.DeleteVolumeIDs) != 1 {
+		t.Fatalf("should not have released the volumes twice")
+	}
+}
+
+func TestStepDetachBlockVolumes_detachErr(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).BlockVolumes = []BlockVolumeConfig{{SizeInGBs: 50}}
+	driver := state.Get("driver").(*driverMock)
+
+	volumes := new(stepBlockVolumes)
+	step := &stepDetachBlockVolumes{volumes: volumes}
+
+	if action := volumes.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	driver.DetachVolumeErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+	if len(driver.DeleteVolumeIDs) != 0 {
+		t.Fatalf("should NOT have deleted a volume that is still attached")
+	}
+}
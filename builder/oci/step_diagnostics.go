@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// maxClockSkew is how far the local clock may drift from OCI before request
+// signatures are rejected with a 401.
+const maxClockSkew = 5 * time.Minute
+
+// diagnosticCheck is one check of the diagnostics report.
+type diagnosticCheck struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// stepDiagnostics checks the credentials, the reachability of the OCI
+// endpoints, the local clock and the configured resources, and prints a
+// pass/fail report instead of building anything.
+type stepDiagnostics struct{}
+
+func (s *stepDiagnostics) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	ui.Say("Running diagnostics...")
+
+	var failed int
+	for _, check := range diagnosticChecks(driver, config) {
+		if err := check.run(ctx); err != nil {
+			failed++
+			ui.Error(fmt.Sprintf("FAIL %s: %s%s", check.name, err, authHint(err)))
+			continue
+		}
+		ui.Message(fmt.Sprintf("PASS %s", check.name))
+	}
+
+	if failed > 0 {
+		err := fmt.Errorf("%d diagnostics failed", failed)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say("All diagnostics passed.")
+	return multistep.ActionContinue
+}
+
+func (s *stepDiagnostics) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
+
+// diagnosticChecks returns the checks that apply to the configuration. The
+// endpoints and the clock are checked first, as the other checks fail when
+// they do.
+func diagnosticChecks(driver Driver, config *Config) []diagnosticCheck {
+	var checks []diagnosticCheck
+
+	endpoints := driver.Endpoints()
+	for _, endpoint := range endpoints {
+		endpoint := endpoint
+		name := endpoint
+		if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+			name = u.Host
+		}
+		checks = append(checks, diagnosticCheck{
+			name: fmt.Sprintf("endpoint %s is reachable", name),
+			run: func(ctx context.Context) error {
+				_, err := driver.GetEndpointTime(ctx, endpoint)
+				return err
+			},
+		})
+	}
+
+	if len(endpoints) > 0 {
+		checks = append(checks, diagnosticCheck{
+			name: "local clock is in sync with OCI",
+			run: func(ctx context.Context) error {
+				remote, err := driver.GetEndpointTime(ctx, endpoints[0])
+				if err != nil {
+					return err
+				}
+				// The Date header has a one second resolution
+				skew := time.Since(remote).Truncate(time.Second)
+				if skew < 0 {
+					skew = -skew
+				}
+				if skew > maxClockSkew {
+					return fmt.Errorf("the local clock is %s off, OCI rejects requests signed more than %s off", skew, maxClockSkew)
+				}
+				return nil
+			},
+		})
+	}
+
+	checks = append(checks, diagnosticCheck{
+		name: fmt.Sprintf("credentials authenticate and compartment_ocid %s is active", config.CompartmentID),
+		run: stateCheck(func(ctx context.Context) (string, error) {
+			return driver.GetCompartmentState(ctx, config.CompartmentID)
+		}, "ACTIVE"),
+	})
+
+	if config.ImageCompartmentID != config.CompartmentID {
+		checks = append(checks, diagnosticCheck{
+			name: fmt.Sprintf("image_compartment_ocid %s is active", config.ImageCompartmentID),
+			run: stateCheck(func(ctx context.Context) (string, error) {
+				return driver.GetCompartmentState(ctx, config.ImageCompartmentID)
+			}, "ACTIVE"),
+		})
+	}
+
+	if config.ImageDestinationCompartmentID != "" {
+		checks = append(checks, diagnosticCheck{
+			name: fmt.Sprintf("image_destination_compartment_ocid %s is active", config.ImageDestinationCompartmentID),
+			run: stateCheck(func(ctx context.Context) (string, error) {
+				return driver.GetCompartmentState(ctx, config.ImageDestinationCompartmentID)
+			}, "ACTIVE"),
+		})
+	}
+
+	if config.SubnetID != "" {
+		checks = append(checks, diagnosticCheck{
+			name: fmt.Sprintf("subnet_ocid %s is available", config.SubnetID),
+			run:  stateCheck(func(ctx context.Context) (string, error) { return driver.GetSubnetState(ctx, config.SubnetID) }, "AVAILABLE"),
+		})
+	}
+
+	if config.BaseImageID != "" {
+		checks = append(checks, diagnosticCheck{
+			name: fmt.Sprintf("base_image_ocid %s is available", config.BaseImageID),
+			run:  stateCheck(func(ctx context.Context) (string, error) { return driver.GetImageState(ctx, config.BaseImageID) }, "AVAILABLE"),
+		})
+	}
+
+	// Base images are commonly shared from another compartment or tenancy
+	if config.BaseImageID == "" && *config.BaseImageFilter.CompartmentId != config.CompartmentID {
+		compartmentID := *config.BaseImageFilter.CompartmentId
+		checks = append(checks, diagnosticCheck{
+			name: fmt.Sprintf("images can be listed in base_image_filter.compartment_id %s", compartmentID),
+			run: func(ctx context.Context) error {
+				if _, err := driver.ListCustomImages(ctx, compartmentID); err != nil {
+					return fmt.Errorf("%w%s", err, crossCompartmentHint(err))
+				}
+				return nil
+			},
+		})
+	}
+
+	return checks
+}
+
+// stateCheck returns a check passing when the lifecycle state returned by
+// getState is the expected one.
+func stateCheck(getState func(ctx context.Context) (string, error), expected string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		state, err := getState(ctx)
+		if err != nil {
+			return err
+		}
+		if state != expected {
+			return fmt.Errorf("lifecycle state is %s", state)
+		}
+		return nil
+	}
+}
+
+// crossCompartmentHint returns a hint on the policies needed to list images
+// in a compartment of another tenancy when OCI did not authorize it.
+func crossCompartmentHint(err error) string {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.StatusCode == 404 {
+		return " (for a compartment of another tenancy, check that both tenancies have policies endorsing and admitting the build to read instance-images)"
+	}
+	return ""
+}
+
+// authHint returns a hint on the usual causes of authentication failures.
+func authHint(err error) string {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.StatusCode == 401 {
+		return " (check the user, key_file and fingerprint, and that the local clock is in sync)"
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func TestStepDiagnostics(t *testing.T) {
+	state := testState()
+	step := new(stepDiagnostics)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); ok {
+		t.Fatalf("should NOT have error")
+	}
+}
+
+func TestStepDiagnostics_ClockSkew(t *testing.T) {
+	state := testState()
+	ui := &packersdk.MockUi{}
+	state.Put("ui", ui)
+	step := new(stepDiagnostics)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.GetEndpointTimeTime = time.Now().Add(-10 * time.Minute)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+	if !strings.Contains(ui.ErrorMessage, "1 diagnostics failed") {
+		t.Fatalf("expected only the clock check to fail, got %q", ui.ErrorMessage)
+	}
+}
+
+func TestStepDiagnostics_Unauthorized(t *testing.T) {
+	state := testState()
+	ui := &packersdk.MockUi{}
+	state.Put("ui", ui)
+	driver := state.Get("driver").(*driverMock)
+	driver.GetCompartmentStateErr = &RequestError{Operation: "GetCompartment", StatusCode: 401, Err: errors.New("not authenticated")}
+	driver.GetImageStateState = "DELETED"
+
+	checks := diagnosticChecks(driver, state.Get("config").(*Config))
+
+	var failures []string
+	for _, check := range checks {
+		if err := check.run(context.Background()); err != nil {
+			failures = append(failures, check.name+": "+err.Error()+authHint(err))
+		}
+	}
+
+	if len(failures) != 2 {
+		t.Fatalf("expected the compartment and image checks to fail, got %q", failures)
+	}
+	if !strings.Contains(failures[0], "local clock is in sync") {
+		t.Errorf("expected a hint on 401 errors, got %q", failures[0])
+	}
+	if !strings.Contains(failures[1], "lifecycle state is DELETED") {
+		t.Errorf("expected the image state, got %q", failures[1])
+	}
+}
+
+func TestStepDiagnostics_BaseImageCompartment(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.BaseImageID = ""
+	config.BaseImageFilter.CompartmentId = stringPtr("ocid1.compartment.oc1..shared")
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesErr = &RequestError{Operation: "ListImages", StatusCode: 404, Err: errors.New("not authorized or not found")}
+
+	var failures []string
+	for _, check := range diagnosticChecks(driver, config) {
+		if err := check.run(context.Background()); err != nil {
+			failures = append(failures, check.name+": "+err.Error())
+		}
+	}
+
+	if len(failures) != 1 || !strings.Contains(failures[0], "base_image_filter.compartment_id ocid1.compartment.oc1..shared") {
+		t.Fatalf("expected the base image compartment check to fail, got %q", failures)
+	}
+	if !strings.Contains(failures[0], "endorsing and admitting") {
+		t.Errorf("expected a hint on cross-tenancy policies, got %q", failures[0])
+	}
+}
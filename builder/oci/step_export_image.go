@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// stepExportImage exports the image to Object Storage with
+// export_to_object_storage, and records the URI of the exported object for
+// the artifact.
+type stepExportImage struct{}
+
+func (s *stepExportImage) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	rawImage, ok := state.GetOk("image")
+	if !ok || config.ExportToObjectStorage.Bucket == "" {
+		return multistep.ActionContinue
+	}
+	image := rawImage.(core.Image)
+
+	// The object name is kept for the image copies
+	if config.ExportToObjectStorage.ObjectName == "" {
+		config.ExportToObjectStorage.ObjectName = config.imageName()
+	}
+	export := config.ExportToObjectStorage
+
+	// The exported object is encrypted with the key of the bucket
+	if export.KmsKeyID != "" {
+		keyID, err := driver.GetBucketKmsKeyID(ctx, export)
+		if err == nil && keyID != export.KmsKeyID {
+			err = fmt.Errorf("bucket %s is not encrypted with kms_key_ocid %s", export.Bucket, export.KmsKeyID)
+		}
+		if err != nil {
+			err = fmt.Errorf("Error checking the encryption key of the export bucket: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+	}
+
+	ui.Say(fmt.Sprintf("Exporting image to %s/%s as %s...", export.Bucket, export.ObjectName, export.ExportFormat))
+
+	workRequestID, uri, err := driver.ExportImage(ctx, *image.Id, export)
+	if err != nil {
+		err = fmt.Errorf("Error exporting image: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if err := driver.WaitForWorkRequest(ctx, workRequestID, workRequestProgress(ui, "Image export")); err != nil {
+		err = fmt.Errorf("Error waiting for image export (%s) to finish: %s", workRequestID, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	state.Put("image_export_uri", uri)
+
+	ui.Say(fmt.Sprintf("Exported image to %s.", uri))
+
+	if export.ShareURLLifetime == 0 {
+		return multistep.ActionContinue
+	}
+
+	// The pre-authenticated request is meant to outlive the build, so it is
+	// left to expire rather than deleted
+	expires := time.Now().Add(export.ShareURLLifetime)
+	_, shareURL, err := driver.CreateObjectReadURL(ctx, export, "image-share", expires)
+	if err != nil {
+		err = fmt.Errorf("Error creating pre-authenticated request for the exported image: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	state.Put("image_share_url", shareURL)
+	state.Put("image_share_url_expires", expires)
+
+	ui.Say(fmt.Sprintf("Created pre-authenticated request for the exported image, valid until %s.", expires.Format(time.RFC3339)))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepExportImage) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
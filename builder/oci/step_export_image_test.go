@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestStepExportImage(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	config := state.Get("config").(*Config)
+	config.ImageName = "golden"
+	config.ExportToObjectStorage = ImageExportConfig{Bucket: "images", ExportFormat: "QCOW2"}
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepExportImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.ExportImageID != "ocid1.image" || driver.ExportImageExport.ObjectName != "golden" {
+		t.Fatalf("should've exported the image as its name: %q %#v", driver.ExportImageID, driver.ExportImageExport)
+	}
+	if driver.WaitForWorkRequestID != "ocid1.workrequest.export" {
+		t.Fatalf("should've waited for the export: %q", driver.WaitForWorkRequestID)
+	}
+	expected := "https://objectstorage.us-phoenix-1.oraclecloud.com/n/tenancy-namespace/b/images/o/golden"
+	if uri := state.Get("image_export_uri"); uri != expected {
+		t.Fatalf("unexpected export URI: %v", uri)
+	}
+}
+
+func TestStepExportImage_waitErr(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	state.Get("config").(*Config).ExportToObjectStorage = ImageExportConfig{Bucket: "images", ObjectName: "golden.oci"}
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForWorkRequestErr = errors.New("error")
+
+	step := new(stepExportImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+	if _, ok := state.GetOk("image_export_uri"); ok {
+		t.Fatalf("should not have an export URI")
+	}
+}
+
+func TestStepExportImage_shareURL(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	state.Get("config").(*Config).ExportToObjectStorage = ImageExportConfig{
+		Bucket:           "images",
+		ObjectName:       "golden.oci",
+		ShareURLLifetime: 7 * 24 * time.Hour,
+	}
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepExportImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if !reflect.DeepEqual(driver.CreateObjectReadURLPurposes, []string{"image-share"}) {
+		t.Fatalf("should've created a pre-authenticated request to share the image: %q", driver.CreateObjectReadURLPurposes)
+	}
+	if until := time.Until(driver.CreateObjectReadURLExpires); until < 6*24*time.Hour || until > 7*24*time.Hour {
+		t.Fatalf("unexpected expiry: %s", driver.CreateObjectReadURLExpires)
+	}
+	expected := "https://objectstorage.us-phoenix-1.oraclecloud.com/p/token/n/ns/b/images/o/golden.oci"
+	if shareURL := state.Get("image_share_url"); shareURL != expected {
+		t.Fatalf("unexpected share URL: %v", shareURL)
+	}
+	if expires := state.Get("image_share_url_expires"); expires != driver.CreateObjectReadURLExpires {
+		t.Fatalf("unexpected share URL expiry: %v", expires)
+	}
+}
+
+func TestStepExportImage_kmsKey(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	state.Get("config").(*Config).ExportToObjectStorage = ImageExportConfig{
+		Bucket:     "images",
+		ObjectName: "golden.oci",
+		KmsKeyID:   "ocid1.key.images",
+	}
+	driver := state.Get("driver").(*driverMock)
+	driver.GetBucketKmsKeyIDKey = "ocid1.key.other"
+
+	step := new(stepExportImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+	if driver.ExportImageID != "" {
+		t.Fatalf("should not have exported the image to a bucket encrypted with another key")
+	}
+
+	driver.GetBucketKmsKeyIDKey = "ocid1.key.images"
+	state.Remove("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if driver.ExportImageID != "ocid1.image" {
+		t.Fatalf("should've exported the image: %q", driver.ExportImageID)
+	}
+}
+
+func TestStepExportImage_disabled(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepExportImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if driver.ExportImageID != "" {
+		t.Fatalf("should not have exported the image")
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+const (
+	firstBootValidationScript  = "/usr/local/bin/packer-first-boot-validation"
+	firstBootValidationLogFile = "/var/log/packer-first-boot-validation.log"
+	firstBootValidationPass    = "FIRST_BOOT_VALIDATION PASS"
+)
+
+// firstBootValidationBundle describes how to check the result of the first
+// boot validation user data of an image.
+type firstBootValidationBundle struct {
+	ImageID        string   `json:"image_id"`
+	ImageName      string   `json:"image_name"`
+	Assertions     []string `json:"assertions"`
+	LogFile        string   `json:"log_file"`
+	ExpectedOutput string   `json:"expected_output"`
+}
+
+// stepFirstBootValidation writes a validation bundle next to the image: a
+// cloud-init user data that runs the configured assertions at first boot and
+// a description of the expected outcome.
+type stepFirstBootValidation struct{}
+
+func (s *stepFirstBootValidation) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	imageRaw, ok := state.GetOk("image")
+	if len(config.FirstBootValidation.Assertions) == 0 || !ok {
+		return multistep.ActionContinue
+	}
+	image := imageRaw.(core.Image)
+
+	dir := config.FirstBootValidation.OutputDirectory
+	ui.Say(fmt.Sprintf("Writing first boot validation bundle to %s...", dir))
+
+	bundle, err := json.MarshalIndent(firstBootValidationBundle{
+		ImageID:        *image.Id,
+		ImageName:      config.imageName(),
+		Assertions:     config.FirstBootValidation.Assertions,
+		LogFile:        firstBootValidationLogFile,
+		ExpectedOutput: firstBootValidationPass,
+	}, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(dir, 0755)
+	}
+
+	files := []string{filepath.Join(dir, "user-data.yaml"), filepath.Join(dir, "assertions.json")}
+	if err == nil {
+		err = os.WriteFile(files[0], []byte(firstBootValidationUserData(config.FirstBootValidation.Assertions)), 0644)
+	}
+	if err == nil {
+		err = os.WriteFile(files[1], append(bundle, '\n'), 0644)
+	}
+	if err != nil {
+		err = fmt.Errorf("Error writing first boot validation bundle: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	state.Put("first_boot_validation_files", files)
+
+	return multistep.ActionContinue
+}
+
+func (s *stepFirstBootValidation) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
+
+// firstBootValidationUserData returns a cloud-config that runs each
+// assertion with sh at first boot. The outcome is written to the serial
+// console and to firstBootValidationLogFile, ending with
+// firstBootValidationPass when all assertions succeeded.
+func firstBootValidationUserData(assertions []string) string {
+	var script strings.Builder
+	script.WriteString(`#!/bin/sh
+failed=0
+check() {
+  if echo "$2" | base64 -d | sh; then
+    echo "FIRST_BOOT_VALIDATION assertion $1 PASS"
+  else
+    echo "FIRST_BOOT_VALIDATION assertion $1 FAIL"
+    failed=1
+  fi
+}
+`)
+	// Assertions are base64 encoded so that they need no quoting
+	for i, assertion := range assertions {
+		fmt.Fprintf(&script, "check %d %s\n", i+1, base64.StdEncoding.EncodeToString([]byte(assertion)))
+	}
+	script.WriteString(`if [ "$failed" -eq 0 ]; then
+  echo "` + firstBootValidationPass + `"
+else
+  echo "FIRST_BOOT_VALIDATION FAIL"
+fi
+exit "$failed"
+`)
+
+	var userData strings.Builder
+	userData.WriteString("#cloud-config\nwrite_files:\n")
+	fmt.Fprintf(&userData, "  - path: %s\n    permissions: '0755'\n    content: |\n", firstBootValidationScript)
+	for _, line := range strings.Split(strings.TrimSuffix(script.String(), "\n"), "\n") {
+		fmt.Fprintf(&userData, "      %s\n", line)
+	}
+	fmt.Fprintf(&userData, "runcmd:\n  - [sh, -c, '%s 2>&1 | tee %s /dev/console']\n", firstBootValidationScript, firstBootValidationLogFile)
+
+	return userData.String()
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestStepFirstBootValidation(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.FirstBootValidation.Assertions = []string{"test -f '/etc/motd'"}
+	config.FirstBootValidation.OutputDirectory = t.TempDir()
+	imageID := "ocid1.image.oc1.iad.bbb"
+	state.Put("image", core.Image{Id: &imageID})
+
+	step := new(stepFirstBootValidation)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	files, ok := state.GetOk("first_boot_validation_files")
+	if !ok || len(files.([]string)) != 2 {
+		t.Fatalf("should have first_boot_validation_files")
+	}
+
+	userData, err := os.ReadFile(files.([]string)[0])
+	if err != nil {
+		t.Fatalf("should have written user data: %s", err)
+	}
+	if !strings.HasPrefix(string(userData), "#cloud-config\n") {
+		t.Fatalf("user data should be a cloud-config:\n%s", userData)
+	}
+	if !strings.Contains(string(userData), base64.StdEncoding.EncodeToString([]byte("test -f '/etc/motd'"))) {
+		t.Fatalf("user data should contain the assertion:\n%s", userData)
+	}
+
+	assertions, err := os.ReadFile(files.([]string)[1])
+	if err != nil {
+		t.Fatalf("should have written assertions: %s", err)
+	}
+	if !strings.Contains(string(assertions), imageID) {
+		t.Fatalf("assertions should reference the image:\n%s", assertions)
+	}
+}
+
+func TestStepFirstBootValidation_NoImage(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.FirstBootValidation.Assertions = []string{"true"}
+	config.FirstBootValidation.OutputDirectory = t.TempDir()
+
+	step := new(stepFirstBootValidation)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("first_boot_validation_files"); ok {
+		t.Fatalf("should NOT have written a bundle without an image")
+	}
+}
@@ -5,14 +5,21 @@ package oci
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"time"
 
 	"github.com/hashicorp/packer-plugin-sdk/communicator"
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
 	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
 )
 
+// initialCredentialsTimeout bounds how long to wait for a Windows instance to
+// generate its initial credentials.
+const initialCredentialsTimeout = 10 * time.Minute
+
 type stepGetDefaultCredentials struct {
 	Debug     bool
 	Comm      *communicator.Config
@@ -21,9 +28,10 @@ type stepGetDefaultCredentials struct {
 
 func (s *stepGetDefaultCredentials) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
 	var (
-		driver = state.Get("driver").(*driverOCI)
+		driver = state.Get("driver").(Driver)
 		ui     = state.Get("ui").(packersdk.Ui)
 		id     = state.Get("instance_id").(string)
+		config = state.Get("config").(*Config)
 	)
 
 	// Skip if we're not using winrm
@@ -38,7 +46,9 @@ func (s *stepGetDefaultCredentials) Run(ctx context.Context, state multistep.Sta
 		return multistep.ActionContinue
 	}
 
-	username, password, err := driver.GetInstanceInitialCredentials(ctx, id)
+	ui.Say("Waiting for the initial credentials of the instance...")
+
+	username, password, err := s.waitForCredentials(ctx, driver, id, config.Timeouts.PollingInterval)
 	if err != nil {
 		err = fmt.Errorf("Error getting instance's credentials: %s", err)
 		ui.Error(err.Error())
@@ -59,6 +69,32 @@ func (s *stepGetDefaultCredentials) Run(ctx context.Context, state multistep.Sta
 	return multistep.ActionContinue
 }
 
+// waitForCredentials polls for the initial credentials of the instance, which
+// are only available once its first boot generated them.
+func (s *stepGetDefaultCredentials) waitForCredentials(ctx context.Context, driver Driver, id string, interval time.Duration) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, initialCredentialsTimeout)
+	defer cancel()
+
+	for {
+		username, password, err := driver.GetInstanceInitialCredentials(ctx, id)
+		if err == nil {
+			return username, password, nil
+		}
+
+		var reqErr *RequestError
+		if !errors.As(err, &reqErr) || (reqErr.StatusCode != http.StatusNotFound && reqErr.StatusCode != http.StatusConflict) {
+			return "", "", err
+		}
+		log.Printf("[DEBUG] Initial credentials not available yet: %s", err)
+
+		select {
+		case <-ctx.Done():
+			return "", "", fmt.Errorf("not available after %s: %s", initialCredentialsTimeout, err)
+		case <-time.After(interval):
+		}
+	}
+}
+
 func (s *stepGetDefaultCredentials) Cleanup(state multistep.StateBag) {
 	// no cleanup
 }
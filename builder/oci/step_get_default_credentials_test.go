@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/communicator"
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepGetDefaultCredentials(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).Timeouts.PollingInterval = time.Millisecond
+	driver := state.Get("driver").(*driverMock)
+	driver.GetInstanceInitialCredentialsErrs = []error{
+		&RequestError{Operation: "GetWindowsInstanceInitialCredentials", StatusCode: 404, Err: errors.New("not found")},
+	}
+
+	comm := &communicator.Config{Type: "winrm"}
+	step := &stepGetDefaultCredentials{Comm: comm}
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.GetInstanceInitialCredentialsCalls != 2 {
+		t.Fatalf("should've retried until the credentials were available, got %d calls", driver.GetInstanceInitialCredentialsCalls)
+	}
+	if comm.WinRMUser != "opc" || comm.WinRMPassword != "initial-password" {
+		t.Fatalf("unexpected credentials: %s/%s", comm.WinRMUser, comm.WinRMPassword)
+	}
+}
+
+func TestStepGetDefaultCredentials_notAuthorized(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	driver := state.Get("driver").(*driverMock)
+	driver.GetInstanceInitialCredentialsErrs = []error{
+		&RequestError{Operation: "GetWindowsInstanceInitialCredentials", StatusCode: 401, Err: errors.New("not authenticated")},
+	}
+
+	step := &stepGetDefaultCredentials{Comm: &communicator.Config{Type: "winrm"}}
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.GetInstanceInitialCredentialsCalls != 1 {
+		t.Fatalf("should not have retried, got %d calls", driver.GetInstanceInitialCredentialsCalls)
+	}
+}
+
+func TestStepGetDefaultCredentials_passwordSet(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	driver := state.Get("driver").(*driverMock)
+
+	comm := &communicator.Config{Type: "winrm"}
+	comm.WinRMPassword = "generated"
+	step := &stepGetDefaultCredentials{Comm: comm}
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.GetInstanceInitialCredentialsCalls != 0 {
+		t.Fatalf("should not have waited for the initial credentials")
+	}
+}
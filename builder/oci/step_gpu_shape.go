@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// isGPUShape reports whether a shape has GPUs, e.g. VM.GPU.A10.1 or
+// BM.GPU.H100.8.
+func isGPUShape(shape string) bool {
+	return strings.Contains(shape, ".GPU")
+}
+
+// stepGPUShape checks that a GPU shape is offered in the availability domains
+// of the build, dropping those that do not offer it, and records the GPUs of
+// the shape for the artifact. GPU shapes are only offered in some
+// availability domains, which LaunchInstance reports as an obscure error.
+type stepGPUShape struct{}
+
+func (s *stepGPUShape) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if !isGPUShape(config.Shape) || config.SkipPreflightChecks {
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Checking availability of GPU shape %s...", config.Shape))
+
+	domains := config.AvailabilityDomains
+	if len(domains) == 0 {
+		domains = []string{config.AvailabilityDomain}
+	}
+
+	var (
+		offered []string
+		shape   *core.Shape
+	)
+	for _, domain := range domains {
+		found, err := driver.GetShape(ctx, config.Shape, domain)
+		if err != nil {
+			ui.Message(fmt.Sprintf("Skipping GPU shape check: %s", err))
+			return multistep.ActionContinue
+		}
+		if found == nil {
+			ui.Message(fmt.Sprintf("Availability domain %s does not offer %s.", domain, config.Shape))
+			continue
+		}
+		offered = append(offered, domain)
+		shape = found
+	}
+
+	if len(offered) == 0 {
+		err := fmt.Errorf("Pre-flight check failed: shape %s is not offered in %s", config.Shape, strings.Join(domains, ", "))
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if len(config.AvailabilityDomains) > 0 {
+		config.AvailabilityDomains = offered
+	}
+	state.Put("gpu_shape", *shape)
+
+	if shape.Gpus != nil && shape.GpuDescription != nil {
+		ui.Say(fmt.Sprintf("Shape %s has %d %s GPU(s).", config.Shape, *shape.Gpus, *shape.GpuDescription))
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepGPUShape) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
+
+// gpuStateData adds the GPUs of the shape of the build, if any, to the state
+// data of an artifact.
+func gpuStateData(state multistep.StateBag, data map[string]interface{}) map[string]interface{} {
+	raw, ok := state.GetOk("gpu_shape")
+	if !ok {
+		return data
+	}
+	shape := raw.(core.Shape)
+	if shape.Gpus != nil {
+		data["gpus"] = *shape.Gpus
+	}
+	if shape.GpuDescription != nil {
+		data["gpu_description"] = *shape.GpuDescription
+	}
+	return data
+}
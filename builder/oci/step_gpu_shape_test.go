@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepGPUShape(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.Shape = "VM.GPU.A10.1"
+	config.AvailabilityDomains = []string{"aaaa:PHX-AD-1", "aaaa:PHX-AD-2", "aaaa:PHX-AD-3"}
+	driver := state.Get("driver").(*driverMock)
+	driver.GetShapeADs = []string{"aaaa:PHX-AD-2"}
+
+	step := new(stepGPUShape)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if expected := []string{"aaaa:PHX-AD-2"}; !reflect.DeepEqual(config.AvailabilityDomains, expected) {
+		t.Fatalf("unexpected availability domains: %q", config.AvailabilityDomains)
+	}
+
+	data := gpuStateData(state, map[string]interface{}{})
+	if data["gpus"] != 1 || data["gpu_description"] != "NVIDIA® A10" {
+		t.Fatalf("unexpected state data: %#v", data)
+	}
+}
+
+func TestStepGPUShape_notOffered(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).Shape = "BM.GPU.H100.8"
+	driver := state.Get("driver").(*driverMock)
+	driver.GetShapeADs = []string{}
+
+	step := new(stepGPUShape)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
+
+func TestStepGPUShape_listShapesFailed(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).Shape = "VM.GPU.A10.1"
+	driver := state.Get("driver").(*driverMock)
+	driver.GetShapeErr = errors.New("not authorized")
+
+	step := new(stepGPUShape)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("gpu_shape"); ok {
+		t.Fatalf("should not have recorded a shape")
+	}
+}
+
+func TestStepGPUShape_notGPU(t *testing.T) {
+	state := testState()
+	driver := state.Get("driver").(*driverMock)
+	driver.GetShapeErr = errors.New("should not be called")
+
+	step := new(stepGPUShape)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+}
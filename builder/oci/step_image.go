@@ -13,12 +13,16 @@ import (
 
 type stepImage struct {
 	SkipCreateImage bool
+
+	// partialImageID is the OCID of the image while it is being created
+	partialImageID string
 }
 
 func (s *stepImage) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
 	var (
 		driver     = state.Get("driver").(Driver)
 		ui         = state.Get("ui").(packersdk.Ui)
+		config     = state.Get("config").(*Config)
 		instanceID = state.Get("instance_id").(string)
 	)
 
@@ -27,17 +31,22 @@ func (s *stepImage) Run(ctx context.Context, state multistep.StateBag) multistep
 		return multistep.ActionContinue
 	}
 
+	if baseImageID, ok := state.Get("base_image_id").(string); ok && config.ProvenanceTags {
+		config.attemptImageTags = withBaseImageTag(config.imageTags(), baseImageID)
+	}
+
 	ui.Say("Creating image from instance...")
 
-	image, err := driver.CreateImage(ctx, instanceID)
+	image, workRequestID, err := driver.CreateImage(ctx, instanceID)
 	if err != nil {
 		err = fmt.Errorf("Error creating image from instance: %s", err)
 		ui.Error(err.Error())
 		state.Put("error", err)
 		return multistep.ActionHalt
 	}
+	s.partialImageID = *image.Id
 
-	err = driver.WaitForImageCreation(ctx, *image.Id)
+	err = driver.WaitForImageCreation(ctx, *image.Id, workRequestID, workRequestProgress(ui, "Image creation"))
 	if err != nil {
 		err = fmt.Errorf("Error waiting for image creation to finish: %s", err)
 		ui.Error(err.Error())
@@ -54,9 +63,43 @@ func (s *stepImage) Run(ctx context.Context, state multistep.StateBag) multistep
 		return multistep.ActionHalt
 	}
 
+	if config.ImageOperatingSystem != "" || config.ImageOperatingSystemVersion != "" {
+		ui.Say("Updating image operating system...")
+		image, err = driver.UpdateImageOperatingSystem(ctx, *image.Id, config.ImageOperatingSystem, config.ImageOperatingSystemVersion)
+		if err != nil {
+			err = fmt.Errorf("Error updating image operating system: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+	}
+
+	// The image is made launchable on every Ampere shape, not only the one
+	// it was built on. A shape that is not offered in the region is skipped.
+	if isAmpereShape(config.Shape) {
+		ui.Say("Adding Ampere shape compatibility to image...")
+		for _, shape := range ampereShapes {
+			if shape == config.Shape {
+				continue
+			}
+			err := driver.AddImageShapeCompatibility(ctx, *image.Id, shape)
+			if err != nil && isShapeUnavailableError(err) {
+				ui.Message(fmt.Sprintf("Skipping compatibility with shape %s: %s", shape, err))
+				continue
+			}
+			if err != nil {
+				err = fmt.Errorf("Error adding compatibility with shape %s to image: %s", shape, err)
+				ui.Error(err.Error())
+				state.Put("error", err)
+				return multistep.ActionHalt
+			}
+		}
+	}
+
 	// TODO(apryde): This is stale as .LifecycleState has changed to
 	// AVAILABLE at this point. Does it matter?
 	state.Put("image", image)
+	s.partialImageID = ""
 
 	ui.Say(fmt.Sprintf("Created image (%s).", *image.Id))
 
@@ -64,5 +107,43 @@ func (s *stepImage) Run(ctx context.Context, state multistep.StateBag) multistep
 }
 
 func (s *stepImage) Cleanup(state multistep.StateBag) {
-	// Nothing to do
+	if s.partialImageID == "" {
+		return
+	}
+
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if !config.DeleteFailedImage {
+		ui.Say(fmt.Sprintf("Image (%s) was not fully created. Please delete it manually, or set delete_failed_image.", s.partialImageID))
+		return
+	}
+
+	ui.Say(fmt.Sprintf("Deleting partially created image (%s)...", s.partialImageID))
+
+	if err := driver.DeleteImageCapabilitySchemas(context.TODO(), s.partialImageID); err != nil {
+		err = fmt.Errorf("Error deleting the capability schema of the image. Please delete it manually: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+	}
+
+	if err := driver.DeleteImage(context.TODO(), s.partialImageID); err != nil {
+		err = fmt.Errorf("Error deleting partially created image. Please delete %s manually: %s", s.partialImageID, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return
+	}
+
+	ui.Say("Deleted partially created image.")
+}
+
+// workRequestProgress returns a progress func for a work request that reports
+// the percentage of completion of operation in the UI.
+func workRequestProgress(ui packersdk.Ui, operation string) func(percentComplete float32) {
+	return func(percentComplete float32) {
+		ui.Message(fmt.Sprintf("%s %.0f%% complete...", operation, percentComplete))
+	}
 }
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// Freeform tags of a base image describing how to connect to its instances.
+const (
+	sshUsernameHintTag = "packer_ssh_username"
+	sshPortHintTag     = "packer_ssh_port"
+)
+
+// stepImageConnectionHints reads the connection settings left unset in the
+// template from the tags of the base image.
+type stepImageConnectionHints struct{}
+
+func (s *stepImageConnectionHints) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if !config.sshUsernameFromImage && !config.sshPortFromImage {
+		return multistep.ActionContinue
+	}
+
+	image, err := driver.GetInstanceImage(ctx, id)
+	if err != nil {
+		err = fmt.Errorf("Error getting base image connection hints: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if config.sshUsernameFromImage {
+		username := image.FreeformTags[sshUsernameHintTag]
+		if username == "" {
+			err = fmt.Errorf("Base image (%s) has no %s tag, ssh_username must be set", *image.Id, sshUsernameHintTag)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		config.Comm.SSHUsername = username
+		ui.Say(fmt.Sprintf("Using SSH username %s from base image.", username))
+	}
+
+	if config.sshPortFromImage {
+		if value, ok := image.FreeformTags[sshPortHintTag]; ok {
+			port, err := strconv.Atoi(value)
+			if err != nil || port < 1 || port > 65535 {
+				err = fmt.Errorf("Base image (%s) has an invalid %s tag: %q", *image.Id, sshPortHintTag, value)
+				ui.Error(err.Error())
+				state.Put("error", err)
+				return multistep.ActionHalt
+			}
+			config.Comm.SSHPort = port
+			ui.Say(fmt.Sprintf("Using SSH port %d from base image.", port))
+		}
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepImageConnectionHints) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
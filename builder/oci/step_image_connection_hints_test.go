@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepImageConnectionHints(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.Comm.SSHUsername = ""
+	config.Comm.SSHPort = 22
+	config.sshUsernameFromImage = true
+	config.sshPortFromImage = true
+
+	driver := state.Get("driver").(*driverMock)
+	driver.GetInstanceImageTags = map[string]string{
+		sshUsernameHintTag: "ubuntu",
+		sshPortHintTag:     "2222",
+	}
+
+	step := new(stepImageConnectionHints)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if config.Comm.SSHUsername != "ubuntu" {
+		t.Errorf("Expected SSH username ubuntu, got %s", config.Comm.SSHUsername)
+	}
+	if config.Comm.SSHPort != 2222 {
+		t.Errorf("Expected SSH port 2222, got %d", config.Comm.SSHPort)
+	}
+}
+
+func TestStepImageConnectionHints_missingUsername(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.Comm.SSHUsername = ""
+	config.sshUsernameFromImage = true
+
+	step := new(stepImageConnectionHints)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// errLockHeld is returned by Driver.CreateLockObject when the lock object
+// already exists.
+var errLockHeld = errors.New("lock is held by another build")
+
+// stepImageLock fences concurrent builds of the same image name by creating
+// a lock object in Object Storage for the duration of the build. The object
+// is created with If-None-Match, so only one build can hold it at a time. A
+// lock not modified for image_lock_ttl was left by a build that died before
+// releasing it, and is replaced with If-Match on its ETag.
+type stepImageLock struct {
+	objectName string
+	etag       string
+}
+
+func (s *stepImageLock) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if config.ImageLockBucket == "" || config.SkipCreateImage {
+		return multistep.ActionContinue
+	}
+
+	// The name of a build counter image is only known once the lock is
+	// held, so the whole series is locked instead
+	objectName := fmt.Sprintf("packer-image-locks/%s", config.ImageName)
+	if config.usesBuildCounter() {
+		objectName = fmt.Sprintf("packer-image-locks/series-%s", config.buildSeries())
+	}
+	hostname, _ := os.Hostname()
+	content := fmt.Sprintf("image_name=%s\nbuild_name=%s\nhost=%s\ncreated=%s\n",
+		config.ImageName, config.PackerBuildName, hostname, time.Now().UTC().Format(time.RFC3339))
+
+	ui.Say(fmt.Sprintf("Acquiring image lock (%s/%s)...", config.ImageLockBucket, objectName))
+
+	deadline := time.Now().Add(config.ImageLockTimeout)
+	for {
+		etag, err := s.acquire(ctx, driver, ui, config, objectName, content)
+		if err == nil {
+			s.objectName = objectName
+			s.etag = etag
+			break
+		}
+		if !errors.Is(err, errLockHeld) || !time.Now().Before(deadline) {
+			err = fmt.Errorf("Error acquiring image lock for %q: %s", config.ImageName, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+
+		select {
+		case <-ctx.Done():
+			err = fmt.Errorf("Error acquiring image lock for %q: %s", config.ImageName, ctx.Err())
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		case <-time.After(config.Timeouts.PollingInterval):
+		}
+	}
+
+	ui.Say("Acquired image lock.")
+
+	return multistep.ActionContinue
+}
+
+// acquire creates the lock object, replacing a stale lock.
+func (s *stepImageLock) acquire(ctx context.Context, driver Driver, ui packersdk.Ui, config *Config, objectName string, content string) (string, error) {
+	etag, err := driver.CreateLockObject(ctx, config.ImageLockBucket, objectName, content)
+	if !errors.Is(err, errLockHeld) || config.ImageLockTTL == 0 {
+		return etag, err
+	}
+
+	heldETag, modified, err := driver.GetLockObject(ctx, config.ImageLockBucket, objectName)
+	if err != nil {
+		return "", err
+	}
+	// Released in the meantime
+	if heldETag == "" {
+		return driver.CreateLockObject(ctx, config.ImageLockBucket, objectName, content)
+	}
+	if time.Since(modified) < config.ImageLockTTL {
+		return "", errLockHeld
+	}
+
+	ui.Say(fmt.Sprintf("Replacing stale image lock, last modified at %s...", modified.UTC().Format(time.RFC3339)))
+
+	// Another build replacing the same lock first makes the If-Match fail
+	return driver.ReplaceLockObject(ctx, config.ImageLockBucket, objectName, content, heldETag)
+}
+
+func (s *stepImageLock) Cleanup(state multistep.StateBag) {
+	if s.objectName == "" {
+		return
+	}
+
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	ui.Say("Releasing image lock...")
+
+	if err := driver.DeleteLockObject(context.TODO(), config.ImageLockBucket, s.objectName, s.etag); err != nil {
+		err = fmt.Errorf("Error releasing image lock. Please delete %s/%s manually: %s", config.ImageLockBucket, s.objectName, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return
+	}
+
+	ui.Say("Released image lock.")
+}
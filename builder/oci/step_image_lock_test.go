@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepImageLock(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).ImageLockBucket = "locks"
+
+	step := new(stepImageLock)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CreateLockObjectName != "packer-image-locks/HelloWorld" {
+		t.Fatalf("unexpected lock object name: %q", driver.CreateLockObjectName)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteLockObjectName != driver.CreateLockObjectName {
+		t.Fatalf("should've released lock (%s != %s)", driver.DeleteLockObjectName, driver.CreateLockObjectName)
+	}
+}
+
+func TestStepImageLock_Held(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).ImageLockBucket = "locks"
+
+	step := new(stepImageLock)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateLockObjectErr = errLockHeld
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteLockObjectName != "" {
+		t.Fatalf("should NOT have released a lock held by another build")
+	}
+}
+
+func TestStepImageLock_Stale(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ImageLockBucket = "locks"
+	config.ImageLockTTL = time.Hour
+
+	step := new(stepImageLock)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateLockObjectErr = errLockHeld
+	driver.GetLockObjectETag = "stale-etag"
+	driver.GetLockObjectModified = time.Now().Add(-2 * time.Hour)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.ReplaceLockObjectETag != "stale-etag" {
+		t.Fatalf("should've replaced the stale lock with If-Match on its ETag: %q", driver.ReplaceLockObjectETag)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteLockObjectName != "packer-image-locks/HelloWorld" {
+		t.Fatalf("should've released the replaced lock: %q", driver.DeleteLockObjectName)
+	}
+}
+
+func TestStepImageLock_NotStale(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ImageLockBucket = "locks"
+	config.ImageLockTTL = time.Hour
+
+	step := new(stepImageLock)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateLockObjectErr = errLockHeld
+	driver.GetLockObjectETag = "etag"
+	driver.GetLockObjectModified = time.Now().Add(-time.Minute)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.ReplaceLockObjectETag != "" {
+		t.Fatalf("should NOT have replaced a lock younger than image_lock_ttl")
+	}
+}
+
+func TestStepImageLock_StaleReplacedByOther(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ImageLockBucket = "locks"
+	config.ImageLockTTL = time.Hour
+
+	step := new(stepImageLock)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateLockObjectErr = errLockHeld
+	driver.GetLockObjectETag = "stale-etag"
+	driver.GetLockObjectModified = time.Now().Add(-2 * time.Hour)
+	driver.ReplaceLockObjectErr = errLockHeld
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteLockObjectName != "" {
+		t.Fatalf("should NOT have released a lock replaced by another build")
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepImageName renders the build counter into the image name and records it
+// in the image tags, so the next build of the series continues from it. With
+// image_version_scheme it instead appends a version to the image name that is
+// greater than the versions of the existing images.
+type stepImageName struct{}
+
+func (s *stepImageName) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if config.SkipCreateImage {
+		return multistep.ActionContinue
+	}
+	if config.ImageVersionScheme != "" {
+		return s.appendVersion(ctx, state)
+	}
+	if !config.usesBuildCounter() {
+		return multistep.ActionContinue
+	}
+
+	series := config.buildSeries()
+	latest, err := driver.GetLatestBuildCounter(ctx, series)
+	if err != nil {
+		err = fmt.Errorf("Error looking up the build counter: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	counter := strconv.Itoa(latest + 1)
+	config.attemptImageName = strings.ReplaceAll(config.ImageName, buildCounterPlaceholder, counter)
+
+	tags := make(map[string]string, len(config.Tags)+2)
+	for k, v := range config.Tags {
+		tags[k] = v
+	}
+	tags[buildSeriesTag] = series
+	tags[buildCounterTag] = counter
+	config.attemptImageTags = tags
+
+	ui.Say(fmt.Sprintf("Using build counter %s, image name is %s.", counter, config.imageName()))
+
+	return multistep.ActionContinue
+}
+
+// appendVersion appends the next version of image_version_scheme to the image
+// name, looking up the versions of the existing images.
+func (s *stepImageName) appendVersion(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	// The images of previous builds are in the destination compartment when
+	// they are moved there
+	compartmentID := config.ImageCompartmentID
+	if config.ImageDestinationCompartmentID != "" {
+		compartmentID = config.ImageDestinationCompartmentID
+	}
+
+	images, err := driver.ListCustomImages(ctx, compartmentID)
+	if err != nil {
+		err = fmt.Errorf("Error looking up the versions of existing images: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+	names := make([]string, 0, len(images))
+	for _, image := range images {
+		if image.DisplayName != nil {
+			names = append(names, *image.DisplayName)
+		}
+	}
+
+	version, err := nextImageVersion(config.ImageVersionScheme, config.ImageName, config.ImageVersion, names, time.Now())
+	if err != nil {
+		err = fmt.Errorf("Error choosing the image version: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+	config.attemptImageName = config.ImageName + "-" + version
+
+	ui.Say(fmt.Sprintf("Using image version %s, image name is %s.", version, config.imageName()))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepImageName) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestStepImageName(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.imageNameTemplate = "base-{{ .BuildCounter }}"
+	config.ImageName = "base-" + buildCounterPlaceholder
+	config.Tags = map[string]string{"team": "images"}
+
+	driver := state.Get("driver").(*driverMock)
+	driver.GetLatestBuildCounterCounter = 41
+
+	step := new(stepImageName)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if config.imageName() != "base-42" {
+		t.Errorf("Expected image name base-42, got %s", config.imageName())
+	}
+	tags := config.imageTags()
+	if tags[buildCounterTag] != "42" || tags[buildSeriesTag] != config.buildSeries() {
+		t.Errorf("Expected the build counter to be tagged, got %v", tags)
+	}
+	if tags["team"] != "images" {
+		t.Errorf("Expected existing tags to be kept, got %v", tags)
+	}
+}
+
+func TestStepImageName_noCounter(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.GetLatestBuildCounterErr = errors.New("should not be called")
+
+	step := new(stepImageName)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if config.imageName() != "HelloWorld" {
+		t.Errorf("Expected image name to be unchanged, got %s", config.imageName())
+	}
+}
+
+func TestStepImageName_error(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ImageName = "base-" + buildCounterPlaceholder
+
+	driver := state.Get("driver").(*driverMock)
+	driver.GetLatestBuildCounterErr = errors.New("error")
+
+	step := new(stepImageName)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
+
+func TestStepImageName_version(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ImageName = "golden"
+	config.ImageVersionScheme = imageVersionCounter
+
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesImages = []core.Image{
+		{DisplayName: stringPtr("golden-7")},
+		{DisplayName: stringPtr("golden-12")},
+		{DisplayName: stringPtr("silver-40")},
+	}
+
+	step := new(stepImageName)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if config.imageName() != "golden-13" {
+		t.Errorf("Expected image name golden-13, got %s", config.imageName())
+	}
+	if driver.ListCustomImagesCompartmentID != config.ImageCompartmentID {
+		t.Errorf("Expected the images of %s to be listed, got %s", config.ImageCompartmentID, driver.ListCustomImagesCompartmentID)
+	}
+}
+
+func TestStepImageName_retry(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ImageName = "golden"
+	config.ImageVersionScheme = imageVersionCounter
+	config.Tags = map[string]string{"team": "images"}
+
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesImages = []core.Image{{DisplayName: stringPtr("golden-12")}}
+
+	// Every build attempt runs a new step with the same config
+	for attempt := 0; attempt < 2; attempt++ {
+		config.resetAttempt()
+		step := new(stepImageName)
+		if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+			t.Fatalf("bad action: %#v", action)
+		}
+		step.Cleanup(state)
+
+		if config.imageName() != "golden-13" {
+			t.Errorf("Expected image name golden-13 on attempt %d, got %s", attempt, config.imageName())
+		}
+	}
+
+	if config.ImageName != "golden" || len(config.Tags) != 1 {
+		t.Errorf("Expected the image name and tags of the template to be unchanged, got %s and %v", config.ImageName, config.Tags)
+	}
+}
+
+func TestNextImageVersion(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		scheme   string
+		semver   string
+		names    []string
+		expected string
+		err      bool
+	}{
+		// Built in the same second as an existing image
+		{scheme: imageVersionTimestamp, names: []string{"golden-20240301120000", "golden-20240229120000"}, expected: "20240301120001"},
+		{scheme: imageVersionTimestamp, names: []string{"golden-20240229120000"}, expected: "20240301120000"},
+		{scheme: imageVersionCounter, names: []string{"golden-3", "golden-beta", "other-99"}, expected: "4"},
+		{scheme: imageVersionCounter, expected: "1"},
+		{scheme: imageVersionSemver, semver: "1.5.0", names: []string{"golden-1.4.0", "other-2.0.0"}, expected: "1.5.0"},
+		{scheme: imageVersionSemver, semver: "1.4.0", names: []string{"golden-1.4.0"}, err: true},
+		{scheme: imageVersionSemver, semver: "1.3.9", names: []string{"golden-1.4.0"}, err: true},
+	}
+
+	for _, tt := range tests {
+		version, err := nextImageVersion(tt.scheme, "golden", tt.semver, tt.names, now)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s %s: expected an error, got version %s", tt.scheme, tt.semver, version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %s: unexpected error: %s", tt.scheme, tt.semver, err)
+		} else if version != tt.expected {
+			t.Errorf("%s %s: expected version %s, got %s", tt.scheme, tt.semver, tt.expected, version)
+		}
+	}
+}
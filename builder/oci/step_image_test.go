@@ -4,11 +4,16 @@
 package oci
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
 )
 
 func TestStepImage(t *testing.T) {
@@ -27,6 +32,179 @@ func TestStepImage(t *testing.T) {
 	}
 }
 
+func TestStepImage_baseImageTag(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put("base_image_id", "ocid1.image.oc1.iad.base")
+	config := state.Get("config").(*Config)
+	config.ProvenanceTags = true
+	config.Tags = map[string]string{"owner": "ci"}
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	expected := map[string]string{"owner": "ci", baseImageTag: "ocid1.image.oc1.iad.base"}
+	if !reflect.DeepEqual(driver.CreateImageTags, expected) {
+		t.Fatalf("should've tagged the image with the base image: %v", driver.CreateImageTags)
+	}
+	if _, ok := config.Tags[baseImageTag]; ok {
+		t.Fatalf("should not have changed the tags of the config: %v", config.Tags)
+	}
+
+	// A base image tag set in the template takes precedence
+	config.resetAttempt()
+	config.Tags = map[string]string{baseImageTag: "golden"}
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if driver.CreateImageTags[baseImageTag] != "golden" {
+		t.Fatalf("should've kept the base image tag of the template: %v", driver.CreateImageTags)
+	}
+}
+
+func TestStepImage_progress(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForImageCreationProgress = []float32{0, 42.5, 100}
+
+	step := new(stepImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.WaitForImageCreationWorkRequestID != "ocid1.workrequest..." {
+		t.Fatalf("should've followed the work request creating the image: %q", driver.WaitForImageCreationWorkRequestID)
+	}
+	output := state.Get("ui").(*packersdk.BasicUi).Writer.(*bytes.Buffer).String()
+	for _, want := range []string{"Image creation 0% complete", "Image creation 42% complete", "Image creation 100% complete"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("should've reported %q: %q", want, output)
+		}
+	}
+}
+
+func TestStepImage_operatingSystem(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.ImageOperatingSystem = "Rocky Linux"
+	config.ImageOperatingSystemVersion = "9"
+
+	step := new(stepImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	driver := state.Get("driver").(*driverMock)
+	if driver.UpdateImageOperatingSystemOS != "Rocky Linux" || driver.UpdateImageOperatingSystemVersion != "9" {
+		t.Fatalf("should've updated the operating system: %q %q", driver.UpdateImageOperatingSystemOS, driver.UpdateImageOperatingSystemVersion)
+	}
+	if image := state.Get("image").(core.Image); *image.OperatingSystem != "Rocky Linux" {
+		t.Fatalf("should've recorded the updated image: %#v", image)
+	}
+}
+
+func TestStepImage_ampereShapes(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).Shape = "VM.Standard.A1.Flex"
+
+	step := new(stepImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	driver := state.Get("driver").(*driverMock)
+	got := strings.Join(driver.AddImageShapeCompatibilityShapes, ",")
+	if got != "BM.Standard.A1.160,VM.Standard.A2.Flex" {
+		t.Fatalf("should've made the image compatible with the other Ampere shapes: %q", got)
+	}
+}
+
+func TestStepImage_ampereShapesErr(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).Shape = "VM.Standard.A2.Flex"
+	driver := state.Get("driver").(*driverMock)
+	driver.AddImageShapeCompatibilityErr = newRequestError("AddImageShapeCompatibilityEntry", nil, testServiceError{
+		statusCode: 404,
+		code:       "NotAuthorizedOrNotFound",
+		message:    "Shape BM.Standard.A1.160 not found",
+	})
+
+	step := new(stepImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("image"); !ok {
+		t.Fatalf("should have image")
+	}
+}
+
+func TestStepImage_ampereShapesFailure(t *testing.T) {
+	for name, err := range map[string]error{
+		"service": newRequestError("AddImageShapeCompatibilityEntry", nil, testServiceError{
+			statusCode: 500,
+			code:       "InternalError",
+			message:    "Internal error",
+		}),
+		"network": errors.New("connection reset by peer"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			state := testState()
+			state.Put("instance_id", "ocid1...")
+			state.Get("config").(*Config).Shape = "VM.Standard.A2.Flex"
+			driver := state.Get("driver").(*driverMock)
+			driver.AddImageShapeCompatibilityErr = err
+
+			step := new(stepImage)
+			defer step.Cleanup(state)
+
+			if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+				t.Fatalf("bad action: %#v", action)
+			}
+			if _, ok := state.GetOk("error"); !ok {
+				t.Fatalf("should have error")
+			}
+			if _, ok := state.GetOk("image"); ok {
+				t.Fatalf("should not have image")
+			}
+		})
+	}
+}
+
+func TestStepImage_nonAmpereShape(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).Shape = "VM.Standard.E4.Flex"
+
+	step := new(stepImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	driver := state.Get("driver").(*driverMock)
+	if len(driver.AddImageShapeCompatibilityShapes) != 0 {
+		t.Fatalf("should not have added shape compatibility: %q", driver.AddImageShapeCompatibilityShapes)
+	}
+}
+
 func TestStepImage_CreateImageErr(t *testing.T) {
 	state := testState()
 	state.Put("instance_id", "ocid1...")
@@ -72,3 +250,65 @@ func TestStepImage_WaitForImageCreationErr(t *testing.T) {
 		t.Fatalf("should not have image")
 	}
 }
+
+func TestStepImage_deleteFailedImage(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).DeleteFailedImage = true
+
+	step := new(stepImage)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.UpdateSchemaErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteImageCapabilitySchemasImageID != "ocid1..." || driver.DeleteImageID != "ocid1..." {
+		t.Fatalf("should've deleted the partially created image and its schema: %q %q",
+			driver.DeleteImageCapabilitySchemasImageID, driver.DeleteImageID)
+	}
+}
+
+func TestStepImage_keepFailedImage(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepImage)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForImageCreationErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteImageID != "" {
+		t.Fatalf("should NOT have deleted the image without delete_failed_image")
+	}
+}
+
+func TestStepImage_keepCreatedImage(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).DeleteFailedImage = true
+
+	step := new(stepImage)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	// A later step failed
+	state.Put("error", errors.New("error"))
+	step.Cleanup(state)
+
+	if driver := state.Get("driver").(*driverMock); driver.DeleteImageID != "" {
+		t.Fatalf("should NOT have deleted the created image")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepImportImage imports the base image from Object Storage with
+// base_image_import. The instance is then launched from the imported image
+// like from base_image_ocid, and the imported image is deleted after the
+// build.
+type stepImportImage struct {
+	imageID string
+}
+
+func (s *stepImportImage) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if config.BaseImageImport.SourceURI == "" {
+		return multistep.ActionContinue
+	}
+
+	// The source URI is not printed, as a pre-authenticated request URL
+	// grants access to the object
+	ui.Say(fmt.Sprintf("Importing %s base image from Object Storage...", config.BaseImageImport.SourceImageType))
+
+	imageID, workRequestID, err := driver.ImportImage(ctx, config.BaseImageImport)
+	if err != nil {
+		err = fmt.Errorf("Error importing base image: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+	s.imageID = imageID
+
+	if err := driver.WaitForWorkRequest(ctx, workRequestID, workRequestProgress(ui, "Image import")); err != nil {
+		err = fmt.Errorf("Error waiting for imported base image (%s) to become available: %s", imageID, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	config.BaseImageID = imageID
+
+	ui.Say(fmt.Sprintf("Imported base image (%s).", imageID))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepImportImage) Cleanup(state multistep.StateBag) {
+	if s.imageID == "" {
+		return
+	}
+
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+	)
+
+	ui.Say(fmt.Sprintf("Deleting imported base image (%s)...", s.imageID))
+
+	if err := driver.DeleteImage(context.TODO(), s.imageID); err != nil {
+		err = fmt.Errorf("Error deleting imported base image. Please delete %s manually: %s", s.imageID, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return
+	}
+
+	ui.Say("Deleted imported base image.")
+}
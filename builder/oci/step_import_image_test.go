@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepImportImage(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.BaseImageID = ""
+	config.BaseImageImport = ImageImportConfig{
+		SourceURI:       "https://objectstorage.us-phoenix-1.oraclecloud.com/n/ns/b/disks/o/disk.qcow2",
+		SourceImageType: "QCOW2",
+	}
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepImportImage)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.ImportImageSource != config.BaseImageImport {
+		t.Fatalf("should've imported the disk image: %#v", driver.ImportImageSource)
+	}
+	if driver.WaitForWorkRequestID != "ocid1.workrequest.import" {
+		t.Fatalf("should've waited for the import: %q", driver.WaitForWorkRequestID)
+	}
+	if config.BaseImageID != "ocid1.image.imported" {
+		t.Fatalf("should've launched from the imported image: %q", config.BaseImageID)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteImageID != "ocid1.image.imported" {
+		t.Fatalf("should've deleted the imported image: %q", driver.DeleteImageID)
+	}
+}
+
+func TestStepImportImage_waitErr(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.BaseImageImport = ImageImportConfig{SourceURI: "https://objectstorage.us-phoenix-1.oraclecloud.com/n/ns/b/disks/o/disk.vmdk"}
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForWorkRequestErr = errors.New("error")
+
+	step := new(stepImportImage)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteImageID != "ocid1.image.imported" {
+		t.Fatalf("should've deleted the failed import: %q", driver.DeleteImageID)
+	}
+}
+
+func TestStepImportImage_noImport(t *testing.T) {
+	state := testState()
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepImportImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if driver.ImportImageSource.SourceURI != "" || driver.DeleteImageID != "" {
+		t.Fatalf("should not have imported an image")
+	}
+}
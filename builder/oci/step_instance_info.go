@@ -17,6 +17,7 @@ func (s *stepInstanceInfo) Run(ctx context.Context, state multistep.StateBag) mu
 	var (
 		driver = state.Get("driver").(Driver)
 		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
 		id     = state.Get("instance_id").(string)
 	)
 
@@ -30,7 +31,11 @@ func (s *stepInstanceInfo) Run(ctx context.Context, state multistep.StateBag) mu
 
 	state.Put("instance_ip", ip)
 
-	ui.Say(fmt.Sprintf("Instance has IP: %s.", ip))
+	if config.UsePrivateFQDN {
+		ui.Say(fmt.Sprintf("Instance has FQDN: %s.", ip))
+	} else {
+		ui.Say(fmt.Sprintf("Instance has IP: %s.", ip))
+	}
 
 	return multistep.ActionContinue
 }
@@ -64,6 +64,50 @@ func TestInstanceInfoPrivateIP(t *testing.T) {
 	}
 }
 
+func TestInstanceInfoPrivateFQDN(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).UsePrivateFQDN = true
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepInstanceInfo)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	instanceIPRaw, ok := state.GetOk("instance_ip")
+	if !ok {
+		t.Fatalf("should have instance_ip")
+	}
+
+	if instanceIPRaw.(string) != "host.subnet.vcn.oraclevcn.com" {
+		t.Fatalf("should've got fqdn ('%s' != 'host.subnet.vcn.oraclevcn.com')", instanceIPRaw.(string))
+	}
+}
+
+func TestInstanceInfoIPv6(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).UseIPv6 = true
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepInstanceInfo)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	instanceIPRaw, ok := state.GetOk("instance_ip")
+	if !ok {
+		t.Fatalf("should have instance_ip")
+	}
+
+	if instanceIPRaw.(string) != "2603:c020:4000:7e00::10" {
+		t.Fatalf("should've got ipv6 ('%s' != '2603:c020:4000:7e00::10')", instanceIPRaw.(string))
+	}
+}
+
 func TestInstanceInfo_GetInstanceIPErr(t *testing.T) {
 	state := testState()
 	state.Put("instance_id", "ocid1...")
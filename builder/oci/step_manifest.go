@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// imageManifest describes a built image for supply-chain attestation.
+type imageManifest struct {
+	ImageID      string `json:"image_id"`
+	ImageName    string `json:"image_name"`
+	SizeInMBs    int64  `json:"size_in_mbs,omitempty"`
+	BaseImageID  string `json:"base_image_id,omitempty"`
+	ExportURI    string `json:"export_uri,omitempty"`
+	ExportSHA256 string `json:"export_sha256,omitempty"`
+	BuildTime    string `json:"build_time"`
+}
+
+// stepManifest writes a manifest of the image to manifest_path, including
+// the SHA256 digest of the exported object when the image was exported.
+type stepManifest struct{}
+
+func (s *stepManifest) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	rawImage, ok := state.GetOk("image")
+	if !ok || config.ManifestPath == "" {
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Writing image manifest to %s...", config.ManifestPath))
+
+	// The image in the state is the one returned on creation, which has no
+	// size yet
+	image, err := driver.GetImage(ctx, *rawImage.(core.Image).Id)
+	if err != nil {
+		err = fmt.Errorf("Error getting image for the manifest: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	// The base image resolved from base_image or base_image_filter when
+	// the instance was launched
+	baseImageID, _ := state.Get("base_image_id").(string)
+
+	manifest := imageManifest{
+		ImageID:     *image.Id,
+		ImageName:   config.imageName(),
+		BaseImageID: baseImageID,
+		BuildTime:   time.Now().UTC().Format(time.RFC3339),
+	}
+	if image.SizeInMBs != nil {
+		manifest.SizeInMBs = *image.SizeInMBs
+	}
+	if image.TimeCreated != nil {
+		manifest.BuildTime = image.TimeCreated.UTC().Format(time.RFC3339)
+	}
+	if uri, ok := state.GetOk("image_export_uri"); ok {
+		manifest.ExportURI = uri.(string)
+		if config.ManifestExportSHA256 {
+			ui.Message("Computing the SHA256 digest of the exported image...")
+			digest, err := driver.GetObjectSHA256(ctx, config.ExportToObjectStorage)
+			if err != nil {
+				err = fmt.Errorf("Error computing the SHA256 digest of the exported image: %s", err)
+				ui.Error(err.Error())
+				state.Put("error", err)
+				return multistep.ActionHalt
+			}
+			manifest.ExportSHA256 = digest
+		}
+	}
+
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err == nil {
+		err = os.WriteFile(config.ManifestPath, append(raw, '\n'), 0644)
+	}
+	if err != nil {
+		err = fmt.Errorf("Error writing image manifest: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	state.Put("manifest", manifest)
+
+	return multistep.ActionContinue
+}
+
+func (s *stepManifest) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
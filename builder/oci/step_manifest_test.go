@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestStepManifest(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ImageName = "golden"
+	// The base image was resolved from base_image_filter at launch
+	config.BaseImageID = ""
+	state.Put("base_image_id", "ocid1.image.oc1.iad.resolved")
+	config.ManifestPath = filepath.Join(t.TempDir(), "manifest.json")
+	config.ManifestExportSHA256 = true
+	config.ExportToObjectStorage = ImageExportConfig{Bucket: "images", ObjectName: "golden"}
+	imageID := "ocid1.image.oc1.iad.bbb"
+	state.Put("image", core.Image{Id: &imageID})
+	state.Put("image_export_uri", "https://objectstorage.us-ashburn-1.oraclecloud.com/n/ns/b/images/o/golden")
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepManifest)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.GetObjectSHA256Object != "golden" {
+		t.Fatalf("should've hashed the exported object: %q", driver.GetObjectSHA256Object)
+	}
+
+	raw, err := os.ReadFile(config.ManifestPath)
+	if err != nil {
+		t.Fatalf("should have written the manifest: %s", err)
+	}
+	var manifest imageManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		t.Fatalf("manifest should be JSON: %s\n%s", err, raw)
+	}
+	expected := imageManifest{
+		ImageID:      imageID,
+		ImageName:    "golden",
+		SizeInMBs:    47694,
+		BaseImageID:  "ocid1.image.oc1.iad.resolved",
+		ExportURI:    "https://objectstorage.us-ashburn-1.oraclecloud.com/n/ns/b/images/o/golden",
+		ExportSHA256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		BuildTime:    "2024-03-01T12:00:00Z",
+	}
+	if manifest != expected {
+		t.Fatalf("unexpected manifest: %#v", manifest)
+	}
+	if state.Get("manifest").(imageManifest) != expected {
+		t.Fatalf("should've recorded the manifest for the artifact")
+	}
+}
+
+func TestStepManifest_notExported(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ManifestPath = filepath.Join(t.TempDir(), "manifest.json")
+	imageID := "ocid1.image.oc1.iad.bbb"
+	state.Put("image", core.Image{Id: &imageID})
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepManifest)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.GetObjectSHA256Object != "" {
+		t.Fatalf("should not have hashed an object")
+	}
+	if manifest := state.Get("manifest").(imageManifest); manifest.ExportSHA256 != "" || manifest.ExportURI != "" {
+		t.Fatalf("should not have an export: %#v", manifest)
+	}
+}
+
+func TestStepManifest_exportWithoutDigest(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ManifestPath = filepath.Join(t.TempDir(), "manifest.json")
+	config.ExportToObjectStorage = ImageExportConfig{Bucket: "images", ObjectName: "golden"}
+	imageID := "ocid1.image.oc1.iad.bbb"
+	state.Put("image", core.Image{Id: &imageID})
+	state.Put("image_export_uri", "https://objectstorage.us-ashburn-1.oraclecloud.com/n/ns/b/images/o/golden")
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepManifest)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.GetObjectSHA256Object != "" {
+		t.Fatalf("should not have downloaded the exported object")
+	}
+	manifest := state.Get("manifest").(imageManifest)
+	if manifest.ExportURI == "" || manifest.ExportSHA256 != "" {
+		t.Fatalf("should only have the export URI: %#v", manifest)
+	}
+}
+
+func TestStepManifest_digestErr(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ManifestPath = filepath.Join(t.TempDir(), "manifest.json")
+	config.ManifestExportSHA256 = true
+	imageID := "ocid1.image.oc1.iad.bbb"
+	state.Put("image", core.Image{Id: &imageID})
+	state.Put("image_export_uri", "https://objectstorage.us-ashburn-1.oraclecloud.com/n/ns/b/images/o/golden")
+	state.Get("driver").(*driverMock).GetObjectSHA256Err = errors.New("error")
+
+	step := new(stepManifest)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+	if _, err := os.Stat(config.ManifestPath); !os.IsNotExist(err) {
+		t.Fatalf("should not have written the manifest: %v", err)
+	}
+}
+
+func TestStepManifest_disabled(t *testing.T) {
+	state := testState()
+	imageID := "ocid1.image.oc1.iad.bbb"
+	state.Put("image", core.Image{Id: &imageID})
+
+	step := new(stepManifest)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("manifest"); ok {
+		t.Fatalf("should not have written a manifest")
+	}
+}
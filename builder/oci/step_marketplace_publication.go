@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// stepMarketplacePublication publishes the image as an OCI Marketplace
+// community listing with marketplace_publication, so that appliance images
+// are released along with the build. Each build creates a new publication,
+// since the package of a publication cannot be replaced. Partner listings
+// are managed in the Partner Portal, which has no API in the OCI SDK.
+type stepMarketplacePublication struct{}
+
+func (s *stepMarketplacePublication) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	rawImage, ok := state.GetOk("image")
+	if !ok || (config.MarketplacePublication == MarketplacePublicationConfig{}) {
+		return multistep.ActionContinue
+	}
+	image := rawImage.(core.Image)
+
+	ui.Say("Publishing image to the Marketplace...")
+
+	publicationID, err := driver.CreateMarketplacePublication(ctx, image)
+	if err != nil {
+		err = fmt.Errorf("Error publishing image to the Marketplace: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if err := driver.WaitForMarketplacePublication(ctx, publicationID); err != nil {
+		err = fmt.Errorf("Error waiting for Marketplace publication (%s) to become active: %s", publicationID, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	state.Put("marketplace_publication_id", publicationID)
+
+	ui.Say(fmt.Sprintf("Published image to the Marketplace (%s).", publicationID))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepMarketplacePublication) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
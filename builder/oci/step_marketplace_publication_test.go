@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestStepMarketplacePublication(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	state.Get("config").(*Config).MarketplacePublication = MarketplacePublicationConfig{
+		ShortDescription:      "Hardened appliance",
+		SupportEmail:          "support@example.com",
+		AgreementAcknowledged: true,
+	}
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepMarketplacePublication)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CreateMarketplacePublicationImageID != "ocid1.image" {
+		t.Fatalf("should've published the image: %q", driver.CreateMarketplacePublicationImageID)
+	}
+	if id := state.Get("marketplace_publication_id"); id != "ocid1.marketplacepublication" {
+		t.Fatalf("unexpected publication: %v", id)
+	}
+}
+
+func TestStepMarketplacePublication_waitErr(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	state.Get("config").(*Config).MarketplacePublication = MarketplacePublicationConfig{ShortDescription: "Hardened appliance"}
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForMarketplacePublicationErr = errors.New("error")
+
+	step := new(stepMarketplacePublication)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+	if _, ok := state.GetOk("marketplace_publication_id"); ok {
+		t.Fatalf("should not have a publication")
+	}
+}
+
+func TestStepMarketplacePublication_disabled(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepMarketplacePublication)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if driver.CreateMarketplacePublicationImageID != "" {
+		t.Fatalf("should not have published the image")
+	}
+}
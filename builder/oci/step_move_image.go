@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// stepMoveImage moves the image to image_destination_compartment_ocid once
+// it is available. It runs before the image is exported or copied, so the
+// copies are imported into the destination compartment too.
+type stepMoveImage struct{}
+
+func (s *stepMoveImage) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	rawImage, ok := state.GetOk("image")
+	if !ok || config.ImageDestinationCompartmentID == "" {
+		return multistep.ActionContinue
+	}
+	image := rawImage.(core.Image)
+
+	ui.Say(fmt.Sprintf("Moving image to compartment %s...", config.ImageDestinationCompartmentID))
+
+	if err := driver.ChangeImageCompartment(ctx, *image.Id, config.ImageDestinationCompartmentID); err != nil {
+		err = fmt.Errorf("Error moving image to compartment %s. The image remains in %s: %s",
+			config.ImageDestinationCompartmentID, config.ImageCompartmentID, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	image.CompartmentId = &config.ImageDestinationCompartmentID
+	state.Put("image", image)
+
+	ui.Say("Moved image.")
+
+	return multistep.ActionContinue
+}
+
+func (s *stepMoveImage) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
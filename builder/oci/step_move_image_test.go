@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestStepMoveImage(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image"), CompartmentId: stringPtr("ocid1.compartment.sandbox")})
+	state.Get("config").(*Config).ImageDestinationCompartmentID = "ocid1.compartment.golden"
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepMoveImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.ChangeImageCompartmentID != "ocid1.image" || driver.ChangeImageCompartmentCompartmentID != "ocid1.compartment.golden" {
+		t.Fatalf("should've moved the image: %q %q", driver.ChangeImageCompartmentID, driver.ChangeImageCompartmentCompartmentID)
+	}
+	if image := state.Get("image").(core.Image); *image.CompartmentId != "ocid1.compartment.golden" {
+		t.Fatalf("should've recorded the destination compartment: %q", *image.CompartmentId)
+	}
+}
+
+func TestStepMoveImage_err(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	state.Get("config").(*Config).ImageDestinationCompartmentID = "ocid1.compartment.golden"
+	driver := state.Get("driver").(*driverMock)
+	driver.ChangeImageCompartmentErr = errors.New("error")
+
+	step := new(stepMoveImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
+
+func TestStepMoveImage_noDestination(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepMoveImage)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if driver.ChangeImageCompartmentID != "" {
+		t.Fatalf("should not have moved the image")
+	}
+}
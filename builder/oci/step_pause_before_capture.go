@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepPauseBeforeCapture holds the provisioned instance so it can be inspected
+// before its image is captured.
+type stepPauseBeforeCapture struct{}
+
+func (s *stepPauseBeforeCapture) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		config = state.Get("config").(*Config)
+		ui     = state.Get("ui").(packersdk.Ui)
+	)
+
+	if config.PauseBeforeCapture == "" || config.SkipCreateImage {
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Pausing before image capture. The instance can be inspected at:")
+	ui.Message(fmt.Sprintf("Instance: %s", state.Get("instance_id").(string)))
+	ui.Message(fmt.Sprintf("Address: %s:%d", state.Get("instance_ip").(string), config.Comm.Port()))
+	ui.Message(fmt.Sprintf("User: %s", config.Comm.User()))
+	if config.Comm.SSHPrivateKeyFile != "" {
+		ui.Message(fmt.Sprintf("Private key: %s", config.Comm.SSHPrivateKeyFile))
+	}
+
+	if config.pauseBeforeCapture == 0 {
+		if _, err := ui.Ask("Press enter to capture the image..."); err != nil {
+			err = fmt.Errorf("Error waiting for confirmation: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Capturing the image in %s...", config.pauseBeforeCapture))
+	select {
+	case <-time.After(config.pauseBeforeCapture):
+		return multistep.ActionContinue
+	case <-ctx.Done():
+		return multistep.ActionHalt
+	}
+}
+
+func (s *stepPauseBeforeCapture) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
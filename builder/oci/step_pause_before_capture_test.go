@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func TestStepPauseBeforeCapture(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put("instance_ip", "ip")
+	config := state.Get("config").(*Config)
+	config.PauseBeforeCapture = "10ms"
+	config.pauseBeforeCapture = 10 * time.Millisecond
+
+	step := new(stepPauseBeforeCapture)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+}
+
+func TestStepPauseBeforeCapture_prompt(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put("instance_ip", "ip")
+	ui := new(packersdk.MockUi)
+	state.Put("ui", ui)
+	config := state.Get("config").(*Config)
+	config.PauseBeforeCapture = "prompt"
+
+	step := new(stepPauseBeforeCapture)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if !ui.AskCalled {
+		t.Error("Expected the user to be prompted")
+	}
+}
+
+func TestStepPauseBeforeCapture_cancelled(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put("instance_ip", "ip")
+	config := state.Get("config").(*Config)
+	config.PauseBeforeCapture = "1h"
+	config.pauseBeforeCapture = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	step := new(stepPauseBeforeCapture)
+	defer step.Cleanup(state)
+
+	if action := step.Run(ctx, state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+}
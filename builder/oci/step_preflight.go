@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepPreflight fails the build early when the instance cannot be launched
+// because the compartment is being deleted or no OCPUs are left for the
+// shape. The checks need permissions the build does not otherwise need, so a
+// check that cannot be run is skipped with a warning.
+type stepPreflight struct{}
+
+func (s *stepPreflight) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if config.SkipPreflightChecks {
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Running pre-flight checks...")
+
+	compartmentState, err := driver.GetCompartmentState(ctx, config.CompartmentID)
+	if err != nil {
+		ui.Message(fmt.Sprintf("Skipping compartment check: %s", err))
+	} else if compartmentState != "ACTIVE" {
+		err = fmt.Errorf("Pre-flight check failed: compartment (%s) is %s", config.CompartmentID, compartmentState)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	// Preemptible and reserved capacity is accounted under other limits
+	if config.IsPreemptible || config.CapacityReservationID != "" {
+		return multistep.ActionContinue
+	}
+
+	limitName := shapeLimitName(config.Shape)
+	if limitName == "" {
+		log.Printf("[INFO] No known compute limit for shape %s, skipping quota check", config.Shape)
+		return multistep.ActionContinue
+	}
+
+	needed := shapeOcpus(config.Shape, config.ShapeConfig)
+	domains := config.AvailabilityDomains
+	if len(domains) == 0 {
+		domains = []string{config.AvailabilityDomain}
+	}
+
+	var blocked []string
+	for _, domain := range domains {
+		availability, err := driver.GetComputeAvailability(ctx, limitName, domain)
+		if err != nil {
+			ui.Message(fmt.Sprintf("Skipping quota check of %s in %s: %s", limitName, domain, err))
+			return multistep.ActionContinue
+		}
+		if availability.Available == nil || *availability.Available >= needed {
+			return multistep.ActionContinue
+		}
+
+		reason := "service limit"
+		if availability.EffectiveQuotaValue != nil {
+			reason = "quota policy"
+		}
+		blocked = append(blocked, fmt.Sprintf("%s blocks %s in %s (%d of %d OCPUs available)",
+			reason, config.Shape, domain, *availability.Available, needed))
+	}
+
+	err = fmt.Errorf("Pre-flight check failed: %s", strings.Join(blocked, ", "))
+	ui.Error(err.Error())
+	state.Put("error", err)
+	return multistep.ActionHalt
+}
+
+func (s *stepPreflight) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
+
+// shapeLimitName returns the name of the compute limit counting the OCPUs of
+// a standard or optimized VM shape, e.g. standard-e4-core-count for
+// VM.Standard.E4.Flex, or an empty string for other shapes.
+func shapeLimitName(shape string) string {
+	if !strings.HasPrefix(shape, "VM.Standard") && !strings.HasPrefix(shape, "VM.Optimized") {
+		return ""
+	}
+
+	parts := strings.Split(strings.TrimPrefix(shape, "VM."), ".")
+	last := parts[len(parts)-1]
+	if _, err := strconv.Atoi(last); err == nil || last == "Flex" {
+		parts = parts[:len(parts)-1]
+	}
+	for _, part := range parts {
+		// Micro, dense I/O and other specialized shapes have their own limits
+		if _, err := strconv.Atoi(part); err == nil || part == "Micro" || strings.HasPrefix(part, "DenseIO") {
+			return ""
+		}
+	}
+
+	return strings.ToLower(strings.Join(parts, "-")) + "-core-count"
+}
+
+// shapeOcpus returns the number of OCPUs an instance of the shape uses.
+func shapeOcpus(shape string, shapeConfig FlexShapeConfig) int64 {
+	if shapeConfig.Ocpus != nil {
+		return int64(math.Ceil(float64(*shapeConfig.Ocpus)))
+	}
+	parts := strings.Split(shape, ".")
+	if ocpus, err := strconv.ParseInt(parts[len(parts)-1], 10, 64); err == nil {
+		return ocpus
+	}
+	return 1
+}
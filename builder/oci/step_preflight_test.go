@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/limits"
+)
+
+func TestStepPreflight(t *testing.T) {
+	state := testState()
+
+	step := new(stepPreflight)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+}
+
+func TestStepPreflight_compartmentDeleting(t *testing.T) {
+	state := testState()
+	driver := state.Get("driver").(*driverMock)
+	driver.GetCompartmentStateState = "DELETING"
+
+	step := new(stepPreflight)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if err := state.Get("error").(error); !strings.Contains(err.Error(), "is DELETING") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestStepPreflight_quotaZero(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).Shape = "VM.Standard.E4.Flex"
+	driver := state.Get("driver").(*driverMock)
+	available := int64(0)
+	quota := float32(0)
+	driver.GetComputeAvailabilityAvailability = &limits.ResourceAvailability{
+		Available:           &available,
+		EffectiveQuotaValue: &quota,
+	}
+
+	step := new(stepPreflight)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if err := state.Get("error").(error); !strings.Contains(err.Error(), "quota policy blocks VM.Standard.E4.Flex") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestStepPreflight_checksUnavailable(t *testing.T) {
+	state := testState()
+	driver := state.Get("driver").(*driverMock)
+	driver.GetCompartmentStateErr = errors.New("not authorized")
+	driver.GetComputeAvailabilityErr = errors.New("not authorized")
+
+	step := new(stepPreflight)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+}
+
+func TestShapeLimitName(t *testing.T) {
+	cases := map[string]string{
+		"VM.Standard.E4.Flex":    "standard-e4-core-count",
+		"VM.Standard3.Flex":      "standard3-core-count",
+		"VM.Standard2.4":         "standard2-core-count",
+		"VM.Optimized3.Flex":     "optimized3-core-count",
+		"VM.Standard.E2.1.Micro": "",
+		"VM.DenseIO2.8":          "",
+		"BM.Standard2.52":        "",
+	}
+	for shape, expected := range cases {
+		if name := shapeLimitName(shape); name != expected {
+			t.Errorf("shapeLimitName(%q) = %q, expected %q", shape, name, expected)
+		}
+	}
+}
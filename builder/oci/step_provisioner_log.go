@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/uuid"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/loggingingestion"
+)
+
+const (
+	// logMirrorFlushInterval is how often buffered output is sent to OCI
+	// Logging.
+	logMirrorFlushInterval = 2 * time.Second
+	// logMirrorBatchSize is the number of buffered lines that triggers an
+	// early flush.
+	logMirrorBatchSize = 100
+)
+
+// stepProvisionerLog mirrors the output of the following steps, notably the
+// provisioners, into an OCI Logging custom log while the build runs.
+type stepProvisionerLog struct {
+	ui *logMirrorUi
+}
+
+func (s *stepProvisionerLog) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if config.ProvisionerLogID == "" {
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Mirroring provisioner output to log (%s)...", config.ProvisionerLogID))
+
+	subject := config.PackerBuildName
+	if id, ok := state.GetOk("instance_id"); ok {
+		subject = id.(string)
+	}
+
+	s.ui = newLogMirrorUi(ui, driver, config.ProvisionerLogID, subject)
+	state.Put("ui", s.ui)
+
+	return multistep.ActionContinue
+}
+
+func (s *stepProvisionerLog) Cleanup(state multistep.StateBag) {
+	if s.ui == nil {
+		return
+	}
+
+	state.Put("ui", s.ui.Ui)
+	s.ui.Close()
+}
+
+// logMirrorUi is a packersdk.Ui that sends everything it displays to an OCI
+// Logging custom log in batches, from a background goroutine so that slow
+// requests never hold up the build.
+type logMirrorUi struct {
+	packersdk.Ui
+
+	driver  Driver
+	logId   string
+	subject string
+
+	mu      sync.Mutex
+	entries []loggingingestion.LogEntry
+	failed  bool
+
+	flush chan struct{}
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+func newLogMirrorUi(ui packersdk.Ui, driver Driver, logId string, subject string) *logMirrorUi {
+	u := &logMirrorUi{
+		Ui:      ui,
+		driver:  driver,
+		logId:   logId,
+		subject: subject,
+		flush:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	u.wg.Add(1)
+	go u.run()
+
+	return u
+}
+
+func (u *logMirrorUi) Say(message string) {
+	u.Ui.Say(message)
+	u.mirror(message)
+}
+
+func (u *logMirrorUi) Sayf(message string, args ...any) {
+	u.Say(fmt.Sprintf(message, args...))
+}
+
+func (u *logMirrorUi) Message(message string) {
+	u.Ui.Message(message)
+	u.mirror(message)
+}
+
+func (u *logMirrorUi) Error(message string) {
+	u.Ui.Error(message)
+	u.mirror(message)
+}
+
+func (u *logMirrorUi) Errorf(message string, args ...any) {
+	u.Error(fmt.Sprintf(message, args...))
+}
+
+// Close sends any buffered output and stops mirroring.
+func (u *logMirrorUi) Close() {
+	close(u.done)
+	u.wg.Wait()
+}
+
+func (u *logMirrorUi) mirror(message string) {
+	now := common.SDKTime{Time: time.Now()}
+
+	u.mu.Lock()
+	for _, line := range strings.Split(strings.TrimRight(message, "\n"), "\n") {
+		u.entries = append(u.entries, loggingingestion.LogEntry{
+			Data: common.String(line),
+			Id:   common.String(uuid.TimeOrderedUUID()),
+			Time: &now,
+		})
+	}
+	full := len(u.entries) >= logMirrorBatchSize
+	u.mu.Unlock()
+
+	if full {
+		select {
+		case u.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (u *logMirrorUi) run() {
+	defer u.wg.Done()
+
+	ticker := time.NewTicker(logMirrorFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-u.flush:
+		case <-u.done:
+			u.send()
+			return
+		}
+		u.send()
+	}
+}
+
+func (u *logMirrorUi) send() {
+	u.mu.Lock()
+	entries := u.entries
+	u.entries = nil
+	u.mu.Unlock()
+
+	if len(entries) == 0 {
+		return
+	}
+
+	if err := u.driver.PutLogs(context.TODO(), u.logId, u.subject, entries); err != nil {
+		u.mu.Lock()
+		failed := u.failed
+		u.failed = true
+		u.mu.Unlock()
+
+		// Mirroring is best effort: report the first failure, but never fail
+		// the build because of it.
+		if !failed {
+			u.Ui.Error(fmt.Sprintf("Error mirroring provisioner output to log (%s), some output may be missing: %s", u.logId, err))
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func TestStepProvisionerLog(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).ProvisionerLogID = "ocid1.log.oc1..aaa"
+
+	originalUi := state.Get("ui").(packersdk.Ui)
+
+	step := new(stepProvisionerLog)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	ui := state.Get("ui").(packersdk.Ui)
+	if ui == originalUi {
+		t.Fatalf("should have replaced ui")
+	}
+	ui.Say("Provisioning with shell script")
+	ui.Message("line one\nline two")
+
+	step.Cleanup(state)
+
+	if state.Get("ui").(packersdk.Ui) != originalUi {
+		t.Fatalf("should have restored ui")
+	}
+
+	if len(driver.PutLogsEntries) != 3 {
+		t.Fatalf("expected 3 mirrored entries, got %d", len(driver.PutLogsEntries))
+	}
+	if data := *driver.PutLogsEntries[2].Data; data != "line two" {
+		t.Fatalf("unexpected mirrored entry: %q", data)
+	}
+}
+
+func TestStepProvisionerLog_Disabled(t *testing.T) {
+	state := testState()
+
+	originalUi := state.Get("ui").(packersdk.Ui)
+
+	step := new(stepProvisionerLog)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if state.Get("ui").(packersdk.Ui) != originalUi {
+		t.Fatalf("should NOT have replaced ui")
+	}
+}
+
+func TestStepProvisionerLog_PutLogsErr(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).ProvisionerLogID = "ocid1.log.oc1..aaa"
+
+	step := new(stepProvisionerLog)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.PutLogsErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	state.Get("ui").(packersdk.Ui).Say("Provisioning with shell script")
+
+	step.Cleanup(state)
+
+	if _, ok := state.GetOk("error"); ok {
+		t.Fatalf("mirroring failures should NOT fail the build")
+	}
+}
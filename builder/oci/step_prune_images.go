@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// stepPruneImages deletes the images matching image_retention beyond the
+// most recent keep_releases once the build succeeded. The new image is
+// always kept. Failing to list or delete images does not fail the build, as
+// the new image is already available.
+type stepPruneImages struct{}
+
+func (s *stepPruneImages) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	rawImage, ok := state.GetOk("image")
+	if !ok || config.ImageRetention.NameRegex == "" {
+		return multistep.ActionContinue
+	}
+	image := rawImage.(core.Image)
+
+	retention := config.ImageRetention
+	compartmentID := retention.CompartmentID
+	if compartmentID == "" && image.CompartmentId != nil {
+		compartmentID = *image.CompartmentId
+	}
+	if compartmentID == "" {
+		compartmentID = config.ImageCompartmentID
+	}
+	nameRegex := regexp.MustCompile(retention.NameRegex)
+
+	ui.Say(fmt.Sprintf("Pruning images matching %q, keeping the %d most recent...", retention.NameRegex, retention.KeepReleases))
+
+	images, err := driver.ListCustomImages(ctx, compartmentID)
+	if err != nil {
+		ui.Error(fmt.Sprintf("Error listing images to prune. Older images were not deleted: %s", err))
+		return multistep.ActionContinue
+	}
+
+	// The new image counts as the most recent release, even if the listing
+	// does not include it yet
+	name := config.imageName()
+	if image.DisplayName != nil {
+		name = *image.DisplayName
+	}
+	kept := 0
+	if nameRegex.MatchString(name) {
+		kept = 1
+	}
+	for _, candidate := range images {
+		if *candidate.Id == *image.Id || candidate.DisplayName == nil || !nameRegex.MatchString(*candidate.DisplayName) {
+			continue
+		}
+		if kept < retention.KeepReleases {
+			kept++
+			continue
+		}
+
+		ui.Say(fmt.Sprintf("Deleting image %s (%s)...", *candidate.DisplayName, *candidate.Id))
+		if err := driver.DeleteImage(ctx, *candidate.Id); err != nil {
+			ui.Error(fmt.Sprintf("Error deleting image %s. Please delete it manually: %s", *candidate.Id, err))
+		}
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepPruneImages) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestStepPruneImages(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{
+		Id:            stringPtr("ocid1.image.new"),
+		DisplayName:   stringPtr("golden-4"),
+		CompartmentId: stringPtr("ocid1.compartment.golden"),
+	})
+	state.Get("config").(*Config).ImageRetention = ImageRetentionConfig{KeepReleases: 2, NameRegex: "^golden-"}
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesImages = []core.Image{
+		{Id: stringPtr("ocid1.image.new"), DisplayName: stringPtr("golden-4")},
+		{Id: stringPtr("ocid1.image.3"), DisplayName: stringPtr("golden-3")},
+		{Id: stringPtr("ocid1.image.other"), DisplayName: stringPtr("silver-1")},
+		{Id: stringPtr("ocid1.image.2"), DisplayName: stringPtr("golden-2")},
+		{Id: stringPtr("ocid1.image.1"), DisplayName: stringPtr("golden-1")},
+	}
+
+	step := new(stepPruneImages)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.ListCustomImagesCompartmentID != "ocid1.compartment.golden" {
+		t.Fatalf("should've pruned the compartment of the image: %q", driver.ListCustomImagesCompartmentID)
+	}
+	if expected := []string{"ocid1.image.2", "ocid1.image.1"}; !reflect.DeepEqual(driver.DeleteImageIDs, expected) {
+		t.Fatalf("should've deleted the older releases %q, deleted %q", expected, driver.DeleteImageIDs)
+	}
+}
+
+func TestStepPruneImages_deleteErr(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image.new"), DisplayName: stringPtr("golden-2")})
+	state.Get("config").(*Config).ImageRetention = ImageRetentionConfig{KeepReleases: 1, NameRegex: "^golden-"}
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesImages = []core.Image{
+		{Id: stringPtr("ocid1.image.1"), DisplayName: stringPtr("golden-1")},
+	}
+	driver.DeleteImageErr = errors.New("error")
+
+	step := new(stepPruneImages)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); ok {
+		t.Fatalf("should not fail the build")
+	}
+}
+
+func TestStepPruneImages_listErr(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image.new")})
+	state.Get("config").(*Config).ImageRetention = ImageRetentionConfig{KeepReleases: 1, NameRegex: "^golden-"}
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesErr = errors.New("error")
+
+	step := new(stepPruneImages)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); ok {
+		t.Fatalf("should not fail the build")
+	}
+	if driver.DeleteImageIDs != nil {
+		t.Fatalf("should not have deleted images: %q", driver.DeleteImageIDs)
+	}
+}
+
+func TestStepPruneImages_disabled(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image.new")})
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepPruneImages)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if driver.ListCustomImagesCompartmentID != "" {
+		t.Fatalf("should not have listed images")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepReservedPublicIP assigns a reserved public IP to the instance, either
+// an existing one or one created for the build, and releases it on teardown.
+type stepReservedPublicIP struct {
+	publicIPID string
+	created    bool
+}
+
+func (s *stepReservedPublicIP) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if config.PublicIPLifetime != "RESERVED" {
+		return multistep.ActionContinue
+	}
+
+	if config.ReservedPublicIPID != "" {
+		ui.Say(fmt.Sprintf("Assigning reserved public IP (%s) to instance...", config.ReservedPublicIPID))
+		if err := driver.AssignPublicIP(ctx, config.ReservedPublicIPID, id); err != nil {
+			err = fmt.Errorf("Error assigning reserved public IP: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		s.publicIPID = config.ReservedPublicIPID
+	} else {
+		ui.Say("Creating reserved public IP for instance...")
+		publicIPID, err := driver.CreateReservedPublicIP(ctx, id)
+		if err != nil {
+			err = fmt.Errorf("Error creating reserved public IP: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		s.publicIPID = publicIPID
+		s.created = true
+	}
+
+	if err := driver.WaitForPublicIPState(ctx, s.publicIPID, []string{"PROVISIONING", "AVAILABLE", "ASSIGNING"}, "ASSIGNED"); err != nil {
+		err = fmt.Errorf("Error waiting for reserved public IP to be assigned: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	state.Put("public_ip_id", s.publicIPID)
+
+	ui.Say(fmt.Sprintf("Assigned reserved public IP (%s).", s.publicIPID))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepReservedPublicIP) Cleanup(state multistep.StateBag) {
+	// The reserved public IP stays assigned to a kept instance
+	if s.publicIPID == "" || keepInstance(state) {
+		return
+	}
+
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if s.created && !config.RetainReservedPublicIP {
+		ui.Say(fmt.Sprintf("Deleting reserved public IP (%s)...", s.publicIPID))
+		if err := driver.DeletePublicIP(context.TODO(), s.publicIPID); err != nil {
+			err = fmt.Errorf("Error deleting reserved public IP. Please delete it manually: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return
+		}
+		ui.Say("Deleted reserved public IP.")
+		return
+	}
+
+	ui.Say(fmt.Sprintf("Unassigning reserved public IP (%s)...", s.publicIPID))
+	if err := driver.UnassignPublicIP(context.TODO(), s.publicIPID); err != nil {
+		err = fmt.Errorf("Error unassigning reserved public IP. Please unassign it manually: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return
+	}
+	ui.Say("Unassigned reserved public IP.")
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepReservedPublicIP_Create(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).PublicIPLifetime = "RESERVED"
+
+	step := new(stepReservedPublicIP)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	publicIPIDRaw, ok := state.GetOk("public_ip_id")
+	if !ok {
+		t.Fatalf("should have public_ip_id")
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeletePublicIPID != publicIPIDRaw.(string) {
+		t.Fatalf("should've deleted public IP (%s != %s)", driver.DeletePublicIPID, publicIPIDRaw.(string))
+	}
+}
+
+func TestStepReservedPublicIP_Retain(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.PublicIPLifetime = "RESERVED"
+	config.RetainReservedPublicIP = true
+
+	step := new(stepReservedPublicIP)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeletePublicIPID != "" {
+		t.Fatalf("should NOT have deleted public IP")
+	}
+	if driver.UnassignPublicIPID != driver.CreateReservedPublicIPID {
+		t.Fatalf("should've unassigned public IP")
+	}
+}
+
+func TestStepReservedPublicIP_Existing(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.PublicIPLifetime = "RESERVED"
+	config.ReservedPublicIPID = "ocid1.publicip.existing"
+
+	step := new(stepReservedPublicIP)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.AssignPublicIPID != "ocid1.publicip.existing" {
+		t.Fatalf("should've assigned existing public IP")
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeletePublicIPID != "" {
+		t.Fatalf("should NOT have deleted existing public IP")
+	}
+	if driver.UnassignPublicIPID != "ocid1.publicip.existing" {
+		t.Fatalf("should've unassigned existing public IP")
+	}
+}
+
+func TestStepReservedPublicIP_CreateReservedPublicIPErr(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).PublicIPLifetime = "RESERVED"
+
+	step := new(stepReservedPublicIP)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateReservedPublicIPErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+
+	if _, ok := state.GetOk("public_ip_id"); ok {
+		t.Fatalf("should NOT have public_ip_id")
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepRestartInstance starts the instance stopped by shutdown_before_image
+// again once the image is captured, restoring the state it was in before the
+// image, so that it is kept running with the "stop" instance_disposal.
+type stepRestartInstance struct{}
+
+func (s *stepRestartInstance) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if _, ok := state.GetOk("image"); !ok || !config.RestartAfterImage {
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Starting instance again...")
+
+	if err := driver.InstanceAction(ctx, id, "START"); err != nil {
+		err = fmt.Errorf("Error starting instance: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if err := driver.WaitForInstanceState(ctx, id, InstanceWaitStates("RUNNING"), "RUNNING"); err != nil {
+		err = fmt.Errorf("Error waiting for instance to start: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	state.Put("instance_restarted", true)
+
+	ui.Say("Instance started.")
+
+	return multistep.ActionContinue
+}
+
+func (s *stepRestartInstance) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestStepRestartInstance(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	state.Get("config").(*Config).RestartAfterImage = true
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepRestartInstance)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if !reflect.DeepEqual(driver.InstanceActionActions, []string{"START"}) {
+		t.Fatalf("should've started the instance: %q", driver.InstanceActionActions)
+	}
+	if _, ok := state.GetOk("instance_restarted"); !ok {
+		t.Fatalf("should've recorded the restart")
+	}
+}
+
+func TestStepRestartInstance_err(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	state.Get("config").(*Config).RestartAfterImage = true
+	driver := state.Get("driver").(*driverMock)
+	driver.InstanceActionErr = errors.New("error")
+
+	step := new(stepRestartInstance)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("instance_restarted"); ok {
+		t.Fatalf("should not have recorded a restart")
+	}
+}
+
+func TestStepRestartInstance_disabled(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image")})
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepRestartInstance)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if len(driver.InstanceActionActions) != 0 {
+		t.Fatalf("should not have started the instance")
+	}
+}
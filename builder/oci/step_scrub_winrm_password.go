@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepScrubWinRMPassword replaces the password generated with
+// rotate_winrm_password by a random one nobody knows before the image is
+// captured, so that instances launched from the image never accept the
+// credential used by the build.
+type stepScrubWinRMPassword struct{}
+
+func (s *stepScrubWinRMPassword) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if !config.RotateWinRMPassword || config.SkipCreateImage {
+		return multistep.ActionContinue
+	}
+
+	// A provisioner running sysprep shuts the instance down, and its next
+	// boot sets a new password anyway.
+	if instanceState, err := driver.GetInstanceState(ctx, id); err == nil && instanceState != "RUNNING" {
+		ui.Say(fmt.Sprintf("Instance is %s, skipping WinRM password scrub...", instanceState))
+		return multistep.ActionContinue
+	}
+
+	comm, ok := state.GetOk("communicator")
+	if !ok || comm == nil {
+		ui.Say("No communicator, skipping WinRM password scrub...")
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Scrubbing the generated WinRM password from the instance...")
+
+	password, err := generatePassword()
+	if err == nil {
+		packersdk.LogSecretFilter.Set(password)
+		cmd := &packersdk.RemoteCmd{
+			Command: fmt.Sprintf("net user %s %s /logonpasswordchg:yes", config.Comm.WinRMUser, password),
+		}
+		err = cmd.RunWithUi(ctx, comm.(packersdk.Communicator), ui)
+		if err == nil && cmd.ExitStatus() != 0 {
+			err = fmt.Errorf("exited with status %d", cmd.ExitStatus())
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("Error scrubbing WinRM password: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepScrubWinRMPassword) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
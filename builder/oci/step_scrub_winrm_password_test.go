@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func TestStepScrubWinRMPassword(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.RotateWinRMPassword = true
+	config.Comm.WinRMUser = "opc"
+	config.Comm.WinRMPassword = "generated"
+	comm := new(packersdk.MockCommunicator)
+	state.Put("communicator", comm)
+
+	step := new(stepScrubWinRMPassword)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	command := comm.StartCmd.Command
+	if !strings.HasPrefix(command, "net user opc ") || !strings.HasSuffix(command, " /logonpasswordchg:yes") {
+		t.Fatalf("unexpected command: %q", command)
+	}
+	if strings.Contains(command, "generated") {
+		t.Fatalf("should've set a new password: %q", command)
+	}
+}
+
+func TestStepScrubWinRMPassword_stopped(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).RotateWinRMPassword = true
+	state.Get("driver").(*driverMock).GetInstanceStateState = "STOPPED"
+	comm := new(packersdk.MockCommunicator)
+	state.Put("communicator", comm)
+
+	step := new(stepScrubWinRMPassword)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if comm.StartCalled {
+		t.Fatalf("should not have run a command on a stopped instance")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/uuid"
+)
+
+// stepSecurityListRule temporarily opens the communicator port in a security
+// list and removes exactly that rule again on cleanup.
+type stepSecurityListRule struct {
+	description string
+}
+
+func (s *stepSecurityListRule) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if config.SecurityListID == "" {
+		return multistep.ActionContinue
+	}
+
+	port := config.Comm.Port()
+	description := fmt.Sprintf("packer-%s", uuid.TimeOrderedUUID())
+
+	ui.Say(fmt.Sprintf("Adding temporary ingress rule for port %d to security list (%s)...", port, config.SecurityListID))
+
+	if err := driver.AddSecurityListIngressRule(ctx, config.SecurityListID, port, description); err != nil {
+		err = fmt.Errorf("Error adding security list ingress rule: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	s.description = description
+
+	return multistep.ActionContinue
+}
+
+func (s *stepSecurityListRule) Cleanup(state multistep.StateBag) {
+	if s.description == "" {
+		return
+	}
+
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	ui.Say(fmt.Sprintf("Removing temporary ingress rule from security list (%s)...", config.SecurityListID))
+
+	if err := driver.RemoveSecurityListIngressRules(context.TODO(), config.SecurityListID, s.description); err != nil {
+		err = fmt.Errorf("Error removing security list ingress rule %q. Please remove it manually: %s", s.description, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return
+	}
+
+	ui.Say("Removed temporary ingress rule.")
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepSecurityListRule(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).SecurityListID = "ocid1.securitylist..."
+
+	step := new(stepSecurityListRule)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.AddSecurityListIngressRuleID != "ocid1.securitylist..." {
+		t.Fatalf("should have added ingress rule")
+	}
+
+	step.Cleanup(state)
+
+	if driver.RemoveSecurityListIngressRulesID != "ocid1.securitylist..." {
+		t.Fatalf("should have removed ingress rule")
+	}
+}
+
+func TestStepSecurityListRule_NoSecurityList(t *testing.T) {
+	state := testState()
+
+	step := new(stepSecurityListRule)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	step.Cleanup(state)
+
+	if driver.AddSecurityListIngressRuleID != "" || driver.RemoveSecurityListIngressRulesID != "" {
+		t.Fatalf("should NOT have touched any security list")
+	}
+}
+
+func TestStepSecurityListRule_AddSecurityListIngressRuleErr(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).SecurityListID = "ocid1.securitylist..."
+
+	step := new(stepSecurityListRule)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.AddSecurityListIngressRuleErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+
+	step.Cleanup(state)
+
+	if driver.RemoveSecurityListIngressRulesID != "" {
+		t.Fatalf("should NOT have removed any ingress rule")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepShutdown stops the instance before the image is captured, so that the
+// operating system flushes and unmounts its filesystems instead of being
+// imaged while running. A stop by the build, or by the provisioners, sets
+// instance_stopped_by_build so that a later failure is not mistaken for
+// platform maintenance and retried.
+type stepShutdown struct{}
+
+func (s *stepShutdown) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if config.SkipCreateImage {
+		return multistep.ActionContinue
+	}
+
+	// A provisioner running sysprep shuts the instance down itself
+	if instanceState, err := driver.GetInstanceState(ctx, id); err == nil && (instanceState == "STOPPING" || instanceState == "STOPPED") {
+		state.Put("instance_stopped_by_build", true)
+	}
+
+	if !config.ShutdownBeforeImage {
+		return multistep.ActionContinue
+	}
+	state.Put("instance_stopped_by_build", true)
+
+	if config.ShutdownCommand != "" {
+		comm, ok := state.GetOk("communicator")
+		if !ok || comm == nil {
+			err := fmt.Errorf("Error shutting down instance: 'shutdown_command' requires a communicator")
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+
+		ui.Say(fmt.Sprintf("Shutting down instance with %q...", config.ShutdownCommand))
+
+		// The connection usually drops as the instance goes down, so the
+		// command is not waited for.
+		cmd := &packersdk.RemoteCmd{Command: config.ShutdownCommand}
+		if err := comm.(packersdk.Communicator).Start(ctx, cmd); err != nil {
+			err = fmt.Errorf("Error running shutdown command: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+	} else {
+		ui.Say("Stopping instance gracefully...")
+
+		if err := driver.InstanceAction(ctx, id, "SOFTSTOP"); err != nil {
+			err = fmt.Errorf("Error stopping instance: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+	}
+
+	if err := driver.WaitForInstanceState(ctx, id, InstanceWaitStates("STOPPED"), "STOPPED"); err != nil {
+		err = fmt.Errorf("Error waiting for instance to stop: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say("Instance stopped.")
+
+	return multistep.ActionContinue
+}
+
+func (s *stepShutdown) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func TestStepShutdown(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).ShutdownBeforeImage = true
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepShutdown)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if !reflect.DeepEqual(driver.InstanceActionActions, []string{"SOFTSTOP"}) {
+		t.Fatalf("should've soft stopped the instance: %q", driver.InstanceActionActions)
+	}
+}
+
+func TestStepShutdown_command(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.ShutdownBeforeImage = true
+	config.ShutdownCommand = "sudo shutdown -P now"
+	driver := state.Get("driver").(*driverMock)
+	comm := new(packersdk.MockCommunicator)
+	state.Put("communicator", comm)
+
+	step := new(stepShutdown)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if comm.StartCmd.Command != "sudo shutdown -P now" {
+		t.Fatalf("unexpected command: %q", comm.StartCmd.Command)
+	}
+	if len(driver.InstanceActionActions) != 0 {
+		t.Fatalf("should not have stopped the instance through the API")
+	}
+}
+
+func TestStepShutdown_waitErr(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).ShutdownBeforeImage = true
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForInstanceStateErr = errors.New("timed out")
+
+	step := new(stepShutdown)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
+
+func TestStepShutdown_stoppedByBuild(t *testing.T) {
+	for _, tt := range []struct {
+		name                string
+		shutdownBeforeImage bool
+		instanceState       string
+		expected            bool
+	}{
+		{name: "Shutdown", shutdownBeforeImage: true, instanceState: "RUNNING", expected: true},
+		{name: "Sysprep", instanceState: "STOPPED", expected: true},
+		{name: "Running", instanceState: "RUNNING", expected: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			state := testState()
+			state.Put("instance_id", "ocid1...")
+			state.Get("config").(*Config).ShutdownBeforeImage = tt.shutdownBeforeImage
+			state.Get("driver").(*driverMock).GetInstanceStateState = tt.instanceState
+
+			step := new(stepShutdown)
+			defer step.Cleanup(state)
+
+			if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+				t.Fatalf("bad action: %#v", action)
+			}
+
+			if _, ok := state.GetOk("instance_stopped_by_build"); ok != tt.expected {
+				t.Fatalf("expected instance_stopped_by_build to be %t", tt.expected)
+			}
+		})
+	}
+}
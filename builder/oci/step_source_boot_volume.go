@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepSourceBootVolume prepares the boot volume the instance is launched
+// from when building from a boot volume rather than an image. It clones
+// source_boot_volume_ocid, leaving the source boot volume untouched, while
+// boot_volume_ocid is used as is.
+type stepSourceBootVolume struct {
+	cloneID string
+}
+
+func (s *stepSourceBootVolume) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	sourceID := config.SourceBootVolumeID
+	if config.BootVolumeID != "" {
+		sourceID = config.BootVolumeID
+	}
+	if sourceID == "" {
+		return multistep.ActionContinue
+	}
+
+	source, err := driver.GetBootVolume(ctx, sourceID)
+	if err != nil {
+		err = fmt.Errorf("Error getting source boot volume: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+	if source.LifecycleState != "AVAILABLE" {
+		err = fmt.Errorf("Error using source boot volume %s: boot volume is %s, not AVAILABLE",
+			sourceID, source.LifecycleState)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	// A boot volume can only be attached to an instance in its own
+	// availability domain
+	if len(config.AvailabilityDomains) > 0 || config.AvailabilityDomain != *source.AvailabilityDomain {
+		ui.Say(fmt.Sprintf("Launching in availability domain %s of the source boot volume...", *source.AvailabilityDomain))
+	}
+	config.AvailabilityDomain = *source.AvailabilityDomain
+	config.AvailabilityDomains = nil
+	if source.DisplayName != nil {
+		config.sourceBootVolumeName = *source.DisplayName
+	}
+
+	if config.BootVolumeID != "" {
+		ui.Say(fmt.Sprintf("Launching from boot volume (%s), which will keep the changes made by the build.", sourceID))
+		config.launchBootVolumeID = sourceID
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Cloning source boot volume (%s)...", config.SourceBootVolumeID))
+
+	cloneID, err := driver.CloneBootVolume(ctx, source)
+	if err != nil {
+		err = fmt.Errorf("Error cloning source boot volume: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+	s.cloneID = cloneID
+
+	if err := driver.WaitForBootVolumeState(ctx, cloneID, []string{"PROVISIONING", "RESTORING"}, "AVAILABLE"); err != nil {
+		err = fmt.Errorf("Error waiting for boot volume clone (%s) to become available: %s", cloneID, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	config.launchBootVolumeID = cloneID
+
+	ui.Say(fmt.Sprintf("Cloned source boot volume (%s).", cloneID))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepSourceBootVolume) Cleanup(state multistep.StateBag) {
+	if s.cloneID == "" {
+		return
+	}
+
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	// The clone is the boot volume of the instance, kept along with it
+	if keepInstance(state) || config.PreserveBootVolume || config.InstanceDisposal == instanceDisposalStop {
+		ui.Say(fmt.Sprintf("Keeping boot volume clone (%s).", s.cloneID))
+		return
+	}
+
+	// The clone is normally deleted when the instance is terminated, so it
+	// only remains when the build failed before or while launching it
+	volume, err := driver.GetBootVolume(context.TODO(), s.cloneID)
+	if err != nil {
+		var reqErr *RequestError
+		if errors.As(err, &reqErr) && reqErr.StatusCode == http.StatusNotFound {
+			return
+		}
+		err = fmt.Errorf("Error getting boot volume clone. Please delete %s manually if it still exists: %s", s.cloneID, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return
+	}
+	if volume.LifecycleState == "TERMINATING" || volume.LifecycleState == "TERMINATED" {
+		return
+	}
+
+	ui.Say(fmt.Sprintf("Deleting boot volume clone (%s)...", s.cloneID))
+
+	if err := driver.DeleteBootVolume(context.TODO(), s.cloneID); err != nil {
+		err = fmt.Errorf("Error deleting boot volume clone. Please delete %s manually: %s", s.cloneID, err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return
+	}
+
+	ui.Say("Deleted boot volume clone.")
+}
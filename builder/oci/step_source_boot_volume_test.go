@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepSourceBootVolume(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.SourceBootVolumeID = "ocid1.bootvolume.source"
+	config.AvailabilityDomains = []string{"aaaa:PHX-AD-1", "aaaa:PHX-AD-2"}
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepSourceBootVolume)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CloneBootVolumeSourceID != "ocid1.bootvolume.source" {
+		t.Fatalf("should've cloned the source boot volume: %q", driver.CloneBootVolumeSourceID)
+	}
+	if config.launchBootVolumeID != "ocid1.bootvolume.clone" {
+		t.Fatalf("should've launched from the clone: %q", config.launchBootVolumeID)
+	}
+	if config.sourceBootVolumeName != "last-known-good" {
+		t.Fatalf("unexpected source name: %q", config.sourceBootVolumeName)
+	}
+	if config.AvailabilityDomain != "aaaa:PHX-AD-2" || config.AvailabilityDomains != nil {
+		t.Fatalf("should've launched in the availability domain of the source: %q %q",
+			config.AvailabilityDomain, config.AvailabilityDomains)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteBootVolumeID != "ocid1.bootvolume.clone" {
+		t.Fatalf("should've deleted the remaining clone: %q", driver.DeleteBootVolumeID)
+	}
+}
+
+func TestStepSourceBootVolume_cloneTerminated(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).SourceBootVolumeID = "ocid1.bootvolume.source"
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepSourceBootVolume)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	// Terminated along with the instance
+	driver.GetBootVolumeState = "TERMINATED"
+	step.Cleanup(state)
+
+	if driver.DeleteBootVolumeID != "" {
+		t.Fatalf("should not have deleted the clone again")
+	}
+}
+
+func TestStepSourceBootVolume_preserveBootVolume(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.SourceBootVolumeID = "ocid1.bootvolume.source"
+	config.PreserveBootVolume = true
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepSourceBootVolume)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteBootVolumeID != "" {
+		t.Fatalf("should've kept the preserved clone")
+	}
+}
+
+func TestStepSourceBootVolume_notAvailable(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).SourceBootVolumeID = "ocid1.bootvolume.source"
+	driver := state.Get("driver").(*driverMock)
+	driver.GetBootVolumeState = "FAULTY"
+
+	step := new(stepSourceBootVolume)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+	if driver.CloneBootVolumeSourceID != "" {
+		t.Fatalf("should not have cloned the source boot volume")
+	}
+}
+
+func TestStepSourceBootVolume_waitErr(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).SourceBootVolumeID = "ocid1.bootvolume.source"
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForBootVolumeStateErr = errors.New("error")
+
+	step := new(stepSourceBootVolume)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteBootVolumeID != "ocid1.bootvolume.clone" {
+		t.Fatalf("should've deleted the clone: %q", driver.DeleteBootVolumeID)
+	}
+}
+
+func TestStepSourceBootVolume_noSource(t *testing.T) {
+	state := testState()
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepSourceBootVolume)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if driver.CloneBootVolumeSourceID != "" {
+		t.Fatalf("should not have cloned a boot volume")
+	}
+}
+
+func TestStepSourceBootVolume_direct(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.BootVolumeID = "ocid1.bootvolume.golden"
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepSourceBootVolume)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CloneBootVolumeSourceID != "" {
+		t.Fatalf("should not have cloned the boot volume")
+	}
+	if config.launchBootVolumeID != "ocid1.bootvolume.golden" {
+		t.Fatalf("should've launched from the boot volume: %q", config.launchBootVolumeID)
+	}
+	if config.AvailabilityDomain != "aaaa:PHX-AD-2" {
+		t.Fatalf("should've launched in the availability domain of the boot volume: %q", config.AvailabilityDomain)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteBootVolumeID != "" {
+		t.Fatalf("should not have deleted the boot volume")
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// consoleStreamInterval is the default interval between two captures of the
+// console history while streaming it.
+const consoleStreamInterval = 15 * time.Second
+
+// stepStreamConsole prints the serial console output of the instance while
+// Packer waits for the communicator, so that kernel panics and cloud-init
+// errors show up as they happen. OCI has no live serial console API, so the
+// console history is captured periodically and new lines are printed until
+// the communicator connects.
+type stepStreamConsole struct {
+	interval time.Duration
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+func (s *stepStreamConsole) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if !config.StreamConsoleOutput {
+		return multistep.ActionContinue
+	}
+
+	interval := s.interval
+	if interval == 0 {
+		interval = consoleStreamInterval
+	}
+
+	ui.Say("Streaming instance console output until connected...")
+
+	// The stream outlives this step, so it does not use the step context
+	streamCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done.Add(1)
+	go func() {
+		defer s.done.Done()
+
+		var printed string
+		for {
+			if _, connected := state.GetOk("communicator"); connected {
+				return
+			}
+
+			history, err := driver.CaptureConsoleHistory(streamCtx, id)
+			if streamCtx.Err() != nil {
+				return
+			}
+			if err != nil {
+				log.Printf("[WARN] Failed to capture console history: %s", err)
+			} else {
+				var lines []string
+				printed, lines = newConsoleLines(printed, history)
+				for _, line := range lines {
+					ui.Message(fmt.Sprintf("console: %s", line))
+				}
+			}
+
+			select {
+			case <-streamCtx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+
+	return multistep.ActionContinue
+}
+
+func (s *stepStreamConsole) Cleanup(state multistep.StateBag) {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.done.Wait()
+}
+
+// newConsoleLines returns the complete lines of the console history next that
+// follow the already printed output, along with the output printed once they
+// are. Console histories are snapshots of a bounded buffer, so when next does
+// not extend printed, the lines following the last printed line are returned
+// if it can be found, and all of them otherwise.
+func newConsoleLines(printed string, next string) (string, []string) {
+	// Only print complete lines, the last one may still be written
+	end := strings.LastIndex(next, "\n")
+	if end < 0 {
+		return printed, nil
+	}
+	next = next[:end+1]
+
+	var rest string
+	switch {
+	case strings.HasPrefix(next, printed):
+		rest = next[len(printed):]
+	default:
+		rest = next
+		if trimmed := strings.TrimSuffix(printed, "\n"); trimmed != "" {
+			last := trimmed[strings.LastIndex(trimmed, "\n")+1:] + "\n"
+			if i := strings.LastIndex(next, last); i >= 0 {
+				rest = next[i+len(last):]
+			}
+		}
+	}
+
+	if rest == "" {
+		return next, nil
+	}
+	return next, strings.Split(strings.TrimSuffix(rest, "\n"), "\n")
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// captureSignalingDriver signals every console history capture.
+type captureSignalingDriver struct {
+	*driverMock
+	captured chan struct{}
+}
+
+func (d *captureSignalingDriver) CaptureConsoleHistory(ctx context.Context, instanceId string) (string, error) {
+	d.captured <- struct{}{}
+	return d.driverMock.CaptureConsoleHistory(ctx, instanceId)
+}
+
+func TestStepStreamConsole(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).StreamConsoleOutput = true
+
+	mock := state.Get("driver").(*driverMock)
+	mock.CaptureConsoleHistoryContent = "Booting Linux\nKernel panic\n"
+	driver := &captureSignalingDriver{driverMock: mock, captured: make(chan struct{})}
+	state.Put("driver", driver)
+
+	step := &stepStreamConsole{interval: time.Millisecond}
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	// The output of the first capture is printed before the second one
+	<-driver.captured
+	<-driver.captured
+	state.Put("communicator", new(packersdk.MockCommunicator))
+	go func() {
+		for range driver.captured {
+		}
+	}()
+	step.Cleanup(state)
+	close(driver.captured)
+
+	output := state.Get("ui").(*packersdk.BasicUi).Writer.(*bytes.Buffer).String()
+	if strings.Count(output, "console: Kernel panic") != 1 {
+		t.Fatalf("expected the console output once, got %q", output)
+	}
+}
+
+func TestStepStreamConsole_Disabled(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepStreamConsole)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	step.Cleanup(state)
+
+	if driver.CaptureConsoleHistoryInstanceID != "" {
+		t.Fatal("should NOT have captured the console history")
+	}
+}
+
+func TestNewConsoleLines(t *testing.T) {
+	cases := []struct {
+		name        string
+		printed     string
+		next        string
+		wantPrinted string
+		wantLines   []string
+	}{
+		{"first", "", "a\nb\npartial", "a\nb\n", []string{"a", "b"}},
+		{"appended", "a\nb\n", "a\nb\nc\n", "a\nb\nc\n", []string{"c"}},
+		{"unchanged", "a\nb\n", "a\nb\n", "a\nb\n", nil},
+		{"rolled over", "a\nb\n", "b\nc\nd\n", "b\nc\nd\n", []string{"c", "d"}},
+		{"no complete line", "", "partial", "", nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			printed, lines := newConsoleLines(tc.printed, tc.next)
+			if printed != tc.wantPrinted {
+				t.Errorf("expected printed %q, got %q", tc.wantPrinted, printed)
+			}
+			if !reflect.DeepEqual(lines, tc.wantLines) {
+				t.Errorf("expected lines %q, got %q", tc.wantLines, lines)
+			}
+		})
+	}
+}
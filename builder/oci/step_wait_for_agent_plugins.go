@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// runCommandPlugin is the name of the Oracle Cloud Agent plugin running
+// bootstrap_script.
+const runCommandPlugin = "Compute Instance Run Command"
+
+// stepWaitForAgentPlugins waits for the configured Oracle Cloud Agent plugins
+// to be running, as the agent starts them a while after the instance boots
+// and the steps relying on them would otherwise race their startup.
+type stepWaitForAgentPlugins struct{}
+
+func (s *stepWaitForAgentPlugins) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	if len(config.WaitForAgentPlugins) == 0 {
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Waiting for agent plugins to be running: %s...", strings.Join(config.WaitForAgentPlugins, ", ")))
+
+	ctx, cancel := context.WithTimeout(ctx, config.AgentPluginsTimeout)
+	defer cancel()
+
+	if err := waitForAgentPlugins(ctx, driver, id, config.WaitForAgentPlugins, config.Timeouts.PollingInterval); err != nil {
+		err = fmt.Errorf("Error waiting for agent plugins: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say("Agent plugins are running.")
+
+	return multistep.ActionContinue
+}
+
+// waitForAgentPlugins polls the agent plugins of an instance until all the
+// given ones are running. A plugin that can never run fails the wait early.
+func waitForAgentPlugins(ctx context.Context, driver Driver, id string, plugins []string, interval time.Duration) error {
+	for {
+		states, err := driver.GetAgentPluginStates(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		var pending []string
+		for _, plugin := range plugins {
+			switch states[plugin] {
+			case "RUNNING":
+			case "NOT_SUPPORTED", "INVALID":
+				return fmt.Errorf("plugin %q is %s on this instance", plugin, states[plugin])
+			default:
+				pending = append(pending, plugin)
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %s: %w", strings.Join(pending, ", "), ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (s *stepWaitForAgentPlugins) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
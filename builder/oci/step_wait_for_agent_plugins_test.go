@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepWaitForAgentPlugins(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.WaitForAgentPlugins = []string{runCommandPlugin, "Bastion"}
+	config.AgentPluginsTimeout = time.Minute
+	config.Timeouts.PollingInterval = time.Millisecond
+	driver := state.Get("driver").(*driverMock)
+	driver.GetAgentPluginStatesStates = []map[string]string{
+		{},
+		{runCommandPlugin: "STOPPED", "Bastion": "RUNNING"},
+		{runCommandPlugin: "RUNNING", "Bastion": "RUNNING"},
+	}
+
+	step := new(stepWaitForAgentPlugins)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if len(driver.GetAgentPluginStatesStates) != 1 {
+		t.Fatalf("should've polled until every plugin was running")
+	}
+}
+
+func TestStepWaitForAgentPlugins_notSupported(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.WaitForAgentPlugins = []string{"OS Management Service Agent"}
+	config.AgentPluginsTimeout = time.Minute
+	driver := state.Get("driver").(*driverMock)
+	driver.GetAgentPluginStatesStates = []map[string]string{
+		{"OS Management Service Agent": "NOT_SUPPORTED"},
+	}
+
+	step := new(stepWaitForAgentPlugins)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if err := state.Get("error").(error); !strings.Contains(err.Error(), "NOT_SUPPORTED") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestStepWaitForAgentPlugins_timeout(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	config := state.Get("config").(*Config)
+	config.WaitForAgentPlugins = []string{runCommandPlugin}
+	config.AgentPluginsTimeout = 10 * time.Millisecond
+	config.Timeouts.PollingInterval = time.Millisecond
+
+	step := new(stepWaitForAgentPlugins)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if err := state.Get("error").(error); !strings.Contains(err.Error(), runCommandPlugin) {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// cloudInitStatusCommand waits for cloud-init to finish. Its exit status is 0
+// when cloud-init is done, 2 when it is done with recoverable errors and 1 on
+// a critical failure.
+const cloudInitStatusCommand = "cloud-init status --wait"
+
+// stepWaitForCloudInit waits for the first boot configuration by cloud-init to
+// finish, so provisioners do not race with it.
+type stepWaitForCloudInit struct{}
+
+func (s *stepWaitForCloudInit) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if !config.WaitForCloudInit {
+		return multistep.ActionContinue
+	}
+
+	comm, ok := state.GetOk("communicator")
+	if !ok || comm == nil {
+		ui.Say("No communicator, skipping wait for cloud-init...")
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Waiting for cloud-init to finish...")
+
+	if config.CloudInitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.CloudInitTimeout)
+		defer cancel()
+	}
+
+	cmd := &packersdk.RemoteCmd{Command: cloudInitStatusCommand}
+	if err := cmd.RunWithUi(ctx, comm.(packersdk.Communicator), ui); err != nil {
+		err = fmt.Errorf("Error waiting for cloud-init: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	switch status := cmd.ExitStatus(); status {
+	case 0:
+		ui.Say("cloud-init finished.")
+	case 2:
+		ui.Say("cloud-init finished with recoverable errors.")
+	default:
+		err := fmt.Errorf("Error waiting for cloud-init: %q exited with status %d", cloudInitStatusCommand, status)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepWaitForCloudInit) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func TestStepWaitForCloudInit(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).WaitForCloudInit = true
+	comm := new(packersdk.MockCommunicator)
+	state.Put("communicator", comm)
+
+	step := new(stepWaitForCloudInit)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if comm.StartCmd.Command != cloudInitStatusCommand {
+		t.Fatalf("unexpected command: %q", comm.StartCmd.Command)
+	}
+}
+
+func TestStepWaitForCloudInit_failed(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).WaitForCloudInit = true
+	state.Put("communicator", &packersdk.MockCommunicator{StartExitStatus: 1})
+
+	step := new(stepWaitForCloudInit)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// gpuDriverReadyCommand waits for the NVIDIA driver to answer and, on shapes
+// that need it to connect their GPUs, for the fabric manager to be active.
+const gpuDriverReadyCommand = `until nvidia-smi >/dev/null 2>&1; do sleep 5; done; ` +
+	`if systemctl cat nvidia-fabricmanager.service >/dev/null 2>&1; then ` +
+	`until systemctl is-active --quiet nvidia-fabricmanager.service; do sleep 5; done; fi`
+
+// stepWaitForGPUDriver waits for the GPUs of the instance to be usable, as
+// the NVIDIA driver and fabric manager take a while to come up after the
+// first boot.
+type stepWaitForGPUDriver struct{}
+
+func (s *stepWaitForGPUDriver) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if !config.WaitForGPUDriver {
+		return multistep.ActionContinue
+	}
+
+	comm, ok := state.GetOk("communicator")
+	if !ok || comm == nil {
+		ui.Say("No communicator, skipping wait for the GPU driver...")
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Waiting for the GPU driver to be ready...")
+
+	if config.GPUDriverTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.GPUDriverTimeout)
+		defer cancel()
+	}
+
+	cmd := &packersdk.RemoteCmd{Command: gpuDriverReadyCommand}
+	if err := cmd.RunWithUi(ctx, comm.(packersdk.Communicator), ui); err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("GPU driver was not ready within %s", config.GPUDriverTimeout)
+		}
+		err = fmt.Errorf("Error waiting for the GPU driver: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if status := cmd.ExitStatus(); status != 0 {
+		err := fmt.Errorf("Error waiting for the GPU driver: exited with status %d", status)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say("GPU driver is ready.")
+
+	return multistep.ActionContinue
+}
+
+func (s *stepWaitForGPUDriver) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
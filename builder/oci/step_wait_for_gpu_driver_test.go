@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func TestStepWaitForGPUDriver(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).WaitForGPUDriver = true
+	comm := new(packersdk.MockCommunicator)
+	state.Put("communicator", comm)
+
+	step := new(stepWaitForGPUDriver)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if comm.StartCmd.Command != gpuDriverReadyCommand {
+		t.Fatalf("unexpected command: %q", comm.StartCmd.Command)
+	}
+}
+
+func TestStepWaitForGPUDriver_failed(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).WaitForGPUDriver = true
+	state.Put("communicator", &packersdk.MockCommunicator{StartExitStatus: 1})
+
+	step := new(stepWaitForGPUDriver)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
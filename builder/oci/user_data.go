@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+)
+
+// maxMetadataSize is the maximum combined size in bytes of the metadata of an
+// instance, user data included.
+const maxMetadataSize = 32000
+
+// metadataSize returns the size counted against maxMetadataSize of metadata
+// holding the given encoded user data.
+func metadataSize(metadata map[string]string, userData string) int {
+	size := len(userData)
+	for k, v := range metadata {
+		if k == "user_data" && userData != "" {
+			continue
+		}
+		size += len(k) + len(v)
+	}
+	return size
+}
+
+// gzipUserData gzip compresses base64 encoded user data, which cloud-init
+// detects and decompresses at boot.
+func gzipUserData(userData string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(userData)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(raw); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
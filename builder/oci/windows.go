@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// generatedPasswordLength is the length of the passwords generated for the
+// WinRM user.
+const generatedPasswordLength = 24
+
+// Generated passwords stick to characters that need no quoting in either
+// PowerShell single-quoted strings or cmd.exe, and include every class
+// required by the default Windows password complexity policy.
+var passwordClasses = []string{
+	"ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"abcdefghijkmnopqrstuvwxyz",
+	"23456789",
+	"-_.@",
+}
+
+// generatePassword returns a random password meeting the Windows password
+// complexity requirements.
+func generatePassword() (string, error) {
+	alphabet := strings.Join(passwordClasses, "")
+	password := make([]byte, generatedPasswordLength)
+	for i := range password {
+		// Start with one character of each class
+		chars := alphabet
+		if i < len(passwordClasses) {
+			chars = passwordClasses[i]
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = chars[n.Int64()]
+	}
+
+	// Shuffle so that the classes are not in a predictable position
+	for i := len(password) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		j := n.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password), nil
+}
+
+// winrmUserData returns the base64 encoded user_data run by cloudbase-init at
+// the first boot of a Windows instance, setting the password of the WinRM user
+// if one is given and enabling a WinRM HTTPS listener if configureWinRM is set.
+func winrmUserData(configureWinRM bool, username, password string) string {
+	lines := []string{
+		"#ps1_sysnative",
+		`$ErrorActionPreference = "Stop"`,
+	}
+
+	if password != "" {
+		lines = append(lines,
+			fmt.Sprintf("net user '%s' '%s' /logonpasswordchg:no", username, password))
+	}
+
+	if configureWinRM {
+		lines = append(lines,
+			"Enable-PSRemoting -Force -SkipNetworkProfileCheck",
+			"$cert = New-SelfSignedCertificate -DnsName $env:COMPUTERNAME -CertStoreLocation Cert:\\LocalMachine\\My",
+			"Get-ChildItem WSMan:\\localhost\\Listener | Where-Object { $_.Keys -contains 'Transport=HTTPS' } | Remove-Item -Recurse -Force",
+			"New-Item -Path WSMan:\\localhost\\Listener -Transport HTTPS -Address * -CertificateThumbPrint $cert.Thumbprint -Force",
+			"Set-Item -Path WSMan:\\localhost\\Service\\Auth\\Basic -Value $true",
+			`New-NetFirewallRule -DisplayName "WinRM HTTPS" -Direction Inbound -Protocol TCP -LocalPort 5986 -Action Allow`,
+		)
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(strings.Join(lines, "\r\n") + "\r\n"))
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	password, err := generatePassword()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(password) != generatedPasswordLength {
+		t.Fatalf("unexpected password length %d", len(password))
+	}
+	for _, class := range passwordClasses {
+		if !strings.ContainsAny(password, class) {
+			t.Errorf("password %q has no character of %q", password, class)
+		}
+	}
+}
+
+func TestWinRMUserData(t *testing.T) {
+	raw, err := base64.StdEncoding.DecodeString(winrmUserData(true, "opc", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := string(raw)
+
+	for _, expected := range []string{"#ps1_sysnative\r\n", "net user 'opc' 'secret'", "-Transport HTTPS"} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("expected user_data to contain %q:\n%s", expected, script)
+		}
+	}
+}
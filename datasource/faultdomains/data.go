@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc mapstructure-to-hcl2 -type DatasourceOutput,Config
+
+// Package faultdomains contains a packersdk.Datasource implementation that
+// lists the fault domains of an availability domain, so templates can spread
+// builds across fault domains or pin a build to one of them.
+package faultdomains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	ocommon "github.com/hashicorp/packer-plugin-oracle/builder/common"
+	"github.com/hashicorp/packer-plugin-sdk/hcl2helper"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/template/config"
+	ocicommon "github.com/oracle/oci-go-sdk/v65/common"
+	ociauth "github.com/oracle/oci-go-sdk/v65/common/auth"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Config of the fault domains data source. The access configuration is read
+// the same way as by the `oracle-oci` builder.
+type Config struct {
+	// If true, use the instance principals of the instance Packer runs on
+	// rather than an API signing key. None of the other access options may
+	// be set then.
+	InstancePrincipals   bool   `mapstructure:"use_instance_principals" required:"false"`
+	AccessCfgFile        string `mapstructure:"access_cfg_file" required:"false"`
+	AccessCfgFileAccount string `mapstructure:"access_cfg_file_account" required:"false"`
+	UserID               string `mapstructure:"user_ocid" required:"false"`
+	TenancyID            string `mapstructure:"tenancy_ocid" required:"false"`
+	Region               string `mapstructure:"region" required:"false"`
+	Fingerprint          string `mapstructure:"fingerprint" required:"false"`
+	KeyFile              string `mapstructure:"key_file" required:"false"`
+	PassPhrase           string `mapstructure:"pass_phrase" required:"false"`
+
+	// The name of the availability domain to list the fault domains of,
+	// e.g. `aaaa:PHX-AD-1`.
+	AvailabilityDomain string `mapstructure:"availability_domain" required:"true"`
+	// The OCID of the compartment to list the fault domains in. Defaults to
+	// the tenancy.
+	CompartmentID string `mapstructure:"compartment_ocid" required:"false"`
+}
+
+// faultDomainLister is the part of the identity client used by the data
+// source, mocked in tests.
+type faultDomainLister interface {
+	ListFaultDomains(ctx context.Context, request identity.ListFaultDomainsRequest) (identity.ListFaultDomainsResponse, error)
+}
+
+// Datasource lists the fault domains of an availability domain.
+type Datasource struct {
+	config         Config
+	configProvider ocicommon.ConfigurationProvider
+	client         faultDomainLister
+}
+
+// DatasourceOutput is the value exposed by the fault domains data source.
+type DatasourceOutput struct {
+	// The names of the fault domains, sorted, e.g. `FAULT-DOMAIN-1`.
+	Names []string `mapstructure:"names"`
+	// The OCIDs of the fault domains, in the order of `names`.
+	IDs []string `mapstructure:"ids"`
+}
+
+func (d *Datasource) ConfigSpec() hcldec.ObjectSpec {
+	return d.config.FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Configure(raws ...interface{}) error {
+	if err := config.Decode(&d.config, nil, raws...); err != nil {
+		return err
+	}
+
+	var errs *packersdk.MultiError
+	if d.config.AvailabilityDomain == "" {
+		errs = packersdk.MultiErrorAppend(errs, errors.New("'availability_domain' must be specified"))
+	}
+
+	c := &d.config
+	if c.InstancePrincipals {
+		for _, option := range []struct {
+			key string
+			set bool
+		}{
+			{"access_cfg_file", c.AccessCfgFile != ""},
+			{"access_cfg_file_account", c.AccessCfgFileAccount != ""},
+			{"user_ocid", c.UserID != ""},
+			{"tenancy_ocid", c.TenancyID != ""},
+			{"region", c.Region != ""},
+			{"fingerprint", c.Fingerprint != ""},
+			{"key_file", c.KeyFile != ""},
+			{"pass_phrase", c.PassPhrase != ""},
+		} {
+			if option.set {
+				errs = packersdk.MultiErrorAppend(
+					errs, fmt.Errorf("%s cannot be present when use_instance_principals is set to true.", option.key))
+			}
+		}
+		if errs != nil && len(errs.Errors) > 0 {
+			return errs
+		}
+		// The provider may already be set by tests, as instance principals
+		// cannot be obtained outside of OCI.
+		if d.configProvider == nil {
+			provider, err := ociauth.InstancePrincipalConfigurationProvider()
+			if err != nil {
+				return err
+			}
+			d.configProvider = provider
+		}
+	} else {
+		if c.AccessCfgFile == "" {
+			c.AccessCfgFile, _ = ocommon.DefaultOCISettingsPath()
+		}
+		if c.AccessCfgFileAccount == "" {
+			c.AccessCfgFileAccount = "DEFAULT"
+		}
+
+		provider, err := ocommon.APIKeyConfig{
+			ConfigFile:        c.AccessCfgFile,
+			ConfigFileProfile: c.AccessCfgFileAccount,
+			UserID:            c.UserID,
+			TenancyID:         c.TenancyID,
+			Region:            c.Region,
+			Fingerprint:       c.Fingerprint,
+			KeyFile:           c.KeyFile,
+			PassPhrase:        c.PassPhrase,
+		}.ConfigurationProvider("")
+		if err != nil {
+			return err
+		}
+		if tenancyOCID, _ := provider.TenancyOCID(); tenancyOCID == "" {
+			errs = packersdk.MultiErrorAppend(errs, errors.New("'tenancy_ocid' must be specified"))
+		}
+		d.configProvider = provider
+	}
+
+	if errs != nil && len(errs.Errors) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (d *Datasource) OutputSpec() hcldec.ObjectSpec {
+	return (&DatasourceOutput{}).FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Execute() (cty.Value, error) {
+	if d.client == nil {
+		client, err := identity.NewIdentityClientWithConfigurationProvider(d.configProvider)
+		if err != nil {
+			return cty.NullVal(cty.EmptyObject), err
+		}
+		d.client = client
+	}
+
+	compartmentID := d.config.CompartmentID
+	if compartmentID == "" {
+		tenancyOCID, err := d.configProvider.TenancyOCID()
+		if err != nil {
+			return cty.NullVal(cty.EmptyObject), err
+		}
+		compartmentID = tenancyOCID
+	}
+
+	resp, err := d.client.ListFaultDomains(context.TODO(), identity.ListFaultDomainsRequest{
+		CompartmentId:      &compartmentID,
+		AvailabilityDomain: &d.config.AvailabilityDomain,
+	})
+	if err != nil {
+		return cty.NullVal(cty.EmptyObject), fmt.Errorf("Error listing fault domains of %s: %s", d.config.AvailabilityDomain, err)
+	}
+	if len(resp.Items) == 0 {
+		return cty.NullVal(cty.EmptyObject), fmt.Errorf("No fault domains found in %s", d.config.AvailabilityDomain)
+	}
+
+	faultDomains := resp.Items
+	sort.Slice(faultDomains, func(i, j int) bool {
+		return *faultDomains[i].Name < *faultDomains[j].Name
+	})
+	output := DatasourceOutput{
+		Names: make([]string, 0, len(faultDomains)),
+		IDs:   make([]string, 0, len(faultDomains)),
+	}
+	for _, fd := range faultDomains {
+		output.Names = append(output.Names, *fd.Name)
+		output.IDs = append(output.IDs, *fd.Id)
+	}
+	return hcl2helper.HCL2ValueFromConfig(output, d.OutputSpec()), nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package faultdomains
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	ocicommon "github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+type faultDomainListerMock struct {
+	request identity.ListFaultDomainsRequest
+	items   []identity.FaultDomain
+}
+
+func (m *faultDomainListerMock) ListFaultDomains(ctx context.Context, request identity.ListFaultDomainsRequest) (identity.ListFaultDomainsResponse, error) {
+	m.request = request
+	return identity.ListFaultDomainsResponse{Items: m.items}, nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestDatasource_ImplementsDatasource(t *testing.T) {
+	var raw interface{}
+	raw = &Datasource{}
+	if _, ok := raw.(packersdk.Datasource); !ok {
+		t.Fatalf("Datasource should be a data source")
+	}
+}
+
+func TestDatasource_Configure(t *testing.T) {
+	d := Datasource{configProvider: ocicommon.NewRawConfigurationProvider("ocid1.tenancy", "", "", "", "", nil)}
+	err := d.Configure(map[string]interface{}{
+		"use_instance_principals": true,
+		"region":                  "us-phoenix-1",
+	})
+	for _, expected := range []string{
+		"'availability_domain' must be specified",
+		"region cannot be present when use_instance_principals is set to true.",
+	} {
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q error, got %v", expected, err)
+		}
+	}
+}
+
+func TestDatasource_Execute(t *testing.T) {
+	client := &faultDomainListerMock{
+		items: []identity.FaultDomain{
+			{Name: stringPtr("FAULT-DOMAIN-2"), Id: stringPtr("ocid1.faultdomain.2")},
+			{Name: stringPtr("FAULT-DOMAIN-1"), Id: stringPtr("ocid1.faultdomain.1")},
+		},
+	}
+	d := Datasource{
+		configProvider: ocicommon.NewRawConfigurationProvider("ocid1.tenancy", "", "", "", "", nil),
+		client:         client,
+	}
+	if err := d.Configure(map[string]interface{}{
+		"use_instance_principals": true,
+		"availability_domain":     "aaaa:PHX-AD-1",
+	}); err != nil {
+		t.Fatalf("Unexpected error configuring data source: %s", err)
+	}
+
+	out, err := d.Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error executing data source: %s", err)
+	}
+
+	if *client.request.CompartmentId != "ocid1.tenancy" {
+		t.Errorf("Expected fault domains of the tenancy, got %s", *client.request.CompartmentId)
+	}
+	if *client.request.AvailabilityDomain != "aaaa:PHX-AD-1" {
+		t.Errorf("Expected fault domains of aaaa:PHX-AD-1, got %s", *client.request.AvailabilityDomain)
+	}
+
+	var names, ids []string
+	for _, v := range out.GetAttr("names").AsValueSlice() {
+		names = append(names, v.AsString())
+	}
+	for _, v := range out.GetAttr("ids").AsValueSlice() {
+		ids = append(ids, v.AsString())
+	}
+	if !reflect.DeepEqual(names, []string{"FAULT-DOMAIN-1", "FAULT-DOMAIN-2"}) {
+		t.Errorf("Unexpected fault domain names: %q", names)
+	}
+	if !reflect.DeepEqual(ids, []string{"ocid1.faultdomain.1", "ocid1.faultdomain.2"}) {
+		t.Errorf("Unexpected fault domain OCIDs: %q", ids)
+	}
+}
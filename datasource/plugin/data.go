@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc mapstructure-to-hcl2 -type DatasourceOutput,Config
+
+// Package plugin contains a packersdk.Datasource implementation that exposes
+// the version of the installed Oracle plugin and the options its components
+// understand, so shared templates can gate optional configuration.
+package plugin
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/packer-plugin-sdk/hcl2helper"
+	"github.com/hashicorp/packer-plugin-sdk/template/config"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/packer-plugin-oracle/builder/classic"
+	"github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	"github.com/hashicorp/packer-plugin-oracle/version"
+)
+
+// Config of the plugin data source. The data source takes no arguments.
+type Config struct{}
+
+// Datasource reports the version and capabilities of the installed plugin.
+type Datasource struct {
+	config Config
+}
+
+// DatasourceOutput is the value exposed by the plugin data source.
+type DatasourceOutput struct {
+	// The version of the plugin, without the pre-release marker, e.g. `1.1.1`.
+	Version string `mapstructure:"version"`
+	// The pre-release marker of the plugin, e.g. `dev`. Empty for final releases.
+	VersionPrerelease string `mapstructure:"version_prerelease"`
+	// The full version string of the plugin, e.g. `1.1.1-dev`.
+	FullVersion string `mapstructure:"full_version"`
+	// The configuration options understood by the `oracle-oci` builder.
+	OCIBuilderOptions []string `mapstructure:"oci_builder_options"`
+	// The configuration options understood by the `oracle-classic` builder.
+	ClassicBuilderOptions []string `mapstructure:"classic_builder_options"`
+}
+
+func (d *Datasource) ConfigSpec() hcldec.ObjectSpec {
+	return d.config.FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Configure(raws ...interface{}) error {
+	return config.Decode(&d.config, nil, raws...)
+}
+
+func (d *Datasource) OutputSpec() hcldec.ObjectSpec {
+	return (&DatasourceOutput{}).FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Execute() (cty.Value, error) {
+	output := DatasourceOutput{
+		Version:               version.PluginVersion.GetVersion(),
+		VersionPrerelease:     version.PluginVersion.GetVersionPrerelease(),
+		FullVersion:           version.PluginVersion.FormattedVersion(),
+		OCIBuilderOptions:     specKeys(new(oci.Builder).ConfigSpec()),
+		ClassicBuilderOptions: specKeys(new(classic.Builder).ConfigSpec()),
+	}
+	return hcl2helper.HCL2ValueFromConfig(output, d.OutputSpec()), nil
+}
+
+// specKeys returns the sorted top-level keys of an HCL2 object spec.
+func specKeys(spec hcldec.ObjectSpec) []string {
+	keys := make([]string, 0, len(spec))
+	for k := range spec {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"testing"
+
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/packer-plugin-oracle/version"
+)
+
+func TestDatasource_ImplementsDatasource(t *testing.T) {
+	var raw interface{}
+	raw = &Datasource{}
+	if _, ok := raw.(packersdk.Datasource); !ok {
+		t.Fatalf("Datasource should be a data source")
+	}
+}
+
+func TestDatasource_Execute(t *testing.T) {
+	var d Datasource
+	if err := d.Configure(map[string]interface{}{}); err != nil {
+		t.Fatalf("Unexpected error configuring data source: %s", err)
+	}
+
+	out, err := d.Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error executing data source: %s", err)
+	}
+
+	if v := out.GetAttr("version").AsString(); v != version.Version {
+		t.Errorf("Expected version %q, got %q", version.Version, v)
+	}
+
+	found := false
+	for _, opt := range out.GetAttr("oci_builder_options").AsValueSlice() {
+		if opt.Equals(cty.StringVal("base_image_filter")).True() {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected oci_builder_options to contain 'base_image_filter'")
+	}
+}
@@ -11,6 +11,8 @@ import (
 
 	classicbuilder "github.com/hashicorp/packer-plugin-oracle/builder/classic"
 	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	faultdomainsdata "github.com/hashicorp/packer-plugin-oracle/datasource/faultdomains"
+	plugindata "github.com/hashicorp/packer-plugin-oracle/datasource/plugin"
 	"github.com/hashicorp/packer-plugin-oracle/version"
 )
 
@@ -18,6 +20,9 @@ func main() {
 	pps := plugin.NewSet()
 	pps.RegisterBuilder("classic", new(classicbuilder.Builder))
 	pps.RegisterBuilder("oci", new(ocibuilder.Builder))
+	pps.RegisterBuilder("oci-instance", new(ocibuilder.InstanceBuilder))
+	pps.RegisterDatasource("plugin", new(plugindata.Datasource))
+	pps.RegisterDatasource("oci-fault-domains", new(faultdomainsdata.Datasource))
 	pps.SetVersion(version.PluginVersion)
 	err := pps.Run()
 	if err != nil {